@@ -0,0 +1,424 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	"os"
+)
+
+// decisionRepository is what the commands need from the Postgres repository.
+type decisionRepository interface {
+	ListDecisions(ctx context.Context, q domain.DecisionsQuery) ([]domain.DecisionRecord, error)
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error)
+	EraseUserMetadata(ctx context.Context, userID domain.UserID) error
+	StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error
+}
+
+// userRepository is what the commands need from the Postgres user
+// repository.
+type userRepository interface {
+	GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error)
+	SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *admin, args []string) error
+}
+
+var commands = []command{
+	{
+		name:    "decisions get",
+		args:    "--actor <id> --recipient <id>",
+		summary: "show the decision between two users",
+		run:     decisionsGet,
+	},
+	{
+		name:    "decisions list",
+		args:    "[--actor <id>] [--recipient <id>]",
+		summary: "list decisions made or received, newest first",
+		run:     decisionsList,
+	},
+	{
+		name:    "likers list",
+		args:    "<user>",
+		summary: "list the user's likers as the API does, from Postgres",
+		run:     likersList,
+	},
+	{
+		name:    "cache inspect",
+		args:    "<user>",
+		summary: "describe every key cached for the user",
+		run:     cacheInspect,
+	},
+	{
+		name:    "cache purge",
+		args:    "<user>",
+		summary: "drop every key cached for the user, on every replica",
+		run:     cachePurge,
+	},
+	{
+		name:    "counts reconcile",
+		args:    "<user> | --all",
+		summary: "fix cached likers counters that drifted from Postgres",
+		run:     countsReconcile,
+	},
+	{
+		name:    "user erase",
+		args:    "<user> --requested-by <name>",
+		summary: "erase every decision of the user (GDPR)",
+		run:     userErase,
+	},
+	{
+		name:    "user set-status",
+		args:    "<user> --status <status>",
+		summary: "pause, ban, delete or reinstate the user",
+		run:     userSetStatus,
+	},
+}
+
+// options are shared by every command. Read-only commands accept --dry-run
+// so scripts can pass it unconditionally.
+type options struct {
+	json   bool
+	dryRun bool
+}
+
+func newFlagSet(a *admin, name string) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	opts := &options{}
+	fs.BoolVar(&opts.json, "json", false, "print JSON")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "report what would change without changing anything")
+
+	return fs, opts
+}
+
+// parseArgs accepts flags before and after positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// userArg parses the single positional user ID of a command.
+func userArg(fs *flag.FlagSet, args []string) (domain.UserID, error) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return "", err
+	}
+
+	if len(positional) != 1 {
+		return "", fmt.Errorf("%s takes exactly one user ID", fs.Name())
+	}
+
+	return parseUserID(positional[0])
+}
+
+func decisionsGet(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "decisions get")
+	actor := fs.String("actor", "", "actor user ID")
+	recipient := fs.String("recipient", "", "recipient user ID")
+	archived := fs.Bool("archived", false, "include archived decisions")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	actorID, err := parseUserID(*actor)
+	if err != nil {
+		return fmt.Errorf("--actor: %w", err)
+	}
+	recipientID, err := parseUserID(*recipient)
+	if err != nil {
+		return fmt.Errorf("--recipient: %w", err)
+	}
+
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return err
+	}
+
+	records, err := repo.ListDecisions(ctx, domain.DecisionsQuery{ActorID: actorID, RecipientID: recipientID, IncludeArchived: *archived})
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return errors.New("decision not found")
+	}
+
+	return a.printDecisions(opts, records)
+}
+
+func decisionsList(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "decisions list")
+	actor := fs.String("actor", "", "only decisions made by this user")
+	recipient := fs.String("recipient", "", "only decisions received by this user")
+	archived := fs.Bool("archived", false, "include archived decisions")
+	limit := fs.Uint64("limit", 100, "maximum number of decisions, 0 for all")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if *actor == "" && *recipient == "" {
+		return errors.New("--actor or --recipient is required")
+	}
+
+	query := domain.DecisionsQuery{IncludeArchived: *archived, Limit: *limit}
+	if *actor != "" {
+		query.ActorID, _ = parseUserID(*actor)
+	}
+	if *recipient != "" {
+		query.RecipientID, _ = parseUserID(*recipient)
+	}
+
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return err
+	}
+
+	records, err := repo.ListDecisions(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return a.printDecisions(opts, records)
+}
+
+func likersList(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "likers list")
+	filter := fs.String("filter", string(domain.LikersFilterAll), "all, pending, matched or rejected")
+	includeExpired := fs.Bool("include-expired", false, "include likes older than LIKE_LIFETIME_DAYS")
+	limit := fs.Int("limit", 100, "maximum number of likers, 0 for all")
+	recipientID, err := userArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if !domain.LikersFilter(*filter).Valid() {
+		return fmt.Errorf("unknown filter %q", *filter)
+	}
+
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return err
+	}
+
+	query := domain.LikersQuery{
+		RecipientID:    recipientID,
+		Filter:         domain.LikersFilter(*filter),
+		IncludeExpired: *includeExpired,
+	}
+
+	var likers []domain.LikerInfo
+	for *limit == 0 || len(likers) < *limit {
+		page, next, err := repo.GetLikers(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		likers = append(likers, page...)
+		if next == nil {
+			break
+		}
+		query.Cursor = next
+	}
+
+	if *limit > 0 && len(likers) > *limit {
+		likers = likers[:*limit]
+	}
+
+	return a.printLikers(opts, likers)
+}
+
+func cacheInspect(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "cache inspect")
+	userID, err := userArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	cache, err := a.redisCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := cache.InspectUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return a.printInspect(opts, inspectResult{UserID: userID, Keys: toCacheEntryViews(entries)})
+}
+
+func cachePurge(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "cache purge")
+	userID, err := userArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	cache, err := a.redisCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := cache.InspectUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	result := purgeResult{UserID: userID, DryRun: opts.dryRun, Keys: toCacheEntryViews(entries)}
+	if !opts.dryRun {
+		purger, err := a.purger(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := purger.PurgeUser(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return a.printPurge(opts, result)
+}
+
+func countsReconcile(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "counts reconcile")
+	all := fs.Bool("all", false, "reconcile every user with a cached counter")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *all == (len(positional) == 1) || len(positional) > 1 {
+		return errors.New("counts reconcile takes either one user ID or --all")
+	}
+
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return err
+	}
+
+	cache, err := a.redisCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	reconciler := application.NewCountReconciler(repo, cache)
+
+	var checks []domain.CounterCheck
+	if *all {
+		checks, err = reconciler.ReconcileAll(ctx, opts.dryRun)
+	} else {
+		var recipientID domain.UserID
+		if recipientID, err = parseUserID(positional[0]); err == nil {
+			checks, err = reconciler.Reconcile(ctx, recipientID, opts.dryRun)
+		}
+	}
+
+	// Print what was checked before failing, as earlier counters may have
+	// been repaired already.
+	if printErr := a.printCounterChecks(opts, checks); printErr != nil && err == nil {
+		err = printErr
+	}
+
+	return err
+}
+
+func userErase(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "user erase")
+	requestedBy := fs.String("requested-by", os.Getenv("USER"), "who asked for the erasure, for the audit log")
+	reason := fs.String("reason", "", "why the user is erased, for the audit log")
+	userID, err := userArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *requestedBy == "" {
+		return errors.New("--requested-by is required")
+	}
+
+	manager, err := a.userDataManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	var result domain.ErasureResult
+	if opts.dryRun {
+		result, err = manager.PreviewErasure(ctx, userID)
+	} else {
+		result, err = manager.EraseUser(ctx, userID, *requestedBy, *reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.printErasure(opts, eraseResult{
+		UserID:            userID,
+		DryRun:            opts.dryRun,
+		DecisionsDeleted:  result.DecisionsDeleted,
+		CountersRecounted: result.CountersRecounted,
+	})
+}
+
+func userSetStatus(ctx context.Context, a *admin, args []string) error {
+	fs, opts := newFlagSet(a, "user set-status")
+	status := fs.String("status", "", "active, paused, banned or deleted")
+	requestedBy := fs.String("requested-by", os.Getenv("USER"), "who asked for the change, for the audit log")
+	reason := fs.String("reason", "", "why the status changes, for the audit log")
+	userID, err := userArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if !domain.UserStatus(*status).Valid() {
+		return fmt.Errorf("unknown status %q", *status)
+	}
+	if *requestedBy == "" {
+		return errors.New("--requested-by is required")
+	}
+
+	manager, err := a.userStatusManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous, err := manager.GetStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !opts.dryRun {
+		if err := manager.SetStatus(ctx, userID, domain.UserStatus(*status), *requestedBy, *reason); err != nil {
+			return err
+		}
+	}
+
+	return a.printStatus(opts, statusResult{
+		UserID:   userID,
+		DryRun:   opts.dryRun,
+		Previous: previous,
+		Status:   domain.UserStatus(*status),
+	})
+}
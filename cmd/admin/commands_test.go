@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"muzz-homework/internal/explore/domain"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+type mockDecisionRepository struct {
+	counts    map[domain.UserID]uint64
+	decisions []domain.DecisionRecord
+	erased    bool
+}
+
+func (m *mockDecisionRepository) ListDecisions(ctx context.Context, q domain.DecisionsQuery) ([]domain.DecisionRecord, error) {
+	return m.decisions, nil
+}
+
+func (m *mockDecisionRepository) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (m *mockDecisionRepository) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	return m.counts[query.RecipientID], nil
+}
+
+func (m *mockDecisionRepository) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	return domain.SeenWatermark{}, nil
+}
+
+func (m *mockDecisionRepository) EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+	m.erased = true
+	return 0, nil, nil
+}
+
+func (m *mockDecisionRepository) EraseUserMetadata(ctx context.Context, userID domain.UserID) error {
+	m.erased = true
+	return nil
+}
+
+func (m *mockDecisionRepository) StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+	for _, record := range m.decisions {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTestAdmin returns an admin backed by miniredis and the given repository,
+// with its output captured in out.
+func newTestAdmin(t *testing.T, repo decisionRepository) (*admin, *miniredis.Miniredis, *bytes.Buffer) {
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	out := &bytes.Buffer{}
+	a := &admin{
+		out:    out,
+		stderr: io.Discard,
+		repo:   repo,
+		redis:  client,
+		cache:  infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: "test", TTL: time.Minute}),
+	}
+
+	return a, server, out
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantJSON       bool
+		wantDryRun     bool
+		wantErr        bool
+	}{
+		{
+			name: "no arguments",
+		},
+		{
+			name:           "positional only",
+			args:           []string{"user1", "user2"},
+			wantPositional: []string{"user1", "user2"},
+		},
+		{
+			name:           "flags before positional",
+			args:           []string{"--json", "--dry-run", "user1"},
+			wantPositional: []string{"user1"},
+			wantJSON:       true,
+			wantDryRun:     true,
+		},
+		{
+			name:           "flags after positional",
+			args:           []string{"user1", "--json"},
+			wantPositional: []string{"user1"},
+			wantJSON:       true,
+		},
+		{
+			name:           "flags between positional",
+			args:           []string{"user1", "--dry-run", "user2"},
+			wantPositional: []string{"user1", "user2"},
+			wantDryRun:     true,
+		},
+		{
+			name:           "double dash stops flag parsing",
+			args:           []string{"--", "--json"},
+			wantPositional: []string{"--json"},
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"user1", "--force"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, opts := newFlagSet(&admin{stderr: io.Discard}, "test")
+
+			positional, err := parseArgs(fs, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPositional, positional)
+			assert.Equal(t, tt.wantJSON, opts.json)
+			assert.Equal(t, tt.wantDryRun, opts.dryRun)
+		})
+	}
+}
+
+func TestUserArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    domain.UserID
+		wantErr string
+	}{
+		{
+			name: "legacy ID kept verbatim",
+			args: []string{"user1"},
+			want: "user1",
+		},
+		{
+			name: "UUID canonicalised",
+			args: []string{"--json", "6F9619FF-8B86-D011-B42D-00C04FC964FF"},
+			want: "6f9619ff-8b86-d011-b42d-00c04fc964ff",
+		},
+		{
+			name:    "missing",
+			args:    []string{"--json"},
+			wantErr: "test takes exactly one user ID",
+		},
+		{
+			name:    "too many",
+			args:    []string{"user1", "user2"},
+			wantErr: "test takes exactly one user ID",
+		},
+		{
+			name:    "empty",
+			args:    []string{""},
+			wantErr: "user ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, _ := newFlagSet(&admin{stderr: io.Discard}, "test")
+
+			got, err := userArg(fs, tt.args)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCachePurge(t *testing.T) {
+	tests := []struct {
+		name     string
+		dryRun   bool
+		wantKeys []string
+	}{
+		{
+			name:     "dry run keeps keys",
+			dryRun:   true,
+			wantKeys: []string{"test:count:{user1}", "test:count:{user2}"},
+		},
+		{
+			name:     "purge drops only the user's keys",
+			wantKeys: []string{"test:count:{user2}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			a, server, out := newTestAdmin(t, nil)
+			for _, id := range []domain.UserID{"user1", "user2"} {
+				require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: id}, 1, 0))
+			}
+
+			args := []string{"user1", "--json"}
+			if tt.dryRun {
+				args = append(args, "--dry-run")
+			}
+			require.NoError(t, cachePurge(ctx, a, args))
+
+			var result purgeResult
+			require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+			assert.Equal(t, domain.UserID("user1"), result.UserID)
+			assert.Equal(t, tt.dryRun, result.DryRun)
+			require.Len(t, result.Keys, 1)
+			assert.Equal(t, "test:count:{user1}", result.Keys[0].Key)
+			assert.ElementsMatch(t, tt.wantKeys, server.Keys())
+		})
+	}
+}
+
+func TestCountsReconcile_DryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockDecisionRepository{counts: map[domain.UserID]uint64{"user1": 3, "user2": 2}}
+	a, _, out := newTestAdmin(t, repo)
+	require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1"}, 5, 0))
+	require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user2"}, 2, 0))
+
+	require.NoError(t, countsReconcile(ctx, a, []string{"--all", "--dry-run", "--json"}))
+
+	var result struct {
+		Counters []counterView `json:"counters"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, []counterView{
+		{RecipientID: "user1", Cached: 5, Actual: 3, Drifted: true},
+		{RecipientID: "user2", Cached: 2, Actual: 2},
+	}, result.Counters)
+
+	cached, err := a.cache.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), cached)
+}
+
+func TestCountsReconcile_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "neither user nor --all"},
+		{name: "user and --all", args: []string{"user1", "--all"}},
+		{name: "two users", args: []string{"user1", "user2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, _ := newTestAdmin(t, &mockDecisionRepository{})
+
+			err := countsReconcile(context.Background(), a, tt.args)
+			assert.EqualError(t, err, "counts reconcile takes either one user ID or --all")
+		})
+	}
+}
+
+func TestUserErase_DryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockDecisionRepository{decisions: []domain.DecisionRecord{
+		{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+		{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionPass},
+		{ActorID: "user4", RecipientID: "user1", Decision: domain.DecisionLike},
+		{ActorID: "user1", RecipientID: "user5", Decision: domain.DecisionLike, Archived: true},
+	}}
+	a, server, out := newTestAdmin(t, repo)
+	require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1"}, 1, 0))
+
+	require.NoError(t, userErase(ctx, a, []string{"user1", "--requested-by", "ops", "--dry-run"}))
+
+	assert.Equal(t, "would erase 3 decisions of user1 and recount 1 likers counters\n", out.String())
+	assert.False(t, repo.erased)
+	assert.Equal(t, []string{"test:count:{user1}"}, server.Keys())
+}
+
+func TestUserErase_RequiresRequester(t *testing.T) {
+	a, _, _ := newTestAdmin(t, &mockDecisionRepository{})
+
+	err := userErase(context.Background(), a, []string{"user1", "--requested-by", ""})
+	assert.EqualError(t, err, "--requested-by is required")
+}
+
+type mockUserRepository struct {
+	users map[domain.UserID]domain.User
+}
+
+func (m *mockUserRepository) GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+	return m.users, nil
+}
+
+func (m *mockUserRepository) SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error {
+	m.users[userID] = domain.User{ID: userID, Status: status}
+	return nil
+}
+
+func TestUserSetStatus(t *testing.T) {
+	ctx := context.Background()
+	users := &mockUserRepository{users: map[domain.UserID]domain.User{}}
+	repo := &mockDecisionRepository{decisions: []domain.DecisionRecord{
+		{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+	}}
+	a, server, out := newTestAdmin(t, repo)
+	a.users = users
+	require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user2"}, 1, 0))
+	require.NoError(t, a.cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1"}, 1, 0))
+
+	require.NoError(t, userSetStatus(ctx, a, []string{"user1", "--status", "banned", "--requested-by", "ops", "--dry-run"}))
+	assert.Equal(t, "would set status of user1 to banned (is active)\n", out.String())
+	assert.Empty(t, users.users)
+	assert.ElementsMatch(t, []string{"test:count:{user1}", "test:count:{user2}"}, server.Keys())
+
+	out.Reset()
+	require.NoError(t, userSetStatus(ctx, a, []string{"user1", "--status", "banned", "--requested-by", "ops"}))
+	assert.Equal(t, "set status of user1 to banned (was active)\n", out.String())
+	assert.Equal(t, domain.UserStatusBanned, users.users["user1"].Status)
+	assert.Empty(t, server.Keys(), "user1's data and the likers user1 was in are purged")
+}
+
+func TestUserSetStatus_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unknown status",
+			args:    []string{"user1", "--status", "frozen", "--requested-by", "ops"},
+			wantErr: `unknown status "frozen"`,
+		},
+		{
+			name:    "no requester",
+			args:    []string{"user1", "--status", "paused", "--requested-by", ""},
+			wantErr: "--requested-by is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, _ := newTestAdmin(t, &mockDecisionRepository{})
+			a.users = &mockUserRepository{users: map[domain.UserID]domain.User{}}
+
+			err := userSetStatus(context.Background(), a, tt.args)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"io"
+	"log/slog"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgre "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"muzz-homework/pkg/postgres"
+	"muzz-homework/pkg/redis"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// admin holds the connections, opened on first use so that cache commands
+// don't need Postgres and the other way round.
+type admin struct {
+	out    io.Writer
+	stderr io.Writer
+
+	db    *sql.DB
+	repo  decisionRepository
+	users userRepository
+	redis goredis.UniversalClient
+	cache *infraRedis.RedisCache
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	a := &admin{out: os.Stdout, stderr: os.Stderr}
+	defer a.close()
+
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		a.close()
+		os.Exit(1)
+	}
+}
+
+func (a *admin) run(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		a.usage()
+		return flag.ErrHelp
+	}
+
+	name := args[0] + " " + args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(ctx, a, args[2:])
+		}
+	}
+
+	a.usage()
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func (a *admin) usage() {
+	fmt.Fprintln(a.stderr, "usage: admin <command> [flags] [args]")
+	fmt.Fprintln(a.stderr, "\nEvery command takes --json for JSON output and --dry-run, which makes no changes.")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-50s %s\n", cmd.name+" "+cmd.args, cmd.summary)
+	}
+}
+
+func (a *admin) postgres() (*sql.DB, error) {
+	if a.db == nil {
+		db, err := postgres.NewSQLDB()
+		if err != nil {
+			return nil, fmt.Errorf("connecting to postgres: %w", err)
+		}
+		a.db = db
+	}
+
+	return a.db, nil
+}
+
+func (a *admin) decisionRepo() (decisionRepository, error) {
+	if a.repo != nil {
+		return a.repo, nil
+	}
+
+	db, err := a.postgres()
+	if err != nil {
+		return nil, err
+	}
+
+	a.repo = infraPostgre.NewDecisionRepository(db, infraPostgre.DecisionRepositoryConfig{
+		LikeLifetime: time.Duration(getEnvIntOrDefault("LIKE_LIFETIME_DAYS", 0)) * 24 * time.Hour,
+	})
+
+	return a.repo, nil
+}
+
+func (a *admin) userRepo() (userRepository, error) {
+	if a.users != nil {
+		return a.users, nil
+	}
+
+	db, err := a.postgres()
+	if err != nil {
+		return nil, err
+	}
+
+	a.users = infraPostgre.NewUserRepository(db)
+
+	return a.users, nil
+}
+
+// redisCache is configured like the API's, so it reads and writes the same
+// keys. XFetch is disabled, as early refreshes would read as misses.
+func (a *admin) redisCache(ctx context.Context) (*infraRedis.RedisCache, error) {
+	if a.cache != nil {
+		return a.cache, nil
+	}
+
+	config, err := redis.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := redis.NewUniversalClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating redis client: %w", err)
+	}
+	a.redis = client
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+
+	a.cache = infraRedis.NewRedisCache(client, infraRedis.RedisConfig{
+		Prefix:               getEnvOrDefault("REDIS_PREFIX", "muzz"),
+		TTL:                  time.Duration(getEnvIntOrDefault("REDIS_TTL_SECONDS", 900)) * time.Second,
+		Encoding:             infraRedis.CacheEncoding(getEnvOrDefault("REDIS_CACHE_ENCODING", string(infraRedis.EncodingBinary))),
+		CompressionThreshold: getEnvIntOrDefault("REDIS_COMPRESSION_THRESHOLD_BYTES", 1024),
+	})
+
+	return a.cache, nil
+}
+
+// purger drops a user's Redis keys and tells every API replica to drop its
+// local copies too.
+func (a *admin) purger(ctx context.Context) (*infraRedis.TieredCache, error) {
+	cache, err := a.redisCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return infraRedis.NewTieredCache(cache, infraRedis.LocalCacheConfig{Size: 1, TTL: time.Second}), nil
+}
+
+func (a *admin) userDataManager(ctx context.Context) (*application.UserDataManager, error) {
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := a.purger(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only ResetUser is used, so the window doesn't need to match the API's.
+	counters := infraRedis.NewAbuseCounters(a.redis, infraRedis.AbuseCountersConfig{
+		Prefix: getEnvOrDefault("REDIS_PREFIX", "muzz"),
+	})
+
+	audit := slog.New(slog.NewJSONHandler(a.stderr, nil)).With("component", "audit")
+
+	return application.NewUserDataManager(repo, cache, counters, audit,
+		uint64(getEnvIntOrDefault("USER_ERASE_BATCH_SIZE", 1000))), nil
+}
+
+func (a *admin) userStatusManager(ctx context.Context) (*application.UserStatusManager, error) {
+	users, err := a.userRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := a.decisionRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := a.purger(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := slog.New(slog.NewJSONHandler(a.stderr, nil)).With("component", "audit")
+
+	return application.NewUserStatusManager(users, repo, cache, audit), nil
+}
+
+func (a *admin) close() {
+	if a.db != nil {
+		a.db.Close()
+		a.db = nil
+	}
+	if a.redis != nil {
+		a.redis.Close()
+		a.redis = nil
+	}
+}
+
+// parseUserID canonicalises UUIDs and keeps anything else verbatim, so legacy
+// rows with non-UUID IDs can still be inspected and repaired.
+func parseUserID(value string) (domain.UserID, error) {
+	if value == "" {
+		return "", errors.New("user ID is required")
+	}
+
+	if id, err := domain.ParseUserID(value); err == nil {
+		return id, nil
+	}
+
+	return domain.UserID(value), nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		fmt.Fprintf(os.Stderr, "invalid %s value, using default: %v\n", key, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"text/tabwriter"
+	"time"
+)
+
+type decisionView struct {
+	ActorID       domain.UserID `json:"actor_user_id"`
+	RecipientID   domain.UserID `json:"recipient_user_id"`
+	Decision      string        `json:"decision"`
+	UnixTimestamp uint64        `json:"unix_timestamp"`
+	Archived      bool          `json:"archived"`
+}
+
+type likerView struct {
+	ActorID       domain.UserID `json:"actor_user_id"`
+	Decision      string        `json:"decision"`
+	UnixTimestamp uint64        `json:"unix_timestamp"`
+}
+
+type cacheEntryView struct {
+	Key     string `json:"key"`
+	Type    string `json:"type"`
+	TTLMs   int64  `json:"ttl_ms"`
+	Summary string `json:"summary"`
+}
+
+type counterView struct {
+	RecipientID    domain.UserID `json:"recipient_user_id"`
+	SeenUpTo       *uint64       `json:"seen_up_to"`
+	SeenActorID    domain.UserID `json:"seen_actor_user_id,omitempty"`
+	IncludeExpired bool          `json:"include_expired"`
+	Cached         uint64        `json:"cached"`
+	Actual         uint64        `json:"actual"`
+	Drifted        bool          `json:"drifted"`
+	Repaired       bool          `json:"repaired"`
+}
+
+type inspectResult struct {
+	UserID domain.UserID    `json:"user_id"`
+	Keys   []cacheEntryView `json:"keys"`
+}
+
+type purgeResult struct {
+	UserID domain.UserID    `json:"user_id"`
+	DryRun bool             `json:"dry_run"`
+	Keys   []cacheEntryView `json:"purged_keys"`
+}
+
+type eraseResult struct {
+	UserID            domain.UserID `json:"user_id"`
+	DryRun            bool          `json:"dry_run"`
+	DecisionsDeleted  uint64        `json:"decisions_deleted"`
+	CountersRecounted uint64        `json:"counters_recounted"`
+}
+
+type statusResult struct {
+	UserID   domain.UserID     `json:"user_id"`
+	DryRun   bool              `json:"dry_run"`
+	Previous domain.UserStatus `json:"previous_status"`
+	Status   domain.UserStatus `json:"status"`
+}
+
+func toCacheEntryViews(entries []infraRedis.CacheEntry) []cacheEntryView {
+	views := make([]cacheEntryView, 0, len(entries))
+	for _, entry := range entries {
+		ttl := int64(-1)
+		if entry.TTL >= 0 {
+			ttl = entry.TTL.Milliseconds()
+		}
+		views = append(views, cacheEntryView{Key: entry.Key, Type: entry.Type, TTLMs: ttl, Summary: entry.Summary})
+	}
+
+	return views
+}
+
+func (a *admin) printJSON(v any) error {
+	encoder := json.NewEncoder(a.out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func (a *admin) table(header string) *tabwriter.Writer {
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, header)
+
+	return w
+}
+
+func (a *admin) printDecisions(opts *options, records []domain.DecisionRecord) error {
+	views := make([]decisionView, 0, len(records))
+	for _, record := range records {
+		views = append(views, decisionView{
+			ActorID:       record.ActorID,
+			RecipientID:   record.RecipientID,
+			Decision:      record.Decision.String(),
+			UnixTimestamp: record.Timestamp,
+			Archived:      record.Archived,
+		})
+	}
+
+	if opts.json {
+		return a.printJSON(struct {
+			Decisions []decisionView `json:"decisions"`
+		}{views})
+	}
+
+	w := a.table("ACTOR\tRECIPIENT\tDECISION\tTIME\tARCHIVED")
+	for _, v := range views {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", v.ActorID, v.RecipientID, v.Decision, formatUnix(v.UnixTimestamp), v.Archived)
+	}
+
+	return w.Flush()
+}
+
+func (a *admin) printLikers(opts *options, likers []domain.LikerInfo) error {
+	views := make([]likerView, 0, len(likers))
+	for _, liker := range likers {
+		views = append(views, likerView{
+			ActorID:       liker.ActorID,
+			Decision:      liker.Decision.String(),
+			UnixTimestamp: liker.Timestamp,
+		})
+	}
+
+	if opts.json {
+		return a.printJSON(struct {
+			Likers []likerView `json:"likers"`
+		}{views})
+	}
+
+	w := a.table("LIKER\tDECISION\tTIME")
+	for _, v := range views {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.ActorID, v.Decision, formatUnix(v.UnixTimestamp))
+	}
+
+	return w.Flush()
+}
+
+func (a *admin) printInspect(opts *options, result inspectResult) error {
+	if opts.json {
+		return a.printJSON(result)
+	}
+
+	return a.printCacheEntries(result.Keys)
+}
+
+func (a *admin) printPurge(opts *options, result purgeResult) error {
+	if opts.json {
+		return a.printJSON(result)
+	}
+
+	if err := a.printCacheEntries(result.Keys); err != nil {
+		return err
+	}
+
+	verb := "purged"
+	if result.DryRun {
+		verb = "would purge"
+	}
+	_, err := fmt.Fprintf(a.out, "%s %d keys of %s\n", verb, len(result.Keys), result.UserID)
+
+	return err
+}
+
+func (a *admin) printCacheEntries(entries []cacheEntryView) error {
+	w := a.table("KEY\tTYPE\tTTL\tSUMMARY")
+	for _, v := range entries {
+		ttl := "none"
+		if v.TTLMs >= 0 {
+			ttl = (time.Duration(v.TTLMs) * time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.Key, v.Type, ttl, v.Summary)
+	}
+
+	return w.Flush()
+}
+
+func (a *admin) printCounterChecks(opts *options, checks []domain.CounterCheck) error {
+	views := make([]counterView, 0, len(checks))
+	for _, check := range checks {
+		view := counterView{
+			RecipientID:    check.Query.RecipientID,
+			IncludeExpired: check.Query.IncludeExpired,
+			Cached:         check.Cached,
+			Actual:         check.Actual,
+			Drifted:        check.Drifted(),
+			Repaired:       check.Repaired,
+		}
+		if seen := check.Query.SeenUpTo; seen != nil {
+			view.SeenUpTo = &seen.Timestamp
+			view.SeenActorID = seen.ActorID
+		}
+		views = append(views, view)
+	}
+
+	if opts.json {
+		return a.printJSON(struct {
+			Counters []counterView `json:"counters"`
+		}{views})
+	}
+
+	w := a.table("RECIPIENT\tCOUNTER\tEXPIRED\tCACHED\tACTUAL\tSTATUS")
+	for _, v := range views {
+		counter := "total"
+		if v.SeenUpTo != nil {
+			counter = fmt.Sprintf("unseen since %d", *v.SeenUpTo)
+			if v.SeenActorID != "" {
+				counter += " after " + string(v.SeenActorID)
+			}
+		}
+
+		status := "ok"
+		switch {
+		case v.Repaired:
+			status = "repaired"
+		case v.Drifted:
+			status = "drifted"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%s\n", v.RecipientID, counter, v.IncludeExpired, v.Cached, v.Actual, status)
+	}
+
+	return w.Flush()
+}
+
+func (a *admin) printErasure(opts *options, result eraseResult) error {
+	if opts.json {
+		return a.printJSON(result)
+	}
+
+	format := "erased %d decisions of %s and recounted %d likers counters\n"
+	if result.DryRun {
+		format = "would erase %d decisions of %s and recount %d likers counters\n"
+	}
+	_, err := fmt.Fprintf(a.out, format, result.DecisionsDeleted, result.UserID, result.CountersRecounted)
+
+	return err
+}
+
+func (a *admin) printStatus(opts *options, result statusResult) error {
+	if opts.json {
+		return a.printJSON(result)
+	}
+
+	format := "set status of %s to %s (was %s)\n"
+	if result.DryRun {
+		format = "would set status of %s to %s (is %s)\n"
+	}
+	_, err := fmt.Fprintf(a.out, format, result.UserID, result.Status, result.Previous)
+
+	return err
+}
+
+func formatUnix(ts uint64) string {
+	return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+}
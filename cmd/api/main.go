@@ -3,23 +3,49 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"github.com/labstack/gommon/log"
-	goredis "github.com/redis/go-redis/v9"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"log/slog"
 	"muzz-homework/internal/explore/adapters/grpc"
+	httpGateway "muzz-homework/internal/explore/adapters/http"
 	"muzz-homework/internal/explore/application"
-	infraPostgre "muzz-homework/internal/explore/infrastructure/postgres"
-	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
-	"muzz-homework/pkg/postgres"
+	"muzz-homework/internal/explore/domain"
+	pb "muzz-homework/pkg/proto"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 )
 
-const port = "8000"
+const (
+	port        = "8000"
+	httpPort    = "8080"
+	metricsPort = "9090"
+
+	sweepModeArchive = "archive"
+	sweepModeDelete  = "delete"
+)
+
+// exploreCache is what the application services need from the cache, served
+// either by Redis alone or by the in-process tier in front of it.
+type exploreCache interface {
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	PurgeUser(ctx context.Context, userID domain.UserID) error
+}
 
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -28,42 +54,156 @@ func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	slog.SetDefault(logger)
 
-	sqlDB, err := postgres.NewSQLDB()
+	storageMode := flag.String("storage", getEnvOrDefault("STORAGE", storagePostgres),
+		"where decisions and cached listings live: postgres (with redis) or memory")
+	flag.Parse()
+
+	likeLifetime := time.Duration(getEnvIntOrDefault("LIKE_LIFETIME_DAYS", 0)) * 24 * time.Hour
+
+	var store storage
+	var err error
+	switch *storageMode {
+	case storagePostgres:
+		store, err = newPostgresStorage(ctx, likeLifetime)
+	case storageMemory:
+		log.Warnf("running with in-memory storage, nothing is persisted")
+		store, err = newMemoryStorage(ctx, likeLifetime)
+	default:
+		err = fmt.Errorf("unknown storage %q, expected %s or %s", *storageMode, storagePostgres, storageMemory)
+	}
 	if err != nil {
-		log.Fatalf("failed to create db err: %v", err)
+		log.Fatalf("failed to set up storage: %v", err)
 		return
 	}
+	defer store.close()
 
-	ttlStr := getEnvOrDefault("REDIS_TTL_SECONDS", "900")
-	ttlSeconds, err := strconv.Atoi(ttlStr)
+	tokenTTLSeconds := getEnvIntOrDefault("PAGINATION_TOKEN_TTL_SECONDS", 3600)
+
+	signingKeys, err := parseSigningKeys(os.Getenv("PAGINATION_TOKEN_KEYS"))
 	if err != nil {
-		log.Warnf("invalid REDIS_TTL_SECONDS value, using default: %v", err)
-		ttlSeconds = 900
+		log.Fatalf("invalid PAGINATION_TOKEN_KEYS: %v", err)
+		return
 	}
-	ttl := time.Duration(ttlSeconds) * time.Second
 
-	redisClient := goredis.NewClient(&goredis.Options{
-		Addr:     getEnvOrDefault("REDIS_ADDR", "redis:6379"),
-		Password: getEnvOrDefault("REDIS_PASSWORD", ""),
-		DB:       0,
-	})
-	defer redisClient.Close()
+	tokenCodec, err := domain.NewTokenCodec(signingKeys, time.Duration(tokenTTLSeconds)*time.Second)
+	if err != nil {
+		log.Fatalf("failed to create pagination token codec: %v", err)
+		return
+	}
 
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		log.Fatalf("failed to connect to redis: %v", err)
+	decisionProvider := application.NewDecisionProvider(store.decisions, store.cache, store.profiles, tokenCodec)
+	superLikeLimit, err := getEnvPositiveInt("SUPER_LIKE_DAILY_LIMIT", 5)
+	if err != nil {
+		log.Fatalf("invalid super-like quota: %v", err)
 		return
 	}
 
-	decisionRepo := infraPostgre.NewDecisionRepository(sqlDB)
-	redisCache := infraRedis.NewRedisCache(redisClient, infraRedis.RedisConfig{
-		Prefix: getEnvOrDefault("REDIS_PREFIX", "muzz"),
-		TTL:    ttl,
-	})
+	abuseDetector := application.NewAbuseDetector(store.abuseCounters, store.abuseFlags, store.decisions, store.cache, abuseDetectorConfig(),
+		logger.With("component", "audit"))
+	decisionCreator := application.NewDecisionCreator(store.decisions, store.users, store.likerIndex, abuseDetector, application.DecisionCreatorConfig{
+		SuperLikeDailyLimit: uint64(superLikeLimit),
+		AllowUnknownUsers:   getEnvOrDefault("ALLOW_UNKNOWN_USERS", "false") == "true",
+	}, logger)
+
+	candidateProvider := application.NewCandidateProvider(store.candidates)
+
+	var likeSweeper *application.LikeSweeper
+	if likeLifetime > 0 {
+		sweeperConfig, err := likeSweeperConfig(likeLifetime)
+		if err != nil {
+			log.Fatalf("invalid like sweeper config: %v", err)
+			return
+		}
+
+		likeSweeper, err = application.NewLikeSweeper(store.decisions, sweeperConfig, logger)
+		if err != nil {
+			log.Fatalf("failed to create like sweeper: %v", err)
+			return
+		}
+	}
+
+	var cacheWarmer *application.CacheWarmer
+	warmupTopN := getEnvIntOrDefault("CACHE_WARMUP_TOP_N", 1000)
+	if warmupTopN > 0 {
+		interval, err := getEnvPositiveInt("CACHE_WARMUP_INTERVAL_SECONDS", 300)
+		if err != nil {
+			log.Fatalf("invalid cache warmer config: %v", err)
+			return
+		}
+
+		cacheWarmer, err = application.NewCacheWarmer(store.decisions, decisionProvider, store.warmupLock, application.CacheWarmerConfig{
+			TopN:                uint64(warmupTopN),
+			Window:              time.Duration(getEnvIntOrDefault("CACHE_WARMUP_WINDOW_HOURS", 24)) * time.Hour,
+			Interval:            time.Duration(interval) * time.Second,
+			RecipientsPerSecond: float64(getEnvIntOrDefault("CACHE_WARMUP_RECIPIENTS_PER_SECOND", 50)),
+		}, logger.With("component", "cache_warmer"))
+		if err != nil {
+			log.Fatalf("failed to create cache warmer: %v", err)
+			return
+		}
+	}
 
-	decisionProvider := application.NewDecisionProvider(decisionRepo, redisCache)
-	decisionCreator := application.NewDecisionCreator(decisionRepo)
+	userDataManager := application.NewUserDataManager(store.decisions, store.cache, store.abuseCounters, logger.With("component", "audit"),
+		uint64(getEnvIntOrDefault("USER_ERASE_BATCH_SIZE", 1000)))
 
-	grpcServer := grpc.NewGRPCServer(port, decisionProvider, decisionCreator, logger)
+	adminTokens, err := parseAdminTokens(os.Getenv("GRPC_ADMIN_TOKENS"))
+	if err != nil {
+		log.Fatalf("invalid GRPC_ADMIN_TOKENS: %v", err)
+		return
+	}
+
+	adminTimeout := time.Duration(getEnvIntOrDefault("GRPC_ADMIN_TIMEOUT_SECONDS", 600)) * time.Second
+	serverConfig := grpc.ServerConfig{
+		TLS: grpc.TLSConfig{
+			CertFile:     getEnvOrDefault("GRPC_TLS_CERT_FILE", ""),
+			KeyFile:      getEnvOrDefault("GRPC_TLS_KEY_FILE", ""),
+			ClientCAFile: getEnvOrDefault("GRPC_TLS_CLIENT_CA_FILE", ""),
+		},
+		Reflection:                   getEnvOrDefault("GRPC_REFLECTION", "false") == "true",
+		KeepaliveMinTime:             time.Duration(getEnvIntOrDefault("GRPC_KEEPALIVE_MIN_TIME_SECONDS", 10)) * time.Second,
+		KeepalivePermitWithoutStream: getEnvOrDefault("GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM", "false") == "true",
+		KeepaliveTime:                time.Duration(getEnvIntOrDefault("GRPC_KEEPALIVE_TIME_SECONDS", 60)) * time.Second,
+		KeepaliveTimeout:             time.Duration(getEnvIntOrDefault("GRPC_KEEPALIVE_TIMEOUT_SECONDS", 20)) * time.Second,
+		MaxRecvMsgSize:               getEnvIntOrDefault("GRPC_MAX_RECV_MSG_BYTES", 4<<20),
+		MaxSendMsgSize:               getEnvIntOrDefault("GRPC_MAX_SEND_MSG_BYTES", 4<<20),
+		MaxConcurrentStreams:         uint32(getEnvIntOrDefault("GRPC_MAX_CONCURRENT_STREAMS", 0)),
+		MaxConnectionAge:             time.Duration(getEnvIntOrDefault("GRPC_MAX_CONNECTION_AGE_SECONDS", 0)) * time.Second,
+		MaxConnectionAgeGrace:        time.Duration(getEnvIntOrDefault("GRPC_MAX_CONNECTION_AGE_GRACE_SECONDS", 0)) * time.Second,
+		Deadlines: grpc.DeadlineConfig{
+			Default: time.Duration(getEnvIntOrDefault("GRPC_DEFAULT_TIMEOUT_MS", 5000)) * time.Millisecond,
+			PerMethod: map[string]time.Duration{
+				pb.ExploreService_EraseUser_FullMethodName:      adminTimeout,
+				pb.ExploreService_ExportUserData_FullMethodName: adminTimeout,
+			},
+		},
+		Admin: grpc.AdminConfig{
+			Tokens: adminTokens,
+		},
+	}
+
+	grpcServer, err := grpc.NewGRPCServer(port, serverConfig, decisionProvider, decisionCreator, candidateProvider, userDataManager, abuseDetector, logger)
+	if err != nil {
+		log.Fatalf("failed to create grpc server: %v", err)
+		return
+	}
+
+	gatewayCreds := insecure.NewCredentials()
+	if serverConfig.TLS.Enabled() {
+		gatewayCreds, err = gatewayCredentials()
+		if err != nil {
+			log.Fatalf("failed to load gateway TLS credentials: %v", err)
+			return
+		}
+	}
+
+	gatewayConn, err := httpGateway.NewGRPCClient(fmt.Sprintf("localhost:%s", port), gatewayCreds)
+	if err != nil {
+		log.Fatalf("failed to create gateway client: %v", err)
+		return
+	}
+	defer gatewayConn.Close()
+
+	gateway := httpGateway.NewGateway(httpPort, pb.NewExploreServiceClient(gatewayConn), logger)
 
 	group, ctx := errgroup.WithContext(ctx)
 	group.Go(func() error {
@@ -71,6 +211,45 @@ func main() {
 		return grpcServer.Run()
 	})
 
+	group.Go(func() error {
+		log.Infof("starting http gateway on: %v", httpPort)
+		return gateway.Run()
+	})
+
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", metricsPort),
+		Handler:           promhttp.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	group.Go(func() error {
+		log.Infof("starting metrics server on: %v", metricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+
+	if store.tieredCache != nil {
+		group.Go(func() error {
+			return store.tieredCache.Run(ctx)
+		})
+	}
+
+	if likeSweeper != nil {
+		group.Go(func() error {
+			log.Infof("starting like sweeper, lifetime: %v", likeLifetime)
+			return likeSweeper.Run(ctx)
+		})
+	}
+
+	if cacheWarmer != nil {
+		group.Go(func() error {
+			log.Infof("starting cache warmer, top recipients: %v", warmupTopN)
+			return cacheWarmer.Run(ctx)
+		})
+	}
+
 	group.Go(func() error {
 		<-ctx.Done()
 		log.Infof("shutting down gRPC server...")
@@ -78,6 +257,14 @@ func main() {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
+		// Drain the gateway first, its in-flight requests still need the gRPC server.
+		if err := gateway.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("failed to shut down http gateway: %v", err)
+		}
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("failed to shut down metrics server: %v", err)
+		}
+
 		done := make(chan struct{})
 		go func() {
 			grpcServer.GracefulStop()
@@ -106,3 +293,126 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		log.Warnf("invalid %s value, using default: %v", key, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// abuseDetectorConfig reads the abuse thresholds; setting one to 0 disables
+// its check.
+func abuseDetectorConfig() application.AbuseDetectorConfig {
+	maxLikeRatio, err := strconv.ParseFloat(getEnvOrDefault("ABUSE_MAX_LIKE_RATIO", "0.95"), 64)
+	if err != nil || maxLikeRatio < 0 {
+		log.Warnf("invalid ABUSE_MAX_LIKE_RATIO value, using default: 0.95")
+		maxLikeRatio = 0.95
+	}
+
+	return application.AbuseDetectorConfig{
+		MaxLikes:             uint64(getEnvIntOrDefault("ABUSE_MAX_LIKES_PER_WINDOW", 500)),
+		MaxLikeRatio:         maxLikeRatio,
+		MinDecisionsForRatio: uint64(getEnvIntOrDefault("ABUSE_MIN_DECISIONS_FOR_RATIO", 200)),
+		MaxSequentialRun:     uint64(getEnvIntOrDefault("ABUSE_MAX_SEQUENTIAL_RUN", 200)),
+	}
+}
+
+// likeSweeperConfig reads the sweeper settings. Unlike most settings these
+// fail startup when invalid, so a typo can't quietly delete expired likes
+// instead of archiving them.
+func likeSweeperConfig(likeLifetime time.Duration) (application.LikeSweeperConfig, error) {
+	interval, err := getEnvPositiveInt("LIKE_SWEEP_INTERVAL_SECONDS", 3600)
+	if err != nil {
+		return application.LikeSweeperConfig{}, err
+	}
+	batchSize, err := getEnvPositiveInt("LIKE_SWEEP_BATCH_SIZE", 1000)
+	if err != nil {
+		return application.LikeSweeperConfig{}, err
+	}
+
+	mode := getEnvOrDefault("LIKE_SWEEP_MODE", sweepModeArchive)
+	if mode != sweepModeArchive && mode != sweepModeDelete {
+		return application.LikeSweeperConfig{}, fmt.Errorf("LIKE_SWEEP_MODE must be %s or %s, got %q", sweepModeArchive, sweepModeDelete, mode)
+	}
+
+	return application.LikeSweeperConfig{
+		LikeLifetime: likeLifetime,
+		Interval:     time.Duration(interval) * time.Second,
+		BatchSize:    uint64(batchSize),
+		BatchPause:   time.Duration(getEnvIntOrDefault("LIKE_SWEEP_BATCH_PAUSE_MS", 100)) * time.Millisecond,
+		Archive:      mode == sweepModeArchive,
+	}, nil
+}
+
+// getEnvPositiveInt is getEnvIntOrDefault for settings that have no safe
+// fallback: an unparsable, zero or negative value is an error.
+func getEnvPositiveInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", key, value)
+	}
+	return parsed, nil
+}
+
+// gatewayCredentials configures the HTTP gateway's connection to the gRPC
+// server when TLS is on; with mTLS it also needs its own client certificate.
+func gatewayCredentials() (credentials.TransportCredentials, error) {
+	return grpc.NewClientCredentials(grpc.ClientTLSConfig{
+		CAFile:     getEnvOrDefault("GRPC_GATEWAY_TLS_CA_FILE", ""),
+		CertFile:   getEnvOrDefault("GRPC_GATEWAY_TLS_CERT_FILE", ""),
+		KeyFile:    getEnvOrDefault("GRPC_GATEWAY_TLS_KEY_FILE", ""),
+		ServerName: getEnvOrDefault("GRPC_GATEWAY_TLS_SERVER_NAME", "localhost"),
+	})
+}
+
+// parseSigningKeys reads a comma-separated list of "id:secret" pairs. The first
+// pair is the active signing key, the rest are only accepted for verification.
+func parseSigningKeys(value string) ([]domain.SigningKey, error) {
+	if value == "" {
+		return nil, errors.New("no signing keys configured")
+	}
+
+	var keys []domain.SigningKey
+	for _, pair := range strings.Split(value, ",") {
+		id, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("expected id:secret, got %q", pair)
+		}
+		keys = append(keys, domain.SigningKey{ID: id, Secret: []byte(secret)})
+	}
+
+	return keys, nil
+}
+
+// parseAdminTokens reads a comma-separated list of name:secret admin bearer
+// tokens. The name is audited as the requester; a holder can be given a new
+// token under the same name before the old one is removed.
+func parseAdminTokens(value string) ([]grpc.AdminToken, error) {
+	var tokens []grpc.AdminToken
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		name, secret, ok := strings.Cut(pair, ":")
+		if !ok || name == "" || secret == "" {
+			return nil, fmt.Errorf("expected name:secret, got %q", pair)
+		}
+		tokens = append(tokens, grpc.AdminToken{Name: name, Secret: secret})
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/gommon/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"muzz-homework/internal/explore/domain"
+	infraMemory "muzz-homework/internal/explore/infrastructure/memory"
+	infraPostgre "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"muzz-homework/pkg/postgres"
+	"muzz-homework/pkg/redis"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+// decisionStore is everything the services and background jobs need from
+// the decision repository.
+type decisionStore interface {
+	InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error)
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error)
+	GetTopRecipients(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error)
+	SweepExpiredLikes(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error)
+	EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error)
+	EraseUserMetadata(ctx context.Context, userID domain.UserID) error
+	StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error
+}
+
+type userStore interface {
+	GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error)
+}
+
+type profileSource interface {
+	GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error)
+}
+
+type candidateSource interface {
+	GetUndecidedLikers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+	GetUndecidedUsers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+}
+
+type abuseCounterStore interface {
+	RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error)
+	MarkFlagged(ctx context.Context, userID domain.UserID) error
+	ResetUser(ctx context.Context, userID domain.UserID) error
+}
+
+type abuseFlagStore interface {
+	FlagUser(ctx context.Context, flag domain.AbuseFlag) error
+	IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error)
+	ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error)
+	ClearAbuseFlag(ctx context.Context, userID domain.UserID) error
+}
+
+type likerIndex interface {
+	RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error
+	RecordOwnDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error
+}
+
+type warmupLock interface {
+	TryAcquire(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+// storage holds the adapters the binary runs on. tieredCache and likerIndex
+// are only set when the matching Redis cache strategy is in use.
+type storage struct {
+	decisions     decisionStore
+	users         userStore
+	profiles      profileSource
+	candidates    candidateSource
+	cache         exploreCache
+	tieredCache   *infraRedis.TieredCache
+	likerIndex    likerIndex
+	abuseCounters abuseCounterStore
+	abuseFlags    abuseFlagStore
+	// warmupLock is shared by every replica; nil when there is only one.
+	warmupLock warmupLock
+	close      func()
+}
+
+// newMemoryStorage keeps everything in process, for local runs and tests
+// without Postgres and Redis. Nothing survives a restart, so the users listed
+// in MEMORY_USERS are provisioned as active, and offered as candidates, on
+// every start.
+func newMemoryStorage(ctx context.Context, likeLifetime time.Duration) (storage, error) {
+	db := infraMemory.NewDatabase()
+	users := infraMemory.NewUserRepository(db)
+
+	for _, id := range strings.Split(os.Getenv("MEMORY_USERS"), ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+
+		userID, err := domain.ParseUserID(id)
+		if err != nil {
+			return storage{}, fmt.Errorf("invalid MEMORY_USERS entry %q: %w", id, err)
+		}
+
+		if err := users.UpsertUser(ctx, domain.User{ID: userID, Status: domain.UserStatusActive}); err != nil {
+			return storage{}, fmt.Errorf("failed to provision user: %w", err)
+		}
+	}
+
+	return storage{
+		decisions: infraMemory.NewDecisionRepository(db, infraMemory.DecisionRepositoryConfig{
+			LikeLifetime: likeLifetime,
+		}),
+		users:      users,
+		profiles:   users,
+		candidates: infraMemory.NewCandidateSource(db),
+		cache: infraMemory.NewCache(infraMemory.CacheConfig{
+			TTL: time.Duration(getEnvIntOrDefault("REDIS_TTL_SECONDS", 900)) * time.Second,
+		}),
+		abuseCounters: infraMemory.NewAbuseCounters(infraMemory.AbuseCountersConfig{
+			Window: abuseWindow(),
+			RunGap: abuseRunGap(),
+		}),
+		abuseFlags: infraMemory.NewAbuseFlagRepository(db),
+		close:      func() {},
+	}, nil
+}
+
+func newPostgresStorage(ctx context.Context, likeLifetime time.Duration) (storage, error) {
+	sqlDB, err := postgres.NewSQLDB()
+	if err != nil {
+		return storage{}, fmt.Errorf("failed to create db: %w", err)
+	}
+
+	ttlStr := getEnvOrDefault("REDIS_TTL_SECONDS", "900")
+	ttlSeconds, err := strconv.Atoi(ttlStr)
+	if err != nil {
+		log.Warnf("invalid REDIS_TTL_SECONDS value, using default: %v", err)
+		ttlSeconds = 900
+	}
+	ttl := time.Duration(ttlSeconds) * time.Second
+
+	redisConfig, err := redis.ConfigFromEnv()
+	if err != nil {
+		sqlDB.Close()
+		return storage{}, err
+	}
+
+	redisClient, err := redis.NewUniversalClient(redisConfig)
+	if err != nil {
+		sqlDB.Close()
+		return storage{}, fmt.Errorf("failed to create redis client: %w", err)
+	}
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		redisClient.Close()
+		sqlDB.Close()
+		return storage{}, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	decisionRepo := infraPostgre.NewDecisionRepository(sqlDB, infraPostgre.DecisionRepositoryConfig{
+		LikeLifetime: likeLifetime,
+	})
+	xfetchBeta, err := strconv.ParseFloat(getEnvOrDefault("REDIS_XFETCH_BETA", "1"), 64)
+	if err != nil || xfetchBeta < 0 {
+		log.Warnf("invalid REDIS_XFETCH_BETA value, using default: 1")
+		xfetchBeta = 1
+	}
+
+	cacheEncoding := infraRedis.CacheEncoding(getEnvOrDefault("REDIS_CACHE_ENCODING", string(infraRedis.EncodingBinary)))
+	if cacheEncoding != infraRedis.EncodingBinary && cacheEncoding != infraRedis.EncodingJSON {
+		log.Warnf("invalid REDIS_CACHE_ENCODING value, using default: %s", infraRedis.EncodingBinary)
+		cacheEncoding = infraRedis.EncodingBinary
+	}
+
+	redisPrefix := getEnvOrDefault("REDIS_PREFIX", "muzz")
+	cacheMetrics := infraRedis.NewCacheMetrics(prometheus.DefaultRegisterer)
+	redisCache := infraRedis.NewRedisCache(redisClient, infraRedis.RedisConfig{
+		Prefix:               redisPrefix,
+		TTL:                  ttl,
+		XFetchBeta:           xfetchBeta,
+		Metrics:              cacheMetrics,
+		Encoding:             cacheEncoding,
+		CompressionThreshold: getEnvIntOrDefault("REDIS_COMPRESSION_THRESHOLD_BYTES", 1024),
+	})
+
+	userRepo := infraPostgre.NewUserRepository(sqlDB)
+	s := storage{
+		decisions: decisionRepo,
+		users:     userRepo,
+		profiles: infraRedis.NewProfileCache(redisCache, userRepo,
+			time.Duration(getEnvIntOrDefault("PROFILE_CACHE_TTL_SECONDS", 300))*time.Second),
+		candidates: infraPostgre.NewCandidateRepository(sqlDB, infraPostgre.CandidateRepositoryConfig{
+			LikeLifetime: likeLifetime,
+		}),
+		cache: redisCache,
+		abuseCounters: infraRedis.NewAbuseCounters(redisClient, infraRedis.AbuseCountersConfig{
+			Prefix: redisPrefix,
+			Window: abuseWindow(),
+			RunGap: abuseRunGap(),
+		}),
+		abuseFlags: infraPostgre.NewAbuseFlagRepository(sqlDB),
+		warmupLock: infraRedis.NewLock(redisClient, redisPrefix+":lock:cache_warmup"),
+		close: func() {
+			redisClient.Close()
+			sqlDB.Close()
+		},
+	}
+
+	cacheStrategy := getEnvOrDefault("CACHE_STRATEGY", "pages")
+	if cacheStrategy != "pages" && cacheStrategy != "index" {
+		log.Warnf("invalid CACHE_STRATEGY value, using default: pages")
+		cacheStrategy = "pages"
+	}
+
+	switch {
+	case cacheStrategy == "index":
+		index := infraRedis.NewLikerIndex(redisCache, decisionRepo, infraRedis.LikerIndexConfig{
+			TTL:          time.Duration(getEnvIntOrDefault("LIKER_INDEX_TTL_SECONDS", 3600)) * time.Second,
+			LikeLifetime: likeLifetime,
+		})
+		s.likerIndex = index
+		s.cache = index
+	case getEnvOrDefault("LOCAL_CACHE_ENABLED", "true") == "true":
+		s.tieredCache = infraRedis.NewTieredCache(redisCache, infraRedis.LocalCacheConfig{
+			Size:    getEnvIntOrDefault("LOCAL_CACHE_SIZE", 10000),
+			TTL:     time.Duration(getEnvIntOrDefault("LOCAL_CACHE_TTL_MS", 2000)) * time.Millisecond,
+			Metrics: cacheMetrics,
+		})
+		s.cache = s.tieredCache
+	}
+
+	return s, nil
+}
+
+// abuseWindow is how long each actor's decisions are counted together for
+// abuse detection.
+func abuseWindow() time.Duration {
+	return time.Duration(getEnvIntOrDefault("ABUSE_WINDOW_SECONDS", 3600)) * time.Second
+}
+
+// abuseRunGap is the longest pause between two decisions that still extends
+// an actor's run of ascending recipient IDs.
+func abuseRunGap() time.Duration {
+	return time.Duration(getEnvIntOrDefault("ABUSE_SEQUENTIAL_RUN_GAP_MS", 1000)) * time.Millisecond
+}
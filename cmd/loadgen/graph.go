@@ -0,0 +1,106 @@
+package main
+
+import (
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"muzz-homework/internal/explore/domain"
+	"strconv"
+)
+
+// userNamespace derives the synthetic user IDs, so seed and run agree on them
+// without sharing anything but the user count.
+var userNamespace = uuid.MustParse("6c6f6164-6765-4e00-8000-6d757a7a0000")
+
+// userID returns the ID of the i-th synthetic user. Lower indexes are more
+// popular.
+func userID(i uint64) domain.UserID {
+	return domain.UserID(uuid.NewSHA1(userNamespace, []byte(strconv.FormatUint(i, 10))).String())
+}
+
+type graphConfig struct {
+	Users        uint64
+	LikesPerUser float64
+	// Skew is the exponent of the Zipf distribution recipients are drawn
+	// from; it must be greater than 1, and higher values concentrate likes
+	// on fewer users.
+	Skew float64
+	// MutualRatio is the share of likes that are liked back.
+	MutualRatio float64
+	// SuperLikeRatio is the share of likes that are super-likes.
+	SuperLikeRatio float64
+	// Span is how far back, in seconds, like timestamps go.
+	Span uint64
+	Seed uint64
+}
+
+type edge struct {
+	actor, recipient uint64
+	decision         domain.Decision
+	timestamp        uint64
+}
+
+// popularity picks users following a power law, so a few users receive most
+// of the likes and reads.
+type popularity struct {
+	zipf *rand.Zipf
+}
+
+func newPopularity(r *rand.Rand, users uint64, skew float64) *popularity {
+	return &popularity{zipf: rand.NewZipf(r, skew, 1, users-1)}
+}
+
+func (p *popularity) next() uint64 {
+	return p.zipf.Uint64()
+}
+
+// generateGraph returns the likes of a synthetic social graph: actors are
+// uniform, recipients follow the popularity distribution, and MutualRatio of
+// the likes are liked back. The same config always yields the same graph.
+func generateGraph(config graphConfig, now uint64) []edge {
+	r := rand.New(rand.NewPCG(config.Seed, config.Seed))
+	recipients := newPopularity(r, config.Users, config.Skew)
+
+	target := int(float64(config.Users) * config.LikesPerUser)
+	seen := make(map[[2]uint64]struct{}, target)
+	edges := make([]edge, 0, target)
+
+	like := func() domain.Decision {
+		if r.Float64() < config.SuperLikeRatio {
+			return domain.DecisionSuperLike
+		}
+		return domain.DecisionLike
+	}
+
+	// Give up on duplicates eventually, as a tiny graph with a high skew
+	// may not have enough distinct pairs.
+	for attempts := 0; len(edges) < target && attempts < target*10; attempts++ {
+		actor, recipient := r.Uint64N(config.Users), recipients.next()
+		if actor == recipient {
+			continue
+		}
+		if _, ok := seen[[2]uint64{actor, recipient}]; ok {
+			continue
+		}
+
+		timestamp := now - r.Uint64N(config.Span+1)
+		seen[[2]uint64{actor, recipient}] = struct{}{}
+		edges = append(edges, edge{actor: actor, recipient: recipient, decision: like(), timestamp: timestamp})
+
+		if r.Float64() >= config.MutualRatio {
+			continue
+		}
+		if _, ok := seen[[2]uint64{recipient, actor}]; ok {
+			continue
+		}
+
+		seen[[2]uint64{recipient, actor}] = struct{}{}
+		edges = append(edges, edge{
+			actor:     recipient,
+			recipient: actor,
+			decision:  like(),
+			timestamp: timestamp + r.Uint64N(now-timestamp+1),
+		})
+	}
+
+	return edges
+}
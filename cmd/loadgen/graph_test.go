@@ -0,0 +1,109 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+func TestGenerateGraph(t *testing.T) {
+	const now = 1_000_000
+	config := graphConfig{
+		Users:          1000,
+		LikesPerUser:   10,
+		Skew:           1.5,
+		MutualRatio:    0.3,
+		SuperLikeRatio: 0.1,
+		Span:           3600,
+		Seed:           7,
+	}
+
+	edges := generateGraph(config, now)
+	// A like back can take the graph one edge past the target.
+	require.InDelta(t, 10000, len(edges), 1)
+
+	t.Run("deterministic", func(t *testing.T) {
+		assert.Equal(t, edges, generateGraph(config, now))
+
+		other := config
+		other.Seed = 8
+		assert.NotEqual(t, edges, generateGraph(other, now))
+	})
+
+	t.Run("valid edges", func(t *testing.T) {
+		pairs := make(map[[2]uint64]struct{}, len(edges))
+		for _, e := range edges {
+			assert.NotEqual(t, e.actor, e.recipient)
+			assert.Less(t, e.actor, config.Users)
+			assert.Less(t, e.recipient, config.Users)
+			assert.True(t, e.decision.Liked())
+			assert.LessOrEqual(t, e.timestamp, uint64(now))
+			assert.GreaterOrEqual(t, e.timestamp, uint64(now)-config.Span)
+
+			_, duplicate := pairs[[2]uint64{e.actor, e.recipient}]
+			assert.False(t, duplicate, "duplicate edge %d -> %d", e.actor, e.recipient)
+			pairs[[2]uint64{e.actor, e.recipient}] = struct{}{}
+		}
+	})
+
+	t.Run("skewed recipients", func(t *testing.T) {
+		received := make(map[uint64]int)
+		for _, e := range edges {
+			received[e.recipient]++
+		}
+
+		// The most popular tenth of the users receive most of the likes,
+		// although the mutual likes back are spread uniformly.
+		top := 0
+		for i := uint64(0); i < config.Users/10; i++ {
+			top += received[i]
+		}
+		assert.Greater(t, top, len(edges)/2)
+		assert.Greater(t, received[0], received[config.Users/2])
+	})
+
+	t.Run("mutual ratio", func(t *testing.T) {
+		pairs := make(map[[2]uint64]struct{}, len(edges))
+		for _, e := range edges {
+			pairs[[2]uint64{e.actor, e.recipient}] = struct{}{}
+		}
+
+		mutual := 0
+		for _, e := range edges {
+			if _, ok := pairs[[2]uint64{e.recipient, e.actor}]; ok {
+				mutual++
+			}
+		}
+
+		// Each mutual pair is two edges out of the first like plus its
+		// like back, so the share of edges in a pair is 2r/(1+r).
+		want := 2 * config.MutualRatio / (1 + config.MutualRatio)
+		assert.InDelta(t, want, float64(mutual)/float64(len(edges)), 0.05)
+	})
+
+	t.Run("super-like ratio", func(t *testing.T) {
+		superLikes := 0
+		for _, e := range edges {
+			if e.decision == domain.DecisionSuperLike {
+				superLikes++
+			}
+		}
+		assert.InDelta(t, config.SuperLikeRatio, float64(superLikes)/float64(len(edges)), 0.02)
+	})
+}
+
+func TestGenerateGraph_TooFewPairs(t *testing.T) {
+	edges := generateGraph(graphConfig{Users: 3, LikesPerUser: 10, Skew: 2, Seed: 1}, 100)
+
+	// Three users have only six distinct pairs.
+	assert.LessOrEqual(t, len(edges), 6)
+	assert.NotEmpty(t, edges)
+}
+
+func TestUserID(t *testing.T) {
+	id, err := domain.ParseUserID(string(userID(42)))
+	require.NoError(t, err)
+	assert.Equal(t, userID(42), id)
+	assert.NotEqual(t, userID(42), userID(43))
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"io"
+	grpcAdapter "muzz-homework/internal/explore/adapters/grpc"
+	"muzz-homework/pkg/postgres"
+	pb "muzz-homework/pkg/proto"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		cancel()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return flag.ErrHelp
+	}
+
+	switch args[0] {
+	case "seed":
+		return seed(ctx, args[1:], out, stderr)
+	case "run":
+		return replay(ctx, args[1:], out, stderr)
+	default:
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: loadgen <command> [flags]")
+	fmt.Fprintln(w, "\ncommands:")
+	fmt.Fprintln(w, "  seed  write a synthetic social graph to the Postgres database in POSTGRES_DSN")
+	fmt.Fprintln(w, "  run   replay a mixed workload against a running server and report latencies")
+	fmt.Fprintln(w, "\nBoth commands must be given the same --users, --skew and --seed.")
+}
+
+// graphFlags registers the flags that decide who the synthetic users are and
+// how popular they are, shared by seed and run.
+func graphFlags(fs *flag.FlagSet) *graphConfig {
+	config := &graphConfig{}
+	fs.Uint64Var(&config.Users, "users", 10000, "number of synthetic users")
+	fs.Float64Var(&config.Skew, "skew", 1.2, "Zipf exponent of user popularity, greater than 1")
+	fs.Uint64Var(&config.Seed, "seed", 1, "random seed")
+
+	return config
+}
+
+func validateGraph(config *graphConfig) error {
+	if config.Users < 2 {
+		return errors.New("--users must be at least 2")
+	}
+	if config.Skew <= 1 {
+		return errors.New("--skew must be greater than 1")
+	}
+
+	return nil
+}
+
+func seed(ctx context.Context, args []string, out, stderr io.Writer) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	config := graphFlags(fs)
+	fs.Float64Var(&config.LikesPerUser, "likes-per-user", 20, "average number of likes each user gives")
+	fs.Float64Var(&config.MutualRatio, "mutual-ratio", 0.2, "share of likes that are liked back")
+	fs.Float64Var(&config.SuperLikeRatio, "super-like-ratio", 0.05, "share of likes that are super-likes")
+	span := fs.Duration("span", 30*24*time.Hour, "how far back like timestamps go")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := validateGraph(config); err != nil {
+		return err
+	}
+	if config.MutualRatio < 0 || config.MutualRatio > 1 || config.SuperLikeRatio < 0 || config.SuperLikeRatio > 1 {
+		return errors.New("--mutual-ratio and --super-like-ratio must be between 0 and 1")
+	}
+	config.Span = uint64(span.Seconds())
+
+	db, err := postgres.NewSQLDB()
+	if err != nil {
+		return fmt.Errorf("connecting to postgres: %w", err)
+	}
+	defer db.Close()
+
+	return seedGraph(ctx, db, *config, out)
+}
+
+func replay(ctx context.Context, args []string, out, stderr io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	graph := graphFlags(fs)
+	addr := fs.String("addr", "localhost:8000", "gRPC address of the server")
+	metricsURL := fs.String("metrics-url", "http://localhost:9090/metrics", "server metrics to compute the cache hit rate from, empty to skip")
+	mix := fs.String("mix", "list=50,new=20,count=20,put=10", "relative weights of list, new, count and put calls")
+	duration := fs.Duration("duration", 30*time.Second, "how long to run")
+	concurrency := fs.Int("concurrency", 16, "number of concurrent callers")
+	rate := fs.Float64("rate", 0, "maximum calls per second across callers, 0 for as fast as possible")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	useTLS := fs.Bool("tls", false, "connect over TLS")
+	var tlsConfig grpcAdapter.ClientTLSConfig
+	fs.StringVar(&tlsConfig.CAFile, "tls-ca", "", "CA certificate to verify the server with, instead of the system roots")
+	fs.StringVar(&tlsConfig.CertFile, "tls-cert", "", "client certificate, for servers requiring mTLS")
+	fs.StringVar(&tlsConfig.KeyFile, "tls-key", "", "client private key, for servers requiring mTLS")
+	fs.StringVar(&tlsConfig.ServerName, "tls-server-name", "", "name to verify the server certificate against, defaults to the host of --addr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := validateGraph(graph); err != nil {
+		return err
+	}
+	if *concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	weights, err := parseMix(*mix)
+	if err != nil {
+		return fmt.Errorf("--mix: %w", err)
+	}
+
+	creds, err := transportCredentials(*useTLS, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("creating grpc client: %w", err)
+	}
+	defer conn.Close()
+
+	var before, after cacheCounters
+	if *metricsURL != "" {
+		if before, err = scrapeCacheCounters(ctx, *metricsURL); err != nil {
+			return err
+		}
+	}
+
+	result := runWorkload(ctx, pb.NewExploreServiceClient(conn), workloadConfig{
+		Users:       graph.Users,
+		Skew:        graph.Skew,
+		Seed:        graph.Seed,
+		Duration:    *duration,
+		Concurrency: *concurrency,
+		Rate:        *rate,
+		Mix:         weights,
+	})
+
+	if *metricsURL != "" {
+		if after, err = scrapeCacheCounters(ctx, *metricsURL); err != nil {
+			return err
+		}
+	}
+
+	return newReport(result, before, after).write(out, *asJSON)
+}
+
+// transportCredentials returns plaintext credentials unless TLS is asked for;
+// any --tls-* flag implies --tls.
+func transportCredentials(useTLS bool, config grpcAdapter.ClientTLSConfig) (credentials.TransportCredentials, error) {
+	if !useTLS && config == (grpcAdapter.ClientTLSConfig{}) {
+		return insecure.NewCredentials(), nil
+	}
+
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("--tls-cert and --tls-key must be given together")
+	}
+
+	creds, err := grpcAdapter.NewClientCredentials(config)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS credentials: %w", err)
+	}
+
+	return creds, nil
+}
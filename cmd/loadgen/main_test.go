@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcAdapter "muzz-homework/internal/explore/adapters/grpc"
+	"path/filepath"
+	"testing"
+)
+
+func TestTransportCredentials(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+
+	tests := []struct {
+		name         string
+		useTLS       bool
+		config       grpcAdapter.ClientTLSConfig
+		wantProtocol string
+		wantErr      string
+	}{
+		{
+			name:         "plaintext by default",
+			wantProtocol: "insecure",
+		},
+		{
+			name:         "TLS with the system roots",
+			useTLS:       true,
+			wantProtocol: "tls",
+		},
+		{
+			name:         "server name implies TLS",
+			config:       grpcAdapter.ClientTLSConfig{ServerName: "explore.internal"},
+			wantProtocol: "tls",
+		},
+		{
+			name:    "unreadable CA",
+			config:  grpcAdapter.ClientTLSConfig{CAFile: missing},
+			wantErr: "loading TLS credentials: failed to read CA file",
+		},
+		{
+			name:    "certificate without key",
+			useTLS:  true,
+			config:  grpcAdapter.ClientTLSConfig{CertFile: missing},
+			wantErr: "--tls-cert and --tls-key must be given together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := transportCredentials(tt.useTLS, tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProtocol, creds.Info().SecurityProtocol)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/prometheus/common/expfmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+const cacheRequestsMetric = "explore_cache_requests_total"
+
+type opReport struct {
+	Operation string         `json:"operation"`
+	Calls     int            `json:"calls"`
+	Errors    map[string]int `json:"errors,omitempty"`
+	PerSecond float64        `json:"per_second"`
+	P50Ms     float64        `json:"p50_ms"`
+	P90Ms     float64        `json:"p90_ms"`
+	P99Ms     float64        `json:"p99_ms"`
+	MaxMs     float64        `json:"max_ms"`
+}
+
+type cacheReport struct {
+	Tier    string  `json:"tier"`
+	Entry   string  `json:"entry"`
+	Lookups float64 `json:"lookups"`
+	Hits    float64 `json:"hits"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+type report struct {
+	ElapsedMs  int64         `json:"elapsed_ms"`
+	Operations []opReport    `json:"operations"`
+	Cache      []cacheReport `json:"cache,omitempty"`
+}
+
+func newReport(result workloadResult, before, after cacheCounters) report {
+	r := report{ElapsedMs: result.elapsed.Milliseconds()}
+
+	for _, op := range operations {
+		stats := result.stats[op]
+		if len(stats.latencies) == 0 {
+			continue
+		}
+
+		latencies := slices.Clone(stats.latencies)
+		slices.Sort(latencies)
+
+		opr := opReport{
+			Operation: op,
+			Calls:     len(latencies),
+			PerSecond: float64(len(latencies)) / result.elapsed.Seconds(),
+			P50Ms:     milliseconds(percentile(latencies, 0.50)),
+			P90Ms:     milliseconds(percentile(latencies, 0.90)),
+			P99Ms:     milliseconds(percentile(latencies, 0.99)),
+			MaxMs:     milliseconds(latencies[len(latencies)-1]),
+		}
+		for code, n := range stats.errors {
+			if opr.Errors == nil {
+				opr.Errors = map[string]int{}
+			}
+			opr.Errors[code.String()] = n
+		}
+		r.Operations = append(r.Operations, opr)
+	}
+
+	if before != nil && after != nil {
+		r.Cache = cacheHitRates(before, after)
+	}
+
+	return r
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(float64(len(sorted))*p+0.5) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func (r report) write(out io.Writer, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "OP\tCALLS\tERRORS\tPER SEC\tP50 MS\tP90 MS\tP99 MS\tMAX MS\t")
+	for _, op := range r.Operations {
+		failed := 0
+		for _, n := range op.Errors {
+			failed += n
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+			op.Operation, op.Calls, failed, op.PerSecond, op.P50Ms, op.P90Ms, op.P99Ms, op.MaxMs)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, op := range r.Operations {
+		codes := make([]string, 0, len(op.Errors))
+		for code := range op.Errors {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			fmt.Fprintf(out, "%s: %d x %s\n", op.Operation, op.Errors[code], code)
+		}
+	}
+
+	if len(r.Cache) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "TIER\tENTRY\tLOOKUPS\tHIT RATE\t")
+	for _, c := range r.Cache {
+		fmt.Fprintf(w, "%s\t%s\t%.0f\t%.1f%%\t\n", c.Tier, c.Entry, c.Lookups, c.HitRate*100)
+	}
+
+	return w.Flush()
+}
+
+// cacheCounters holds explore_cache_requests_total by tier, entry and result.
+type cacheCounters map[[3]string]float64
+
+// scrapeCacheCounters reads the server's cache lookup counters, which are
+// diffed around the run to get the hit rate of the run alone.
+func scrapeCacheCounters(ctx context.Context, url string) (cacheCounters, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating metrics request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching metrics: unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metrics: %w", err)
+	}
+
+	counters := cacheCounters{}
+	family, ok := families[cacheRequestsMetric]
+	if !ok {
+		return counters, nil
+	}
+
+	for _, metric := range family.GetMetric() {
+		var key [3]string
+		for _, label := range metric.GetLabel() {
+			switch label.GetName() {
+			case "tier":
+				key[0] = label.GetValue()
+			case "entry":
+				key[1] = label.GetValue()
+			case "result":
+				key[2] = label.GetValue()
+			}
+		}
+		counters[key] = metric.GetCounter().GetValue()
+	}
+
+	return counters, nil
+}
+
+// cacheHitRates reports, per tier and entry, the share of lookups made
+// during the run that were hits.
+func cacheHitRates(before, after cacheCounters) []cacheReport {
+	byEntry := map[[2]string]*cacheReport{}
+	for key, value := range after {
+		delta := value - before[key]
+		if delta <= 0 {
+			continue
+		}
+
+		c, ok := byEntry[[2]string{key[0], key[1]}]
+		if !ok {
+			c = &cacheReport{Tier: key[0], Entry: key[1]}
+			byEntry[[2]string{key[0], key[1]}] = c
+		}
+		c.Lookups += delta
+		if key[2] == "hit" {
+			c.Hits += delta
+		}
+	}
+
+	reports := make([]cacheReport, 0, len(byEntry))
+	for _, c := range byEntry {
+		c.HitRate = c.Hits / c.Lookups
+		reports = append(reports, *c)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Tier != reports[j].Tier {
+			return reports[i].Tier < reports[j].Tier
+		}
+		return reports[i].Entry < reports[j].Entry
+	})
+
+	return reports
+}
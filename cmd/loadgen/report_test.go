@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	hundred := make([]time.Duration, 100)
+	for i := range hundred {
+		hundred[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{name: "single value p50", sorted: []time.Duration{5}, p: 0.5, want: 5},
+		{name: "single value p99", sorted: []time.Duration{5}, p: 0.99, want: 5},
+		{name: "p0 is the minimum", sorted: hundred, p: 0, want: time.Millisecond},
+		{name: "p50", sorted: hundred, p: 0.50, want: 50 * time.Millisecond},
+		{name: "p90", sorted: hundred, p: 0.90, want: 90 * time.Millisecond},
+		{name: "p99", sorted: hundred, p: 0.99, want: 99 * time.Millisecond},
+		{name: "p100 is the maximum", sorted: hundred, p: 1, want: 100 * time.Millisecond},
+		{name: "nearest rank rounds", sorted: []time.Duration{1, 2, 3, 4}, p: 0.5, want: 2},
+		{name: "nearest rank rounds up", sorted: []time.Duration{1, 2, 3, 4}, p: 0.9, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, percentile(tt.sorted, tt.p))
+		})
+	}
+}
+
+func TestCacheHitRates(t *testing.T) {
+	before := cacheCounters{
+		{"local", "likers", "hit"}:   10,
+		{"local", "likers", "miss"}:  10,
+		{"redis", "likers", "hit"}:   5,
+		{"redis", "likers", "miss"}:  5,
+		{"redis", "count", "miss"}:   3,
+		{"local", "profile", "miss"}: 4,
+	}
+	after := cacheCounters{
+		{"local", "likers", "hit"}:   40,
+		{"local", "likers", "miss"}:  20,
+		{"redis", "likers", "hit"}:   5,
+		{"redis", "likers", "miss"}:  15,
+		{"redis", "count", "hit"}:    2,
+		{"redis", "count", "miss"}:   3,
+		{"local", "profile", "miss"}: 4,
+	}
+
+	assert.Equal(t, []cacheReport{
+		{Tier: "local", Entry: "likers", Lookups: 40, Hits: 30, HitRate: 0.75},
+		{Tier: "redis", Entry: "count", Lookups: 2, Hits: 2, HitRate: 1},
+		{Tier: "redis", Entry: "likers", Lookups: 10, Hits: 0, HitRate: 0},
+	}, cacheHitRates(before, after))
+}
+
+func TestNewReport(t *testing.T) {
+	stats := newOpStats()
+	list := stats[opList]
+	list.latencies = []time.Duration{4 * time.Millisecond, 1 * time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond}
+	list.errors[codes.Unavailable] = 1
+
+	result := workloadResult{
+		elapsed: 2 * time.Second,
+		stats:   stats,
+	}
+
+	r := newReport(result, nil, nil)
+	assert.Equal(t, report{
+		ElapsedMs: 2000,
+		Operations: []opReport{{
+			Operation: opList,
+			Calls:     4,
+			Errors:    map[string]int{"Unavailable": 1},
+			PerSecond: 2,
+			P50Ms:     2,
+			P90Ms:     4,
+			P99Ms:     4,
+			MaxMs:     4,
+		}},
+	}, r)
+	assert.Equal(t, []time.Duration{4 * time.Millisecond, 1 * time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond},
+		list.latencies, "the report must not reorder the recorded latencies")
+
+	var out bytes.Buffer
+	require.NoError(t, r.write(&out, false))
+	assert.Contains(t, out.String(), "list: 1 x Unavailable\n")
+}
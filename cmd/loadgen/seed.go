@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"io"
+	"time"
+)
+
+// seedBatchSize keeps each INSERT well below Postgres' 65535 parameters.
+const seedBatchSize = 1000
+
+// seedGraph writes the users and likes of the graph. Rows that already exist
+// are left alone, so seeding twice with the same config is a no-op.
+func seedGraph(ctx context.Context, db *sql.DB, config graphConfig, out io.Writer) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	now := time.Now()
+
+	started := time.Now()
+	for first := uint64(0); first < config.Users; first += seedBatchSize {
+		insert := psql.Insert("users").
+			Columns("user_id", "display_name", "photo_url", "birth_date", "created_at", "updated_at").
+			Suffix("ON CONFLICT (user_id) DO NOTHING")
+
+		for i := first; i < min(first+seedBatchSize, config.Users); i++ {
+			birthDate := now.AddDate(-18-int(i%40), 0, -int(i%365)).Format(time.DateOnly)
+			insert = insert.Values(userID(i), fmt.Sprintf("User %d", i),
+				fmt.Sprintf("https://example.com/photos/%d.jpg", i), birthDate, now.Unix(), now.Unix())
+		}
+
+		if _, err := insert.RunWith(db).ExecContext(ctx); err != nil {
+			return fmt.Errorf("inserting users: %w", err)
+		}
+	}
+	fmt.Fprintf(out, "seeded %d users in %s\n", config.Users, time.Since(started).Round(time.Millisecond))
+
+	started = time.Now()
+	edges := generateGraph(config, uint64(now.Unix()))
+	for first := 0; first < len(edges); first += seedBatchSize {
+		insert := psql.Insert("user_decisions").
+			Columns("actor_user_id", "recipient_user_id", "decision", "decision_timestamp").
+			Suffix("ON CONFLICT (actor_user_id, recipient_user_id) DO NOTHING")
+
+		for _, e := range edges[first:min(first+seedBatchSize, len(edges))] {
+			insert = insert.Values(userID(e.actor), userID(e.recipient), e.decision, e.timestamp)
+		}
+
+		if _, err := insert.RunWith(db).ExecContext(ctx); err != nil {
+			return fmt.Errorf("inserting decisions: %w", err)
+		}
+	}
+	fmt.Fprintf(out, "seeded %d likes in %s\n", len(edges), time.Since(started).Round(time.Millisecond))
+
+	if _, err := db.ExecContext(ctx, "ANALYZE users, user_decisions"); err != nil {
+		return fmt.Errorf("analyzing tables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math/rand/v2"
+	pb "muzz-homework/pkg/proto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	opList  = "list"
+	opNew   = "new"
+	opCount = "count"
+	opPut   = "put"
+)
+
+var operations = []string{opList, opNew, opCount, opPut}
+
+type weightedOp struct {
+	name   string
+	weight int
+}
+
+// parseMix parses a workload mix such as "list=50,new=20,count=20,put=10".
+// Weights are relative and don't need to add up to 100.
+func parseMix(value string) ([]weightedOp, error) {
+	var mix []weightedOp
+	for _, part := range strings.Split(value, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mix entry %q, expected op=weight", part)
+		}
+
+		known := false
+		for _, op := range operations {
+			known = known || op == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown operation %q, expected one of %s", name, strings.Join(operations, ", "))
+		}
+
+		w, err := strconv.Atoi(weight)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %s", weight, name)
+		}
+		if w > 0 {
+			mix = append(mix, weightedOp{name: name, weight: w})
+		}
+	}
+
+	if len(mix) == 0 {
+		return nil, errors.New("mix has no operation with a positive weight")
+	}
+
+	return mix, nil
+}
+
+type workloadConfig struct {
+	Users       uint64
+	Skew        float64
+	Seed        uint64
+	Duration    time.Duration
+	Concurrency int
+	// Rate caps the operations per second across all workers, 0 for none.
+	Rate float64
+	Mix  []weightedOp
+}
+
+// opStats holds the outcome of every call of one operation.
+type opStats struct {
+	latencies []time.Duration
+	errors    map[codes.Code]int
+}
+
+func (s *opStats) merge(other *opStats) {
+	s.latencies = append(s.latencies, other.latencies...)
+	for code, n := range other.errors {
+		s.errors[code] += n
+	}
+}
+
+type workloadResult struct {
+	elapsed time.Duration
+	stats   map[string]*opStats
+}
+
+func newOpStats() map[string]*opStats {
+	stats := make(map[string]*opStats, len(operations))
+	for _, op := range operations {
+		stats[op] = &opStats{errors: map[codes.Code]int{}}
+	}
+	return stats
+}
+
+// runWorkload calls the service from Concurrency workers until Duration has
+// passed. Calls in flight when it ends are allowed to finish, so they are
+// not reported as cancelled.
+func runWorkload(ctx context.Context, client pb.ExploreServiceClient, config workloadConfig) workloadResult {
+	runCtx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	limit := rate.Inf
+	if config.Rate > 0 {
+		limit = rate.Limit(config.Rate)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	totalWeight := 0
+	for _, op := range config.Mix {
+		totalWeight += op.weight
+	}
+
+	result := workloadResult{stats: newOpStats()}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	started := time.Now()
+	for worker := 0; worker < config.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := rand.New(rand.NewPCG(config.Seed, uint64(worker)))
+			w := &workloadWorker{
+				client:     client,
+				r:          r,
+				users:      config.Users,
+				recipients: newPopularity(r, config.Users, config.Skew),
+			}
+			stats := newOpStats()
+
+			for limiter.Wait(runCtx) == nil {
+				pick := r.IntN(totalWeight)
+				op := config.Mix[0].name
+				for _, candidate := range config.Mix {
+					if pick < candidate.weight {
+						op = candidate.name
+						break
+					}
+					pick -= candidate.weight
+				}
+
+				callStarted := time.Now()
+				err := w.call(ctx, op)
+				stats[op].latencies = append(stats[op].latencies, time.Since(callStarted))
+				if err != nil {
+					stats[op].errors[status.Code(err)]++
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for op, s := range stats {
+				result.stats[op].merge(s)
+			}
+		}()
+	}
+
+	wg.Wait()
+	result.elapsed = time.Since(started)
+
+	return result
+}
+
+type workloadWorker struct {
+	client     pb.ExploreServiceClient
+	r          *rand.Rand
+	users      uint64
+	recipients *popularity
+}
+
+// call runs one operation. Readers follow the same power law as likes, so
+// popular users' listings are read the most, as they would be in production.
+func (w *workloadWorker) call(ctx context.Context, op string) error {
+	recipient := userID(w.recipients.next())
+
+	switch op {
+	case opList:
+		_, err := w.client.ListLikedYou(ctx, &pb.ListLikedYouRequest{RecipientUserId: recipient.String()})
+		return err
+	case opNew:
+		_, err := w.client.ListNewLikedYou(ctx, &pb.ListLikedYouRequest{RecipientUserId: recipient.String()})
+		return err
+	case opCount:
+		_, err := w.client.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: recipient.String()})
+		return err
+	default:
+		actor := userID(w.r.Uint64N(w.users))
+		for actor == recipient {
+			actor = userID(w.r.Uint64N(w.users))
+		}
+
+		_, err := w.client.PutDecision(ctx, &pb.PutDecisionRequest{
+			ActorUserId:     actor.String(),
+			RecipientUserId: recipient.String(),
+			Decision:        w.decision(),
+		})
+		return err
+	}
+}
+
+func (w *workloadWorker) decision() pb.Decision {
+	switch p := w.r.Float64(); {
+	case p < 0.05:
+		return pb.Decision_DECISION_SUPER_LIKE
+	case p < 0.20:
+		return pb.Decision_DECISION_PASS
+	default:
+		return pb.Decision_DECISION_LIKE
+	}
+}
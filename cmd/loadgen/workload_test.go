@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseMix(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []weightedOp
+		wantErr string
+	}{
+		{
+			name:  "default mix",
+			value: "list=50,new=20,count=20,put=10",
+			want:  []weightedOp{{opList, 50}, {opNew, 20}, {opCount, 20}, {opPut, 10}},
+		},
+		{
+			name:  "spaces and zero weights",
+			value: " list=3 , put=0, count=1",
+			want:  []weightedOp{{opList, 3}, {opCount, 1}},
+		},
+		{
+			name:    "missing weight",
+			value:   "list",
+			wantErr: `invalid mix entry "list", expected op=weight`,
+		},
+		{
+			name:    "unknown operation",
+			value:   "list=1,delete=1",
+			wantErr: `unknown operation "delete", expected one of list, new, count, put`,
+		},
+		{
+			name:    "negative weight",
+			value:   "list=-1",
+			wantErr: `invalid weight "-1" for list`,
+		},
+		{
+			name:    "non-numeric weight",
+			value:   "new=many",
+			wantErr: `invalid weight "many" for new`,
+		},
+		{
+			name:    "all weights zero",
+			value:   "list=0,put=0",
+			wantErr: "mix has no operation with a positive weight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMix(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/labstack/gommon/log"
+	"muzz-homework/internal/explore/adapters/http"
+	"os"
+)
+
+func main() {
+	spec, err := http.OpenAPISpec()
+	if err != nil {
+		log.Fatalf("failed to build openapi spec: %v", err)
+		return
+	}
+
+	if _, err := os.Stdout.Write(append(spec, '\n')); err != nil {
+		log.Fatalf("failed to write openapi spec: %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pb "muzz-homework/pkg/proto"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// DeadlineConfig bounds requests that arrive without a client deadline so
+// they can't hold database connections indefinitely. Client deadlines are
+// always kept as they are.
+type DeadlineConfig struct {
+	Default time.Duration
+	// PerMethod overrides Default, keyed by full method name.
+	PerMethod map[string]time.Duration
+}
+
+func (c DeadlineConfig) timeout(fullMethod string) time.Duration {
+	if timeout, ok := c.PerMethod[fullMethod]; ok {
+		return timeout
+	}
+
+	return c.Default
+}
+
+// AdminToken is a bearer token that may call the admin RPCs. Name says who
+// holds it and is recorded in the audit log as the requester.
+type AdminToken struct {
+	Name   string
+	Secret string
+}
+
+// AdminConfig lists the bearer tokens that may call the admin RPCs. With no
+// tokens configured the admin RPCs are refused to everyone.
+type AdminConfig struct {
+	Tokens []AdminToken
+}
+
+// adminMethods are the RPCs that read or delete other users' data, or
+// review abuse flags.
+var adminMethods = map[string]bool{
+	pb.ExploreService_EraseUser_FullMethodName:      true,
+	pb.ExploreService_ExportUserData_FullMethodName: true,
+	pb.ExploreService_ListAbuseFlags_FullMethodName: true,
+	pb.ExploreService_ClearAbuseFlag_FullMethodName: true,
+}
+
+// authorize requires an "authorization: Bearer <token>" header carrying one
+// of the admin tokens, and returns that token's name.
+func (c AdminConfig) authorize(ctx context.Context) (string, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	for _, admin := range c.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(admin.Secret)) == 1 {
+			return admin.Name, nil
+		}
+	}
+
+	return "", status.Error(codes.PermissionDenied, "admin role required")
+}
+
+type adminKey struct{}
+
+// withAdmin marks the request as made by the named admin, so handlers can
+// allow admin-only options on the public RPCs and audit who called them.
+func withAdmin(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, adminKey{}, name)
+}
+
+// adminName returns the name of the admin token the request was made with,
+// or "" if it wasn't made by an admin.
+func adminName(ctx context.Context) string {
+	name, _ := ctx.Value(adminKey{}).(string)
+	return name
+}
+
+func isAdmin(ctx context.Context) bool {
+	return adminName(ctx) != ""
+}
+
+// requireAdmin returns the name of the admin making the request, to audit
+// the admin RPCs under. The interceptor already refuses them to anyone else.
+func requireAdmin(ctx context.Context) (string, error) {
+	name := adminName(ctx)
+	if name == "" {
+		return "", status.Error(codes.PermissionDenied, "admin role required")
+	}
+
+	return name, nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok && token != "" {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+// interceptors returns the server middleware, outermost first. Recovery
+// runs first so it also catches panics in the interceptors after it.
+func interceptors(config ServerConfig, logger logger) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			recoveryUnaryInterceptor(logger),
+			adminUnaryInterceptor(config.Admin),
+			deadlineUnaryInterceptor(config.Deadlines),
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStreamInterceptor(logger),
+			adminStreamInterceptor(config.Admin),
+			deadlineStreamInterceptor(config.Deadlines),
+		),
+	}
+}
+
+func recoveryUnaryInterceptor(logger logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredError(logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor(logger logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredError(logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+func recoveredError(logger logger, method string, r any) error {
+	logger.Error("panic recovered", "method", method, "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func adminUnaryInterceptor(config AdminConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		name, err := config.authorize(ctx)
+		if err != nil && adminMethods[info.FullMethod] {
+			return nil, err
+		}
+		if err == nil {
+			ctx = withAdmin(ctx, name)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func adminStreamInterceptor(config AdminConfig) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		name, err := config.authorize(stream.Context())
+		if err != nil && adminMethods[info.FullMethod] {
+			return err
+		}
+		if err == nil {
+			stream = &contextStream{ServerStream: stream, ctx: withAdmin(stream.Context(), name)}
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func deadlineUnaryInterceptor(config DeadlineConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := withDefaultDeadline(ctx, config.timeout(info.FullMethod))
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		return resp, contextError(ctx, err)
+	}
+}
+
+func deadlineStreamInterceptor(config DeadlineConfig) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withDefaultDeadline(stream.Context(), config.timeout(info.FullMethod))
+		defer cancel()
+
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		return contextError(ctx, err)
+	}
+}
+
+func withDefaultDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextError reports requests that failed because they ran out of time or
+// were cancelled as such, instead of the generic Internal the handlers return.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || status.Code(err) != codes.Internal {
+		return err
+	}
+
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
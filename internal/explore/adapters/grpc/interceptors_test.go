@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"muzz-homework/internal/explore/domain"
+	pb "muzz-homework/pkg/proto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) Error(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, fmt.Sprint(append([]any{format}, args...)...))
+}
+
+func (l *recordingLogger) logged() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.entries...)
+}
+
+func TestInterceptors_Recovery(t *testing.T) {
+	var calls int
+	provider := &mockDecisionProvider{
+		countLikedYou: func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+			calls++
+			if calls == 1 {
+				panic("boom")
+			}
+			return domain.LikersCount{Total: 3}, nil
+		},
+	}
+	logger := &recordingLogger{}
+
+	lis := startBufconnServer(t, ServerConfig{}, provider, logger)
+	client := pb.NewExploreServiceClient(dialBufconn(t, lis, insecure.NewCredentials()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: user1})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+
+	entries := logger.logged()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0], "panic recovered")
+	assert.Contains(t, entries[0], "/explore.ExploreService/CountLikedYou")
+	assert.Contains(t, entries[0], "boom")
+	assert.Contains(t, entries[0], "runtime/debug.Stack")
+
+	resp, err := client.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: user1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.GetCount())
+}
+
+func TestInterceptors_Deadline(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         DeadlineConfig
+		clientTimeout  time.Duration
+		wantDeadlineIn time.Duration
+		wantDeadline   bool
+	}{
+		{
+			name:           "default applied without client deadline",
+			config:         DeadlineConfig{Default: time.Second},
+			wantDeadline:   true,
+			wantDeadlineIn: time.Second,
+		},
+		{
+			name: "per method override",
+			config: DeadlineConfig{
+				Default:   time.Second,
+				PerMethod: map[string]time.Duration{pb.ExploreService_ListLikedYou_FullMethodName: 3 * time.Second},
+			},
+			wantDeadline:   true,
+			wantDeadlineIn: 3 * time.Second,
+		},
+		{
+			name:           "client deadline kept",
+			config:         DeadlineConfig{Default: time.Second},
+			clientTimeout:  4 * time.Second,
+			wantDeadline:   true,
+			wantDeadlineIn: 4 * time.Second,
+		},
+		{
+			name:         "no default configured",
+			config:       DeadlineConfig{},
+			wantDeadline: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockDecisionProvider{
+				listLikedYou: func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					deadline, ok := ctx.Deadline()
+					assert.Equal(t, tt.wantDeadline, ok)
+					if tt.wantDeadline {
+						assert.WithinDuration(t, time.Now().Add(tt.wantDeadlineIn), deadline, 500*time.Millisecond)
+					}
+					return nil, "", nil
+				},
+			}
+
+			lis := startBufconnServer(t, ServerConfig{Deadlines: tt.config}, provider, &mockLogger{})
+			client := pb.NewExploreServiceClient(dialBufconn(t, lis, insecure.NewCredentials()))
+
+			ctx := context.Background()
+			if tt.clientTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.clientTimeout)
+				defer cancel()
+			}
+
+			_, err := client.ListLikedYou(ctx, &pb.ListLikedYouRequest{RecipientUserId: user1})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInterceptors_DeadlineExceeded(t *testing.T) {
+	provider := &mockDecisionProvider{
+		countLikedYou: func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+			<-ctx.Done()
+			return domain.LikersCount{}, fmt.Errorf("failed to count likers: %w", ctx.Err())
+		},
+	}
+	logger := &mockLogger{error: func(format string, args ...any) {}}
+
+	lis := startBufconnServer(t, ServerConfig{Deadlines: DeadlineConfig{Default: 50 * time.Millisecond}}, provider, logger)
+	client := pb.NewExploreServiceClient(dialBufconn(t, lis, insecure.NewCredentials()))
+
+	_, err := client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{RecipientUserId: user1})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.True(t, strings.Contains(status.Convert(err).Message(), "deadline exceeded"))
+}
+
+func TestInterceptors_Admin(t *testing.T) {
+	tests := []struct {
+		name          string
+		tokens        []AdminToken
+		authorization string
+		wantCode      codes.Code
+		wantAdmin     string
+	}{
+		{
+			name:          "admin token",
+			tokens:        []AdminToken{{Name: "oncall", Secret: "oncall-token"}, {Name: "gdpr", Secret: "gdpr-token"}},
+			authorization: "Bearer gdpr-token",
+			wantCode:      codes.OK,
+			wantAdmin:     "gdpr",
+		},
+		{
+			name:     "missing token",
+			tokens:   []AdminToken{{Name: "oncall", Secret: "admin-token"}},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:          "not a bearer token",
+			tokens:        []AdminToken{{Name: "oncall", Secret: "admin-token"}},
+			authorization: "Basic admin-token",
+			wantCode:      codes.Unauthenticated,
+		},
+		{
+			name:          "unknown token",
+			tokens:        []AdminToken{{Name: "oncall", Secret: "admin-token"}},
+			authorization: "Bearer user-token",
+			wantCode:      codes.PermissionDenied,
+		},
+		{
+			name:          "token name is not a token",
+			tokens:        []AdminToken{{Name: "oncall", Secret: "admin-token"}},
+			authorization: "Bearer oncall",
+			wantCode:      codes.PermissionDenied,
+		},
+		{
+			name:          "no tokens configured",
+			authorization: "Bearer admin-token",
+			wantCode:      codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var erased, exported bool
+			userData := &mockUserDataManager{
+				eraseUser: func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error) {
+					erased = true
+					assert.Equal(t, tt.wantAdmin, requestedBy)
+					return domain.ErasureResult{}, nil
+				},
+				exportUserData: func(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error {
+					exported = true
+					assert.Equal(t, tt.wantAdmin, requestedBy)
+					return nil
+				},
+			}
+			var listed, cleared bool
+			abuse := &mockAbuseReviewer{
+				listFlags: func(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error) {
+					listed = true
+					return nil, nil
+				},
+				clearFlag: func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error {
+					cleared = true
+					assert.Equal(t, tt.wantAdmin, requestedBy)
+					return nil
+				},
+			}
+			server, err := NewGRPCServer("0", ServerConfig{Admin: AdminConfig{Tokens: tt.tokens}}, &mockDecisionProvider{},
+				&mockDecisionCreator{}, &mockCandidateProvider{}, userData, abuse, &recordingLogger{})
+			require.NoError(t, err)
+			lis := bufconn.Listen(1 << 20)
+			go server.Serve(lis)
+			t.Cleanup(server.Stop)
+			client := pb.NewExploreServiceClient(dialBufconn(t, lis, insecure.NewCredentials()))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+
+			_, err = client.EraseUser(ctx, &pb.EraseUserRequest{UserId: user1})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, erased)
+
+			stream, err := client.ExportUserData(ctx, &pb.ExportUserDataRequest{UserId: user1})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			if tt.wantCode == codes.OK {
+				assert.ErrorIs(t, err, io.EOF)
+			} else {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+			}
+			assert.Equal(t, tt.wantCode == codes.OK, exported)
+
+			_, err = client.ListAbuseFlags(ctx, &pb.ListAbuseFlagsRequest{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, listed)
+
+			_, err = client.ClearAbuseFlag(ctx, &pb.ClearAbuseFlagRequest{UserId: user1})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, cleared)
+		})
+	}
+}
+
+func TestInterceptors_AdminOnPublicMethods(t *testing.T) {
+	provider := &mockDecisionProvider{
+		countLikedYou: func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+			return domain.LikersCount{Total: 1}, nil
+		},
+	}
+
+	lis := startBufconnServer(t, ServerConfig{Admin: AdminConfig{Tokens: []AdminToken{{Name: "oncall", Secret: "admin-token"}}}}, provider, &recordingLogger{})
+	client := pb.NewExploreServiceClient(dialBufconn(t, lis, insecure.NewCredentials()))
+
+	_, err := client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{RecipientUserId: user1})
+	assert.NoError(t, err, "public methods need no token")
+
+	_, err = client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{RecipientUserId: user1, IncludeExpired: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer admin-token")
+	_, err = client.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: user1, IncludeExpired: true})
+	assert.NoError(t, err, "admins may include expired likes")
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"os"
+	"time"
+)
+
+// ServerConfig tunes the gRPC server. Zero values keep the grpc-go defaults.
+type ServerConfig struct {
+	TLS        TLSConfig
+	Reflection bool
+
+	// KeepaliveMinTime is the shortest ping interval clients may use before
+	// the server closes the connection with ENHANCE_YOUR_CALM.
+	KeepaliveMinTime             time.Duration
+	KeepalivePermitWithoutStream bool
+	KeepaliveTime                time.Duration
+	KeepaliveTimeout             time.Duration
+
+	MaxRecvMsgSize       int
+	MaxSendMsgSize       int
+	MaxConcurrentStreams uint32
+
+	// MaxConnectionAge makes clients reconnect periodically so connections
+	// rebalance across instances behind an L4 load balancer.
+	MaxConnectionAge      time.Duration
+	MaxConnectionAgeGrace time.Duration
+
+	Deadlines DeadlineConfig
+	Admin     AdminConfig
+}
+
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mTLS: clients must present a certificate signed by it.
+	ClientCAFile string
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// ClientTLSConfig configures callers of the server, such as the HTTP gateway.
+type ClientTLSConfig struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+func serverOptions(config ServerConfig, logger logger) ([]grpc.ServerOption, error) {
+	opts := interceptors(config, logger)
+
+	if config.TLS.Enabled() {
+		tlsConfig, err := loadServerTLS(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	if config.KeepaliveMinTime > 0 || config.KeepalivePermitWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.KeepaliveMinTime,
+			PermitWithoutStream: config.KeepalivePermitWithoutStream,
+		}))
+	}
+
+	opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+		Time:                  config.KeepaliveTime,
+		Timeout:               config.KeepaliveTimeout,
+		MaxConnectionAge:      config.MaxConnectionAge,
+		MaxConnectionAgeGrace: config.MaxConnectionAgeGrace,
+	}))
+
+	if config.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(config.MaxSendMsgSize))
+	}
+	if config.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(config.MaxConcurrentStreams))
+	}
+
+	return opts, nil
+}
+
+func loadServerTLS(config TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if config.ClientCAFile != "" {
+		pool, err := loadCertPool(config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func NewClientCredentials(config ClientTLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		ServerName: config.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if config.CAFile != "" {
+		pool, err := loadCertPool(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("failed to parse CA file: no certificates found")
+	}
+
+	return pool, nil
+}
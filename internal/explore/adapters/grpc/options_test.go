@@ -0,0 +1,268 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"math/big"
+	pb "muzz-homework/pkg/proto"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPKI struct {
+	caFile         string
+	serverCertFile string
+	serverKeyFile  string
+	clientCertFile string
+	clientKeyFile  string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	pki := testPKI{caFile: filepath.Join(dir, "ca.pem")}
+	writePEM(t, pki.caFile, "CERTIFICATE", caDER)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		certFile := filepath.Join(dir, name+".pem")
+		keyFile := filepath.Join(dir, name+"-key.pem")
+		writePEM(t, certFile, "CERTIFICATE", der)
+		writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+		return certFile, keyFile
+	}
+
+	pki.serverCertFile, pki.serverKeyFile = issue("bufnet", 2, x509.ExtKeyUsageServerAuth)
+	pki.clientCertFile, pki.clientKeyFile = issue("client", 3, x509.ExtKeyUsageClientAuth)
+
+	return pki
+}
+
+func writePEM(t *testing.T, file string, blockType string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+func startBufconnServer(t *testing.T, config ServerConfig, provider decisionProvider, logger logger) *bufconn.Listener {
+	t.Helper()
+
+	server, err := NewGRPCServer("0", config, provider, &mockDecisionCreator{}, &mockCandidateProvider{}, &mockUserDataManager{}, &mockAbuseReviewer{}, logger)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis
+}
+
+func dialBufconn(t *testing.T, lis *bufconn.Listener, creds credentials.TransportCredentials) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(creds),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestServer_TLS(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name      string
+		server    TLSConfig
+		client    ClientTLSConfig
+		wantError bool
+	}{
+		{
+			name:   "tls - trusted server",
+			server: TLSConfig{CertFile: pki.serverCertFile, KeyFile: pki.serverKeyFile},
+			client: ClientTLSConfig{CAFile: pki.caFile, ServerName: "bufnet"},
+		},
+		{
+			name:      "tls - untrusted server",
+			server:    TLSConfig{CertFile: pki.serverCertFile, KeyFile: pki.serverKeyFile},
+			client:    ClientTLSConfig{ServerName: "bufnet"},
+			wantError: true,
+		},
+		{
+			name:      "tls - server name mismatch",
+			server:    TLSConfig{CertFile: pki.serverCertFile, KeyFile: pki.serverKeyFile},
+			client:    ClientTLSConfig{CAFile: pki.caFile, ServerName: "other"},
+			wantError: true,
+		},
+		{
+			name: "mtls - client certificate",
+			server: TLSConfig{
+				CertFile:     pki.serverCertFile,
+				KeyFile:      pki.serverKeyFile,
+				ClientCAFile: pki.caFile,
+			},
+			client: ClientTLSConfig{
+				CAFile:     pki.caFile,
+				CertFile:   pki.clientCertFile,
+				KeyFile:    pki.clientKeyFile,
+				ServerName: "bufnet",
+			},
+		},
+		{
+			name: "mtls - missing client certificate",
+			server: TLSConfig{
+				CertFile:     pki.serverCertFile,
+				KeyFile:      pki.serverKeyFile,
+				ClientCAFile: pki.caFile,
+			},
+			client:    ClientTLSConfig{CAFile: pki.caFile, ServerName: "bufnet"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis := startBufconnServer(t, ServerConfig{TLS: tt.server}, &mockDecisionProvider{}, &mockLogger{})
+
+			creds, err := NewClientCredentials(tt.client)
+			require.NoError(t, err)
+			conn := dialBufconn(t, lis, creds)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+			}
+		})
+	}
+}
+
+func TestServer_TLSInvalidFiles(t *testing.T) {
+	_, err := NewGRPCServer("0", ServerConfig{TLS: TLSConfig{CertFile: "missing.pem", KeyFile: "missing-key.pem"}},
+		&mockDecisionProvider{}, &mockDecisionCreator{}, &mockCandidateProvider{}, &mockUserDataManager{}, &mockAbuseReviewer{}, &mockLogger{})
+	assert.Error(t, err)
+}
+
+func TestServer_Reflection(t *testing.T) {
+	tests := []struct {
+		name         string
+		reflection   bool
+		wantServices []string
+		wantCode     codes.Code
+	}{
+		{
+			name:         "enabled",
+			reflection:   true,
+			wantServices: []string{"explore.ExploreService", "grpc.health.v1.Health"},
+		},
+		{
+			name:       "disabled",
+			reflection: false,
+			wantCode:   codes.Unimplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis := startBufconnServer(t, ServerConfig{Reflection: tt.reflection}, &mockDecisionProvider{}, &mockLogger{})
+			conn := dialBufconn(t, lis, insecure.NewCredentials())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+				MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+			}))
+
+			resp, err := stream.Recv()
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var services []string
+			for _, service := range resp.GetListServicesResponse().GetService() {
+				services = append(services, service.Name)
+			}
+			assert.Subset(t, services, tt.wantServices)
+		})
+	}
+}
+
+func TestServer_MaxRecvMsgSize(t *testing.T) {
+	lis := startBufconnServer(t, ServerConfig{MaxRecvMsgSize: 1024}, &mockDecisionProvider{}, &mockLogger{})
+	conn := dialBufconn(t, lis, insecure.NewCredentials())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := pb.NewExploreServiceClient(conn).PutDecision(ctx, &pb.PutDecisionRequest{
+		ActorUserId:     strings.Repeat("a", 2048),
+		RecipientUserId: user2,
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -2,26 +2,44 @@ package grpc
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"io"
 	"muzz-homework/internal/explore/domain"
 	pb "muzz-homework/pkg/proto"
 	"net"
+	"time"
 )
 
 type decisionProvider interface {
-	ListLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error)
-	ListNewLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error)
-	CountLikedYou(ctx context.Context, recipientID string) (uint64, error)
+	ListLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	ListNewLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	CountLikedYou(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error)
+	MarkLikesSeen(ctx context.Context, recipientID domain.UserID, upTo domain.SeenWatermark) (domain.SeenWatermark, error)
 }
 
 type decisionCreator interface {
-	SaveDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error)
+	SaveDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error)
+}
+
+type candidateProvider interface {
+	GetCandidates(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error)
+}
+
+type userDataManager interface {
+	EraseUser(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error)
+	ExportUserData(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error
+}
+
+type abuseReviewer interface {
+	ListFlags(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error)
+	ClearFlag(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error
 }
 
 type logger interface {
@@ -30,57 +48,83 @@ type logger interface {
 
 type grpcServer struct {
 	pb.UnimplementedExploreServiceServer
-	engine   *grpc.Server
-	port     string
-	provider decisionProvider
-	creator  decisionCreator
-	logger   logger
+	engine     *grpc.Server
+	port       string
+	reflection bool
+	provider   decisionProvider
+	creator    decisionCreator
+	candidates candidateProvider
+	userData   userDataManager
+	abuse      abuseReviewer
+	logger     logger
 }
 
-func NewGRPCServer(port string, provider decisionProvider, creator decisionCreator, logger logger) *grpcServer {
-	return &grpcServer{
-		port:     port,
-		engine:   grpc.NewServer(),
-		provider: provider,
-		creator:  creator,
-		logger:   logger,
+func NewGRPCServer(port string, config ServerConfig, provider decisionProvider, creator decisionCreator, candidates candidateProvider, userData userDataManager, abuse abuseReviewer, logger logger) (*grpcServer, error) {
+	opts, err := serverOptions(config, logger)
+	if err != nil {
+		return nil, err
 	}
+
+	return &grpcServer{
+		port:       port,
+		engine:     grpc.NewServer(opts...),
+		reflection: config.Reflection,
+		provider:   provider,
+		creator:    creator,
+		candidates: candidates,
+		userData:   userData,
+		abuse:      abuse,
+		logger:     logger,
+	}, nil
 }
 
 func (s *grpcServer) Register() {
 	pb.RegisterExploreServiceServer(s.engine, s)
 	grpc_health_v1.RegisterHealthServer(s.engine, health.NewServer())
+	if s.reflection {
+		reflection.Register(s.engine)
+	}
 }
 
 func (s *grpcServer) Run() error {
-	s.Register()
-
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", s.port))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 
+	return s.Serve(lis)
+}
+
+func (s *grpcServer) Serve(lis net.Listener) error {
+	s.Register()
+
 	return s.engine.Serve(lis)
 }
 
 func (s *grpcServer) ListLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient user ID is required")
+	var violations fieldViolations
+	recipientID := violations.userID("recipient_user_id", "recipient user ID", req.RecipientUserId)
+	fields := violations.profileFields("include_profile", req.IncludeProfile)
+	if err := violations.err(); err != nil {
+		return nil, err
 	}
-
-	if req.PaginationToken != nil && !isValidBase64(*req.PaginationToken) {
-		return nil, status.Error(codes.InvalidArgument, "invalid pagination token format")
+	if err := requireAdminForExpired(ctx, req.IncludeExpired); err != nil {
+		return nil, err
 	}
 
-	likers, nextToken, err := s.provider.ListLikedYou(ctx, req.RecipientUserId, req.GetPaginationToken())
+	likers, nextToken, err := s.provider.ListLikedYou(ctx, recipientID, req.GetPaginationToken(), toListLikersOptions(req, fields))
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPaginationToken) || errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		s.logger.Error("ListLikedYou failed", err)
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
+	now := time.Now()
 	protoLikers := make([]*pb.ListLikedYouResponse_Liker, len(likers))
 	for i, liker := range likers {
-		protoLikers[i] = toLikerProto(liker)
+		protoLikers[i] = toLikerProto(liker, fields, now)
 	}
 
 	var nextTokenPtr *string
@@ -95,23 +139,29 @@ func (s *grpcServer) ListLikedYou(ctx context.Context, req *pb.ListLikedYouReque
 }
 
 func (s *grpcServer) ListNewLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient user ID is required")
+	var violations fieldViolations
+	recipientID := violations.userID("recipient_user_id", "recipient user ID", req.RecipientUserId)
+	fields := violations.profileFields("include_profile", req.IncludeProfile)
+	if err := violations.err(); err != nil {
+		return nil, err
 	}
-
-	if req.PaginationToken != nil && !isValidBase64(*req.PaginationToken) {
-		return nil, status.Error(codes.InvalidArgument, "invalid pagination token format")
+	if err := requireAdminForExpired(ctx, req.IncludeExpired); err != nil {
+		return nil, err
 	}
 
-	likers, nextToken, err := s.provider.ListNewLikedYou(ctx, req.RecipientUserId, req.GetPaginationToken())
+	likers, nextToken, err := s.provider.ListNewLikedYou(ctx, recipientID, req.GetPaginationToken(), toListLikersOptions(req, fields))
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPaginationToken) || errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		s.logger.Error("ListNewLikedYou failed", err)
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
+	now := time.Now()
 	protoLikers := make([]*pb.ListLikedYouResponse_Liker, len(likers))
 	for i, liker := range likers {
-		protoLikers[i] = toLikerProto(liker)
+		protoLikers[i] = toLikerProto(liker, fields, now)
 	}
 
 	var nextTokenPtr *string
@@ -126,32 +176,75 @@ func (s *grpcServer) ListNewLikedYou(ctx context.Context, req *pb.ListLikedYouRe
 }
 
 func (s *grpcServer) CountLikedYou(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
-	if req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient user ID is required")
+	var violations fieldViolations
+	recipientID := violations.userID("recipient_user_id", "recipient user ID", req.RecipientUserId)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+	if err := requireAdminForExpired(ctx, req.IncludeExpired); err != nil {
+		return nil, err
 	}
 
-	count, err := s.provider.CountLikedYou(ctx, req.RecipientUserId)
+	count, err := s.provider.CountLikedYou(ctx, recipientID, domain.CountLikersOptions{
+		IncludeExpired: req.IncludeExpired,
+	})
 	if err != nil {
 		s.logger.Error("CountLikedYou failed", err)
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
 	return &pb.CountLikedYouResponse{
-		Count: count,
+		Count:       count.Total,
+		UnseenCount: count.Unseen,
 	}, nil
 }
 
-func (s *grpcServer) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
-	if req.ActorUserId == "" || req.RecipientUserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "both actor and recipient user IDs are required")
+func (s *grpcServer) MarkLikesSeen(ctx context.Context, req *pb.MarkLikesSeenRequest) (*pb.MarkLikesSeenResponse, error) {
+	var violations fieldViolations
+	recipientID := violations.userID("recipient_user_id", "recipient user ID", req.RecipientUserId)
+	violations.required("up_to_cursor", "up to cursor", req.UpToCursor != 0)
+	upToActorID := violations.userID("up_to_actor_id", "up to actor ID", req.UpToActorId)
+	if err := violations.err(); err != nil {
+		return nil, err
 	}
 
-	if req.ActorUserId == req.RecipientUserId {
-		return nil, status.Error(codes.InvalidArgument, "both actor and recipient user IDs are the same")
+	seenUpTo, err := s.provider.MarkLikesSeen(ctx, recipientID, domain.SeenWatermark{Timestamp: req.UpToCursor, ActorID: upToActorID})
+	if err != nil {
+		s.logger.Error("MarkLikesSeen failed", err)
+		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
-	mutualLikes, err := s.creator.SaveDecision(ctx, req.ActorUserId, req.RecipientUserId, req.LikedRecipient)
+	return &pb.MarkLikesSeenResponse{
+		SeenUpTo:        seenUpTo.Timestamp,
+		SeenUpToActorId: string(seenUpTo.ActorID),
+	}, nil
+}
+
+func (s *grpcServer) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
+	var violations fieldViolations
+	actorID := violations.userID("actor_user_id", "actor user ID", req.ActorUserId)
+	recipientID := violations.userID("recipient_user_id", "recipient user ID", req.RecipientUserId)
+	if actorID != "" && actorID == recipientID {
+		violations.add("recipient_user_id", "both actor and recipient user IDs are the same")
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	mutualLikes, err := s.creator.SaveDecision(ctx, actorID, recipientID, toDecision(req))
 	if err != nil {
+		if errors.Is(err, domain.ErrSuperLikeQuotaExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrUserNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		s.logger.Error("PutDecision failed", err)
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
@@ -161,6 +254,118 @@ func (s *grpcServer) PutDecision(ctx context.Context, req *pb.PutDecisionRequest
 	}, nil
 }
 
+func (s *grpcServer) GetCandidates(ctx context.Context, req *pb.GetCandidatesRequest) (*pb.GetCandidatesResponse, error) {
+	var violations fieldViolations
+	actorID := violations.userID("actor_user_id", "actor user ID", req.ActorUserId)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.candidates.GetCandidates(ctx, actorID, req.PageSize)
+	if err != nil {
+		s.logger.Error("GetCandidates failed", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	protoUserIDs := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		protoUserIDs[i] = id.String()
+	}
+
+	return &pb.GetCandidatesResponse{
+		UserIds: protoUserIDs,
+	}, nil
+}
+
+func (s *grpcServer) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	var violations fieldViolations
+	userID := violations.userID("user_id", "user ID", req.UserId)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	requestedBy, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.userData.EraseUser(ctx, userID, requestedBy, req.Reason)
+	if err != nil {
+		s.logger.Error("EraseUser failed", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	return &pb.EraseUserResponse{
+		DecisionsDeleted:  result.DecisionsDeleted,
+		CountersRecounted: result.CountersRecounted,
+	}, nil
+}
+
+func (s *grpcServer) ExportUserData(req *pb.ExportUserDataRequest, stream grpc.ServerStreamingServer[pb.ExportUserDataResponse]) error {
+	var violations fieldViolations
+	userID := violations.userID("user_id", "user ID", req.UserId)
+	if err := violations.err(); err != nil {
+		return err
+	}
+
+	requestedBy, err := requireAdmin(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := s.userData.ExportUserData(stream.Context(), userID, requestedBy, &exportStreamWriter{stream: stream}); err != nil {
+		s.logger.Error("ExportUserData failed", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	return nil
+}
+
+func (s *grpcServer) ListAbuseFlags(ctx context.Context, req *pb.ListAbuseFlagsRequest) (*pb.ListAbuseFlagsResponse, error) {
+	flags, err := s.abuse.ListFlags(ctx, req.PageSize)
+	if err != nil {
+		s.logger.Error("ListAbuseFlags failed", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	protoFlags := make([]*pb.ListAbuseFlagsResponse_AbuseFlag, len(flags))
+	for i, flag := range flags {
+		protoFlags[i] = &pb.ListAbuseFlagsResponse_AbuseFlag{
+			UserId:    flag.UserID.String(),
+			Reason:    toAbuseReasonProto(flag.Reason),
+			Details:   flag.Details,
+			FlaggedAt: flag.FlaggedAt,
+		}
+	}
+
+	return &pb.ListAbuseFlagsResponse{
+		Flags: protoFlags,
+	}, nil
+}
+
+func (s *grpcServer) ClearAbuseFlag(ctx context.Context, req *pb.ClearAbuseFlagRequest) (*pb.ClearAbuseFlagResponse, error) {
+	var violations fieldViolations
+	userID := violations.userID("user_id", "user ID", req.UserId)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	requestedBy, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.abuse.ClearFlag(ctx, userID, requestedBy, req.Reason); err != nil {
+		if errors.Is(err, domain.ErrAbuseFlagNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		s.logger.Error("ClearAbuseFlag failed", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	return &pb.ClearAbuseFlagResponse{}, nil
+}
+
 func (s *grpcServer) GracefulStop() {
 	s.engine.GracefulStop()
 }
@@ -169,14 +374,128 @@ func (s *grpcServer) Stop() {
 	s.engine.Stop()
 }
 
-func isValidBase64(s string) bool {
-	_, err := base64.StdEncoding.DecodeString(s)
-	return err == nil
+type exportStreamWriter struct {
+	stream grpc.ServerStreamingServer[pb.ExportUserDataResponse]
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	if err := w.stream.Send(&pb.ExportUserDataResponse{JsonLines: data}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// requireAdminForExpired keeps expired likes, which users are no longer meant
+// to see, to admin callers.
+func requireAdminForExpired(ctx context.Context, includeExpired bool) error {
+	if includeExpired && !isAdmin(ctx) {
+		return status.Error(codes.PermissionDenied, "include_expired requires the admin role")
+	}
+
+	return nil
+}
+
+func toListLikersOptions(req *pb.ListLikedYouRequest, fields profileFields) domain.ListLikersOptions {
+	return domain.ListLikersOptions{
+		Filter:          toLikersFilter(req.Filter),
+		UnseenOnly:      req.UnseenOnly,
+		SuperLikesFirst: req.SuperLikesFirst,
+		IncludeExpired:  req.IncludeExpired,
+		IncludeProfile:  fields.any(),
+	}
+}
+
+func toLikersFilter(filter pb.LikerFilter) domain.LikersFilter {
+	switch filter {
+	case pb.LikerFilter_LIKER_FILTER_UNSPECIFIED:
+		return ""
+	case pb.LikerFilter_LIKER_FILTER_ALL:
+		return domain.LikersFilterAll
+	case pb.LikerFilter_LIKER_FILTER_PENDING:
+		return domain.LikersFilterPending
+	case pb.LikerFilter_LIKER_FILTER_MATCHED:
+		return domain.LikersFilterMatched
+	case pb.LikerFilter_LIKER_FILTER_REJECTED:
+		return domain.LikersFilterRejected
+	default:
+		return domain.LikersFilter(filter.String())
+	}
+}
+
+func toDecision(req *pb.PutDecisionRequest) domain.Decision {
+	switch req.Decision {
+	case pb.Decision_DECISION_UNSPECIFIED:
+		return domain.DecisionFromLiked(req.LikedRecipient)
+	case pb.Decision_DECISION_PASS:
+		return domain.DecisionPass
+	case pb.Decision_DECISION_LIKE:
+		return domain.DecisionLike
+	case pb.Decision_DECISION_SUPER_LIKE:
+		return domain.DecisionSuperLike
+	default:
+		return domain.Decision(-1)
+	}
+}
+
+func toDecisionProto(decision domain.Decision) pb.Decision {
+	switch decision {
+	case domain.DecisionPass:
+		return pb.Decision_DECISION_PASS
+	case domain.DecisionLike:
+		return pb.Decision_DECISION_LIKE
+	case domain.DecisionSuperLike:
+		return pb.Decision_DECISION_SUPER_LIKE
+	default:
+		return pb.Decision_DECISION_UNSPECIFIED
+	}
 }
 
-func toLikerProto(info domain.LikerInfo) *pb.ListLikedYouResponse_Liker {
-	return &pb.ListLikedYouResponse_Liker{
-		ActorId:       info.ActorID,
+func toLikerProto(info domain.LikerInfo, fields profileFields, now time.Time) *pb.ListLikedYouResponse_Liker {
+	liker := &pb.ListLikedYouResponse_Liker{
+		ActorId:       info.ActorID.String(),
 		UnixTimestamp: info.Timestamp,
+		Decision:      toDecisionProto(info.Decision),
+	}
+
+	if info.Profile != nil && fields.any() {
+		liker.Profile = toProfileProto(*info.Profile, fields, now)
+	}
+
+	return liker
+}
+
+// toProfileProto only sets the fields in the mask. Age is left unset when the
+// birth date is unknown.
+func toProfileProto(profile domain.Profile, fields profileFields, now time.Time) *pb.Profile {
+	msg := &pb.Profile{}
+	if fields.name {
+		msg.Name = &profile.Name
+	}
+
+	if fields.photoURL {
+		msg.PhotoUrl = &profile.PhotoURL
+	}
+
+	if age := profile.Age(now); fields.age && age > 0 {
+		msg.Age = &age
+	}
+
+	return msg
+}
+
+func toAbuseReasonProto(reason domain.AbuseReason) pb.AbuseReason {
+	switch reason {
+	case domain.AbuseReasonVelocity:
+		return pb.AbuseReason_ABUSE_REASON_VELOCITY
+	case domain.AbuseReasonLikeRatio:
+		return pb.AbuseReason_ABUSE_REASON_LIKE_RATIO
+	case domain.AbuseReasonSequentialIDs:
+		return pb.AbuseReason_ABUSE_REASON_SEQUENTIAL_IDS
+	default:
+		return pb.AbuseReason_ABUSE_REASON_UNSPECIFIED
 	}
 }
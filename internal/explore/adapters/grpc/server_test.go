@@ -2,38 +2,93 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"io"
 	"muzz-homework/internal/explore/domain"
 	pb "muzz-homework/pkg/proto"
+	"strings"
 	"testing"
+	"time"
+)
+
+// Request user IDs must be UUIDs.
+const (
+	user1 = "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01"
+	user2 = "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e02"
+	user3 = "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e03"
 )
 
 type mockDecisionProvider struct {
-	listLikedYou    func(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error)
-	listNewLikedYou func(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error)
-	countLikedYou   func(ctx context.Context, recipientID string) (uint64, error)
+	listLikedYou    func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	listNewLikedYou func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	countLikedYou   func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error)
+	markLikesSeen   func(ctx context.Context, recipientID domain.UserID, upTo domain.SeenWatermark) (domain.SeenWatermark, error)
 }
 
-func (m *mockDecisionProvider) ListLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error) {
-	return m.listLikedYou(ctx, recipientID, encodedToken)
+func (m *mockDecisionProvider) ListLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	return m.listLikedYou(ctx, recipientID, encodedToken, opts)
 }
 
-func (m *mockDecisionProvider) ListNewLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error) {
-	return m.listNewLikedYou(ctx, recipientID, encodedToken)
+func (m *mockDecisionProvider) ListNewLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	return m.listNewLikedYou(ctx, recipientID, encodedToken, opts)
 }
 
-func (m *mockDecisionProvider) CountLikedYou(ctx context.Context, recipientID string) (uint64, error) {
-	return m.countLikedYou(ctx, recipientID)
+func (m *mockDecisionProvider) CountLikedYou(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+	return m.countLikedYou(ctx, recipientID, opts)
+}
+
+func (m *mockDecisionProvider) MarkLikesSeen(ctx context.Context, recipientID domain.UserID, upTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+	return m.markLikesSeen(ctx, recipientID, upTo)
 }
 
 type mockDecisionCreator struct {
-	saveDecision func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error)
+	saveDecision func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error)
+}
+
+func (m *mockDecisionCreator) SaveDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+	return m.saveDecision(ctx, actorID, recipientID, decision)
+}
+
+type mockCandidateProvider struct {
+	getCandidates func(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error)
+}
+
+func (m *mockCandidateProvider) GetCandidates(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error) {
+	return m.getCandidates(ctx, actorID, pageSize)
+}
+
+type mockUserDataManager struct {
+	eraseUser      func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error)
+	exportUserData func(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error
+}
+
+func (m *mockUserDataManager) EraseUser(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error) {
+	return m.eraseUser(ctx, userID, requestedBy, reason)
+}
+
+func (m *mockUserDataManager) ExportUserData(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error {
+	return m.exportUserData(ctx, userID, requestedBy, w)
+}
+
+type mockAbuseReviewer struct {
+	listFlags func(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error)
+	clearFlag func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error
 }
 
-func (m *mockDecisionCreator) SaveDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-	return m.saveDecision(ctx, actorID, recipientID, liked)
+func (m *mockAbuseReviewer) ListFlags(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error) {
+	return m.listFlags(ctx, pageSize)
+}
+
+func (m *mockAbuseReviewer) ClearFlag(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error {
+	return m.clearFlag(ctx, userID, requestedBy, reason)
 }
 
 type mockLogger struct {
@@ -48,27 +103,34 @@ func TestServer(t *testing.T) {
 	tests := []struct {
 		name          string
 		req           interface{}
+		method        string
+		admin         bool
 		mockBehavior  func(*mockDecisionProvider, *mockDecisionCreator, *mockLogger)
+		userData      func(*mockUserDataManager)
+		candidates    func(*mockCandidateProvider)
+		abuse         func(*mockAbuseReviewer)
 		expectedResp  interface{}
 		expectedError error
 	}{
 		{
 			name: "ListLikedYou - success",
 			req: &pb.ListLikedYouRequest{
-				RecipientUserId: "user1",
+				RecipientUserId: user1,
 			},
 			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
-				mp.listLikedYou = func(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error) {
+				mp.listLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
 					return []domain.LikerInfo{{
-						ActorID:   "user2",
+						ActorID:   user2,
 						Timestamp: 1234567890,
+						Decision:  domain.DecisionSuperLike,
 					}}, "next_token", nil
 				}
 			},
 			expectedResp: &pb.ListLikedYouResponse{
 				Likers: []*pb.ListLikedYouResponse_Liker{{
-					ActorId:       "user2",
+					ActorId:       user2,
 					UnixTimestamp: 1234567890,
+					Decision:      pb.Decision_DECISION_SUPER_LIKE,
 				}},
 				NextPaginationToken: stringPtr("next_token"),
 			},
@@ -82,30 +144,179 @@ func TestServer(t *testing.T) {
 			expectedResp:  nil,
 			expectedError: status.Error(codes.InvalidArgument, "recipient user ID is required"),
 		},
+		{
+			name: "ListLikedYou - invalid pagination token",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				PaginationToken: stringPtr("forged"),
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.listLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					return nil, "", domain.ErrTokenSignature
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.InvalidArgument, domain.ErrTokenSignature.Error()),
+		},
 		{
 			name: "CountLikedYou - success",
 			req: &pb.CountLikedYouRequest{
-				RecipientUserId: "user1",
+				RecipientUserId: user1,
+				IncludeExpired:  true,
 			},
+			admin: true,
 			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
-				mp.countLikedYou = func(ctx context.Context, recipientID string) (uint64, error) {
-					return 42, nil
+				mp.countLikedYou = func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+					assert.True(t, opts.IncludeExpired)
+					return domain.LikersCount{Total: 42, Unseen: 3}, nil
 				}
 			},
 			expectedResp: &pb.CountLikedYouResponse{
-				Count: 42,
+				Count:       42,
+				UnseenCount: 3,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "CountLikedYou - include expired needs admin",
+			req: &pb.CountLikedYouRequest{
+				RecipientUserId: user1,
+				IncludeExpired:  true,
+			},
+			mockBehavior:  func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.PermissionDenied, "include_expired requires the admin role"),
+		},
+		{
+			name: "ListLikedYou - include expired needs admin",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				IncludeExpired:  true,
+			},
+			mockBehavior:  func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.PermissionDenied, "include_expired requires the admin role"),
+		},
+		{
+			name: "ListNewLikedYou - include expired as admin",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				IncludeExpired:  true,
+			},
+			method: "ListNewLikedYou",
+			admin:  true,
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.listNewLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					assert.True(t, opts.IncludeExpired)
+					return []domain.LikerInfo{}, "", nil
+				}
+			},
+			expectedResp: &pb.ListLikedYouResponse{
+				Likers: []*pb.ListLikedYouResponse_Liker{},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ListNewLikedYou - unseen only",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				UnseenOnly:      true,
+			},
+			method: "ListNewLikedYou",
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.listNewLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					assert.True(t, opts.UnseenOnly)
+					return []domain.LikerInfo{}, "", nil
+				}
+			},
+			expectedResp: &pb.ListLikedYouResponse{
+				Likers: []*pb.ListLikedYouResponse_Liker{},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ListLikedYou - rejected filter",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				Filter:          pb.LikerFilter_LIKER_FILTER_REJECTED,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.listLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					assert.Equal(t, domain.LikersFilterRejected, opts.Filter)
+					return []domain.LikerInfo{}, "", nil
+				}
+			},
+			expectedResp: &pb.ListLikedYouResponse{
+				Likers: []*pb.ListLikedYouResponse_Liker{},
 			},
 			expectedError: nil,
 		},
+		{
+			name: "ListNewLikedYou - unsupported filter",
+			req: &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				Filter:          pb.LikerFilter_LIKER_FILTER_MATCHED,
+			},
+			method: "ListNewLikedYou",
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.listNewLikedYou = func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					return nil, "", domain.ErrInvalidFilter
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.InvalidArgument, domain.ErrInvalidFilter.Error()),
+		},
+		{
+			name: "MarkLikesSeen - success",
+			req: &pb.MarkLikesSeenRequest{
+				RecipientUserId: user1,
+				UpToCursor:      1234567890,
+				UpToActorId:     strings.ToUpper(user2),
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mp.markLikesSeen = func(ctx context.Context, recipientID domain.UserID, upTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+					assert.Equal(t, domain.SeenWatermark{Timestamp: 1234567890, ActorID: user2}, upTo)
+					return upTo, nil
+				}
+			},
+			expectedResp: &pb.MarkLikesSeenResponse{
+				SeenUpTo:        1234567890,
+				SeenUpToActorId: user2,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "MarkLikesSeen - missing cursor",
+			req: &pb.MarkLikesSeenRequest{
+				RecipientUserId: user1,
+				UpToActorId:     user2,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.InvalidArgument, "up to cursor is required"),
+		},
+		{
+			name: "MarkLikesSeen - missing actor",
+			req: &pb.MarkLikesSeenRequest{
+				RecipientUserId: user1,
+				UpToCursor:      1234567890,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.InvalidArgument, "up to actor ID is required"),
+		},
 		{
 			name: "PutDecision - success",
 			req: &pb.PutDecisionRequest{
-				ActorUserId:     "user1",
-				RecipientUserId: "user2",
+				ActorUserId:     user1,
+				RecipientUserId: user2,
 				LikedRecipient:  true,
 			},
 			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
-				mc.saveDecision = func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
+				mc.saveDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					assert.Equal(t, domain.DecisionLike, decision)
 					return true, nil
 				}
 			},
@@ -114,11 +325,235 @@ func TestServer(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "PutDecision - decision takes precedence over liked flag",
+			req: &pb.PutDecisionRequest{
+				ActorUserId:     user1,
+				RecipientUserId: user2,
+				LikedRecipient:  false,
+				Decision:        pb.Decision_DECISION_SUPER_LIKE,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mc.saveDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					assert.Equal(t, domain.DecisionSuperLike, decision)
+					return false, nil
+				}
+			},
+			expectedResp: &pb.PutDecisionResponse{
+				MutualLikes: false,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "PutDecision - super like quota exceeded",
+			req: &pb.PutDecisionRequest{
+				ActorUserId:     user1,
+				RecipientUserId: user2,
+				Decision:        pb.Decision_DECISION_SUPER_LIKE,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mc.saveDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					return false, domain.ErrSuperLikeQuotaExceeded
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.ResourceExhausted, domain.ErrSuperLikeQuotaExceeded.Error()),
+		},
+		{
+			name: "PutDecision - unknown recipient",
+			req: &pb.PutDecisionRequest{
+				ActorUserId:     user1,
+				RecipientUserId: user2,
+				LikedRecipient:  true,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mc.saveDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					return false, fmt.Errorf("recipient: %w", domain.ErrUserNotFound)
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.NotFound, "recipient: user not found"),
+		},
+		{
+			name: "PutDecision - banned actor",
+			req: &pb.PutDecisionRequest{
+				ActorUserId:     user1,
+				RecipientUserId: user2,
+				LikedRecipient:  true,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				mc.saveDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					return false, fmt.Errorf("actor is banned: %w", domain.ErrUserNotActive)
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.FailedPrecondition, "actor is banned: user is not active"),
+		},
+		{
+			name: "GetCandidates - success",
+			req: &pb.GetCandidatesRequest{
+				ActorUserId: user1,
+				PageSize:    2,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			candidates: func(mc *mockCandidateProvider) {
+				mc.getCandidates = func(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error) {
+					assert.Equal(t, domain.UserID(user1), actorID)
+					assert.Equal(t, uint64(2), pageSize)
+					return []domain.UserID{user2, user3}, nil
+				}
+			},
+			expectedResp: &pb.GetCandidatesResponse{
+				UserIds: []string{user2, user3},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "GetCandidates - missing actor",
+			req:  &pb.GetCandidatesRequest{},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.InvalidArgument, "actor user ID is required"),
+		},
+		{
+			name: "GetCandidates - source error",
+			req: &pb.GetCandidatesRequest{
+				ActorUserId: user1,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				ml.error = func(format string, args ...any) {}
+			},
+			candidates: func(mc *mockCandidateProvider) {
+				mc.getCandidates = func(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.Internal, "internal server error"),
+		},
+		{
+			name: "EraseUser - success",
+			req: &pb.EraseUserRequest{
+				UserId: user1,
+				Reason: "account deleted",
+			},
+			admin: true,
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			userData: func(mu *mockUserDataManager) {
+				mu.eraseUser = func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error) {
+					assert.Equal(t, "oncall", requestedBy, "the admin token's name")
+					return domain.ErasureResult{DecisionsDeleted: 12, CountersRecounted: 4}, nil
+				}
+			},
+			expectedResp: &pb.EraseUserResponse{
+				DecisionsDeleted:  12,
+				CountersRecounted: 4,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "EraseUser - not an admin",
+			req: &pb.EraseUserRequest{
+				UserId: user1,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.PermissionDenied, "admin role required"),
+		},
+		{
+			name: "ListAbuseFlags - success",
+			req: &pb.ListAbuseFlagsRequest{
+				PageSize: 10,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			abuse: func(ma *mockAbuseReviewer) {
+				ma.listFlags = func(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error) {
+					return []domain.AbuseFlag{{
+						UserID:    user1,
+						Reason:    domain.AbuseReasonSequentialIDs,
+						Details:   "250 decisions in a row on ascending user IDs, limit 200",
+						FlaggedAt: 1234567890,
+					}}, nil
+				}
+			},
+			expectedResp: &pb.ListAbuseFlagsResponse{
+				Flags: []*pb.ListAbuseFlagsResponse_AbuseFlag{{
+					UserId:    user1,
+					Reason:    pb.AbuseReason_ABUSE_REASON_SEQUENTIAL_IDS,
+					Details:   "250 decisions in a row on ascending user IDs, limit 200",
+					FlaggedAt: 1234567890,
+				}},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ListAbuseFlags - internal error",
+			req:  &pb.ListAbuseFlagsRequest{},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+				ml.error = func(format string, args ...any) {}
+			},
+			abuse: func(ma *mockAbuseReviewer) {
+				ma.listFlags = func(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.Internal, "internal server error"),
+		},
+		{
+			name: "ClearAbuseFlag - success",
+			req: &pb.ClearAbuseFlagRequest{
+				UserId: user1,
+				Reason: "false positive",
+			},
+			admin: true,
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			abuse: func(ma *mockAbuseReviewer) {
+				ma.clearFlag = func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error {
+					assert.Equal(t, "oncall", requestedBy, "the admin token's name")
+					return nil
+				}
+			},
+			expectedResp:  &pb.ClearAbuseFlagResponse{},
+			expectedError: nil,
+		},
+		{
+			name: "ClearAbuseFlag - not an admin",
+			req: &pb.ClearAbuseFlagRequest{
+				UserId: user1,
+			},
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.PermissionDenied, "admin role required"),
+		},
+		{
+			name: "ClearAbuseFlag - not flagged",
+			req: &pb.ClearAbuseFlagRequest{
+				UserId: user1,
+			},
+			admin: true,
+			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
+			},
+			abuse: func(ma *mockAbuseReviewer) {
+				ma.clearFlag = func(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error {
+					return fmt.Errorf("failed to clear abuse flag: %w", domain.ErrAbuseFlagNotFound)
+				}
+			},
+			expectedResp:  nil,
+			expectedError: status.Error(codes.NotFound, "failed to clear abuse flag: abuse flag not found"),
+		},
 		{
 			name: "PutDecision - same user",
 			req: &pb.PutDecisionRequest{
-				ActorUserId:     "user1",
-				RecipientUserId: "user1",
+				ActorUserId:     user1,
+				RecipientUserId: user1,
 				LikedRecipient:  true,
 			},
 			mockBehavior: func(mp *mockDecisionProvider, mc *mockDecisionCreator, ml *mockLogger) {
@@ -134,20 +569,52 @@ func TestServer(t *testing.T) {
 			mockCreator := &mockDecisionCreator{}
 			mockLogger := &mockLogger{}
 
+			mockUserData := &mockUserDataManager{}
+			mockCandidates := &mockCandidateProvider{}
+			mockAbuse := &mockAbuseReviewer{}
+
 			tt.mockBehavior(mockProvider, mockCreator, mockLogger)
+			if tt.userData != nil {
+				tt.userData(mockUserData)
+			}
+			if tt.candidates != nil {
+				tt.candidates(mockCandidates)
+			}
+			if tt.abuse != nil {
+				tt.abuse(mockAbuse)
+			}
 
-			server := NewGRPCServer("8080", mockProvider, mockCreator, mockLogger)
+			server, err := NewGRPCServer("8080", ServerConfig{}, mockProvider, mockCreator, mockCandidates, mockUserData, mockAbuse, mockLogger)
+			assert.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.admin {
+				ctx = withAdmin(ctx, "oncall")
+			}
 
 			var resp interface{}
-			var err error
 
 			switch req := tt.req.(type) {
 			case *pb.ListLikedYouRequest:
-				resp, err = server.ListLikedYou(context.Background(), req)
+				if tt.method == "ListNewLikedYou" {
+					resp, err = server.ListNewLikedYou(ctx, req)
+				} else {
+					resp, err = server.ListLikedYou(ctx, req)
+				}
 			case *pb.CountLikedYouRequest:
-				resp, err = server.CountLikedYou(context.Background(), req)
+				resp, err = server.CountLikedYou(ctx, req)
 			case *pb.PutDecisionRequest:
-				resp, err = server.PutDecision(context.Background(), req)
+				resp, err = server.PutDecision(ctx, req)
+			case *pb.MarkLikesSeenRequest:
+				resp, err = server.MarkLikesSeen(ctx, req)
+			case *pb.GetCandidatesRequest:
+				resp, err = server.GetCandidates(ctx, req)
+			case *pb.EraseUserRequest:
+				resp, err = server.EraseUser(ctx, req)
+			case *pb.ListAbuseFlagsRequest:
+				resp, err = server.ListAbuseFlags(ctx, req)
+			case *pb.ClearAbuseFlagRequest:
+				resp, err = server.ClearAbuseFlag(ctx, req)
 			}
 
 			if tt.expectedError != nil {
@@ -161,6 +628,197 @@ func TestServer(t *testing.T) {
 	}
 }
 
+type mockExportStream struct {
+	grpc.ServerStream
+	sent []*pb.ExportUserDataResponse
+}
+
+func (m *mockExportStream) Context() context.Context {
+	return withAdmin(context.Background(), "oncall")
+}
+
+func (m *mockExportStream) Send(resp *pb.ExportUserDataResponse) error {
+	m.sent = append(m.sent, resp)
+	return nil
+}
+
+func TestServer_ExportUserData(t *testing.T) {
+	mockUserData := &mockUserDataManager{
+		exportUserData: func(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error {
+			assert.Equal(t, domain.UserID(user1), userID)
+			assert.Equal(t, "oncall", requestedBy)
+			if _, err := w.Write([]byte("{\"a\":1}\n")); err != nil {
+				return err
+			}
+			_, err := w.Write([]byte("{\"b\":2}\n"))
+			return err
+		},
+	}
+
+	server, err := NewGRPCServer("8080", ServerConfig{}, &mockDecisionProvider{}, &mockDecisionCreator{}, &mockCandidateProvider{}, mockUserData, &mockAbuseReviewer{}, &mockLogger{})
+	assert.NoError(t, err)
+	stream := &mockExportStream{}
+
+	err = server.ExportUserData(&pb.ExportUserDataRequest{UserId: user1}, stream)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.ExportUserDataResponse{
+		{JsonLines: []byte("{\"a\":1}\n")},
+		{JsonLines: []byte("{\"b\":2}\n")},
+	}, stream.sent)
+}
+
+func TestServer_UserIDValidation(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            *pb.PutDecisionRequest
+		wantMessage    string
+		wantViolations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:        "every invalid field is reported",
+			req:         &pb.PutDecisionRequest{ActorUserId: "not-a-uuid"},
+			wantMessage: "actor user ID must be a UUID; recipient user ID is required",
+			wantViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "actor_user_id", Description: "actor user ID must be a UUID"},
+				{Field: "recipient_user_id", Description: "recipient user ID is required"},
+			},
+		},
+		{
+			name:        "longer than a UUID",
+			req:         &pb.PutDecisionRequest{ActorUserId: user1, RecipientUserId: user2 + "0"},
+			wantMessage: "recipient user ID must be a UUID",
+			wantViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "recipient_user_id", Description: "recipient user ID must be a UUID"},
+			},
+		},
+		{
+			name:        "same user in another case",
+			req:         &pb.PutDecisionRequest{ActorUserId: user1, RecipientUserId: strings.ToUpper(user1)},
+			wantMessage: "both actor and recipient user IDs are the same",
+			wantViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "recipient_user_id", Description: "both actor and recipient user IDs are the same"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewGRPCServer("8080", ServerConfig{}, &mockDecisionProvider{}, &mockDecisionCreator{}, &mockCandidateProvider{}, &mockUserDataManager{}, &mockAbuseReviewer{}, &mockLogger{})
+			require.NoError(t, err)
+
+			_, err = server.PutDecision(context.Background(), tt.req)
+
+			st := status.Convert(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Equal(t, tt.wantMessage, st.Message())
+			require.Len(t, st.Details(), 1)
+			badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+			require.True(t, ok)
+			require.Len(t, badRequest.FieldViolations, len(tt.wantViolations))
+			for i, want := range tt.wantViolations {
+				assert.Equal(t, want.Field, badRequest.FieldViolations[i].Field)
+				assert.Equal(t, want.Description, badRequest.FieldViolations[i].Description)
+			}
+		})
+	}
+}
+
+func TestServer_UserIDsAreCanonical(t *testing.T) {
+	creator := &mockDecisionCreator{
+		saveDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+			assert.Equal(t, domain.UserID(user1), actorID)
+			assert.Equal(t, domain.UserID(user2), recipientID)
+			return false, nil
+		},
+	}
+
+	server, err := NewGRPCServer("8080", ServerConfig{}, &mockDecisionProvider{}, creator, &mockCandidateProvider{}, &mockUserDataManager{}, &mockAbuseReviewer{}, &mockLogger{})
+	require.NoError(t, err)
+
+	_, err = server.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     strings.ToUpper(user1),
+		RecipientUserId: "{" + user2 + "}",
+		LikedRecipient:  true,
+	})
+	assert.NoError(t, err)
+}
+
+func TestServer_IncludeProfile(t *testing.T) {
+	profile := &domain.Profile{
+		UserID:    user2,
+		Name:      "Ann",
+		PhotoURL:  "https://example.com/ann.jpg",
+		BirthDate: time.Now().AddDate(-30, 0, -1),
+	}
+	likers := []domain.LikerInfo{
+		{ActorID: user2, Timestamp: 200, Decision: domain.DecisionLike, Profile: profile},
+		{ActorID: user3, Timestamp: 100, Decision: domain.DecisionLike},
+	}
+
+	tests := []struct {
+		name               string
+		mask               *fieldmaskpb.FieldMask
+		wantIncludeProfile bool
+		wantProfile        *pb.Profile
+		wantErr            error
+	}{
+		{
+			name:               "masked fields only",
+			mask:               &fieldmaskpb.FieldMask{Paths: []string{"name", "age"}},
+			wantIncludeProfile: true,
+			wantProfile:        &pb.Profile{Name: stringPtr("Ann"), Age: uint32Ptr(30)},
+		},
+		{
+			name:               "every field",
+			mask:               &fieldmaskpb.FieldMask{Paths: []string{"name", "photo_url", "age"}},
+			wantIncludeProfile: true,
+			wantProfile:        &pb.Profile{Name: stringPtr("Ann"), PhotoUrl: stringPtr("https://example.com/ann.jpg"), Age: uint32Ptr(30)},
+		},
+		{
+			name: "empty mask",
+			mask: &fieldmaskpb.FieldMask{},
+		},
+		{
+			name:    "unknown path",
+			mask:    &fieldmaskpb.FieldMask{Paths: []string{"name", "email"}},
+			wantErr: status.Error(codes.InvalidArgument, `unknown profile field "email", expected name, photo_url or age`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockDecisionProvider{
+				listLikedYou: func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+					assert.Equal(t, tt.wantIncludeProfile, opts.IncludeProfile)
+					return likers, "", nil
+				},
+			}
+
+			server, err := NewGRPCServer("8080", ServerConfig{}, provider, &mockDecisionCreator{}, &mockCandidateProvider{}, &mockUserDataManager{}, &mockAbuseReviewer{}, &mockLogger{})
+			require.NoError(t, err)
+
+			resp, err := server.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+				RecipientUserId: user1,
+				IncludeProfile:  tt.mask,
+			})
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.Likers, 2)
+			assert.Equal(t, tt.wantProfile, resp.Likers[0].Profile)
+			assert.Nil(t, resp.Likers[1].Profile)
+		})
+	}
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"muzz-homework/internal/explore/domain"
+	"strings"
+)
+
+// fieldViolations collects every invalid field of a request so that clients
+// get them all at once, as BadRequest details of an InvalidArgument status.
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+func (v *fieldViolations) add(field string, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// userID parses a required user ID field. label names the field in the
+// description, e.g. "recipient user ID".
+func (v *fieldViolations) userID(field string, label string, value string) domain.UserID {
+	if value == "" {
+		v.add(field, label+" is required")
+		return ""
+	}
+
+	id, err := domain.ParseUserID(value)
+	if err != nil {
+		v.add(field, label+" must be a UUID")
+		return ""
+	}
+
+	return id
+}
+
+func (v *fieldViolations) required(field string, label string, present bool) {
+	if !present {
+		v.add(field, label+" is required")
+	}
+}
+
+// profileFields are the profile fields requested by a field mask.
+type profileFields struct {
+	name     bool
+	photoURL bool
+	age      bool
+}
+
+func (f profileFields) any() bool {
+	return f.name || f.photoURL || f.age
+}
+
+// profileFields parses an optional profile field mask. Unknown paths are
+// reported instead of ignored, so typos don't silently return less.
+func (v *fieldViolations) profileFields(field string, mask *fieldmaskpb.FieldMask) profileFields {
+	var fields profileFields
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case "name":
+			fields.name = true
+		case "photo_url":
+			fields.photoURL = true
+		case "age":
+			fields.age = true
+		default:
+			v.add(field, fmt.Sprintf("unknown profile field %q, expected name, photo_url or age", path))
+		}
+	}
+
+	return fields
+}
+
+// err returns nil when no field was invalid.
+func (v fieldViolations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(v))
+	for i, violation := range v {
+		descriptions[i] = violation.Description
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(descriptions, "; "))
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	pb "muzz-homework/pkg/proto"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const maxBodyBytes = 1 << 20
+
+// forwardedHeaders are copied into the outgoing gRPC metadata so the gRPC
+// server authenticates gateway calls exactly like native ones.
+var forwardedHeaders = []string{"Authorization"}
+
+type logger interface {
+	Error(format string, args ...any)
+}
+
+// Gateway serves the REST/JSON API by translating each request into a call
+// on the gRPC server, so validation, error mapping and auth live in one place.
+type Gateway struct {
+	server *http.Server
+	client pb.ExploreServiceClient
+	logger logger
+}
+
+func NewGateway(port string, client pb.ExploreServiceClient, logger logger) *Gateway {
+	g := &Gateway{
+		client: client,
+		logger: logger,
+	}
+
+	g.server = &http.Server{
+		Addr:              fmt.Sprintf(":%s", port),
+		Handler:           g.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return g
+}
+
+// NewGRPCClient connects the gateway to the gRPC server it fronts.
+func NewGRPCClient(target string, creds credentials.TransportCredentials) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, r := range routes {
+		mux.HandleFunc(r.method+" "+r.path, g.handle(r))
+	}
+	mux.HandleFunc("GET /openapi.json", g.serveOpenAPI)
+
+	return mux
+}
+
+func (g *Gateway) Run() error {
+	if err := g.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve http: %w", err)
+	}
+
+	return nil
+}
+
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	return g.server.Shutdown(ctx)
+}
+
+func (g *Gateway) handle(r route) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		msg := r.newRequest()
+
+		if r.body {
+			body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodyBytes))
+			if err != nil {
+				g.writeError(w, status.New(codes.InvalidArgument, "failed to read request body"))
+				return
+			}
+			if err := protojson.Unmarshal(body, msg); err != nil {
+				g.writeError(w, status.New(codes.InvalidArgument, fmt.Sprintf("invalid request body: %v", err)))
+				return
+			}
+		} else if err := bindQuery(msg, req.URL.Query(), r.pathParam); err != nil {
+			g.writeError(w, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		if r.pathParam != "" {
+			setField(msg, r.pathParam, req.PathValue("id"))
+		}
+
+		resp, err := r.call(outgoingContext(req), g.client, msg)
+		if err != nil {
+			g.writeError(w, status.Convert(err))
+			return
+		}
+
+		g.writeMessage(w, http.StatusOK, resp)
+	}
+}
+
+func (g *Gateway) serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	spec, err := OpenAPISpec()
+	if err != nil {
+		g.logger.Error("failed to build openapi spec", err)
+		g.writeError(w, status.New(codes.Internal, "internal server error"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+func (g *Gateway) writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		g.logger.Error("failed to marshal response", err)
+		code = http.StatusInternalServerError
+		data = []byte(`{"code":13,"message":"internal server error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func (g *Gateway) writeError(w http.ResponseWriter, st *status.Status) {
+	g.writeMessage(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+func outgoingContext(req *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, header := range forwardedHeaders {
+		if values := req.Header.Values(header); len(values) > 0 {
+			md.Set(strings.ToLower(header), values...)
+		}
+	}
+
+	return metadata.NewOutgoingContext(req.Context(), md)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
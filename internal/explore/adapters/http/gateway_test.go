@@ -0,0 +1,219 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pb "muzz-homework/pkg/proto"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+type mockExploreClient struct {
+	pb.ExploreServiceClient
+	listLikedYou    func(ctx context.Context, in *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error)
+	listNewLikedYou func(ctx context.Context, in *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error)
+	countLikedYou   func(ctx context.Context, in *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error)
+	putDecision     func(ctx context.Context, in *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error)
+}
+
+func (m *mockExploreClient) ListLikedYou(ctx context.Context, in *pb.ListLikedYouRequest, opts ...grpc.CallOption) (*pb.ListLikedYouResponse, error) {
+	return m.listLikedYou(ctx, in)
+}
+
+func (m *mockExploreClient) ListNewLikedYou(ctx context.Context, in *pb.ListLikedYouRequest, opts ...grpc.CallOption) (*pb.ListLikedYouResponse, error) {
+	return m.listNewLikedYou(ctx, in)
+}
+
+func (m *mockExploreClient) CountLikedYou(ctx context.Context, in *pb.CountLikedYouRequest, opts ...grpc.CallOption) (*pb.CountLikedYouResponse, error) {
+	return m.countLikedYou(ctx, in)
+}
+
+func (m *mockExploreClient) PutDecision(ctx context.Context, in *pb.PutDecisionRequest, opts ...grpc.CallOption) (*pb.PutDecisionResponse, error) {
+	return m.putDecision(ctx, in)
+}
+
+type mockLogger struct{}
+
+func (m *mockLogger) Error(format string, args ...any) {}
+
+func TestGateway(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		headers      map[string]string
+		mockBehavior func(*mockExploreClient)
+		wantStatus   int
+		wantBody     string
+	}{
+		{
+			name:   "success - list likers with query params",
+			method: http.MethodGet,
+			target: "/v1/users/user1/likers?pagination_token=abc&filter=LIKER_FILTER_MATCHED&superLikesFirst=true",
+			mockBehavior: func(m *mockExploreClient) {
+				m.listLikedYou = func(ctx context.Context, in *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+					assert.Equal(t, "user1", in.RecipientUserId)
+					assert.Equal(t, "abc", in.GetPaginationToken())
+					assert.Equal(t, pb.LikerFilter_LIKER_FILTER_MATCHED, in.Filter)
+					assert.True(t, in.SuperLikesFirst)
+					next := "next"
+					return &pb.ListLikedYouResponse{
+						Likers: []*pb.ListLikedYouResponse_Liker{
+							{ActorId: "user2", UnixTimestamp: 100, Decision: pb.Decision_DECISION_LIKE},
+						},
+						NextPaginationToken: &next,
+					}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"likers":[{"actorId":"user2","unixTimestamp":"100","decision":"DECISION_LIKE"}],"nextPaginationToken":"next"}`,
+		},
+		{
+			name:   "success - list likers with profiles",
+			method: http.MethodGet,
+			target: "/v1/users/user1/likers?include_profile=name,photoUrl",
+			mockBehavior: func(m *mockExploreClient) {
+				m.listLikedYou = func(ctx context.Context, in *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+					assert.Equal(t, []string{"name", "photo_url"}, in.GetIncludeProfile().GetPaths())
+					name := "Ann"
+					return &pb.ListLikedYouResponse{
+						Likers: []*pb.ListLikedYouResponse_Liker{
+							{ActorId: "user2", UnixTimestamp: 100, Decision: pb.Decision_DECISION_LIKE, Profile: &pb.Profile{Name: &name}},
+						},
+					}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"likers":[{"actorId":"user2","unixTimestamp":"100","decision":"DECISION_LIKE","profile":{"name":"Ann"}}]}`,
+		},
+		{
+			name:   "success - list new likers",
+			method: http.MethodGet,
+			target: "/v1/users/user1/likers/new?unseen_only=true",
+			mockBehavior: func(m *mockExploreClient) {
+				m.listNewLikedYou = func(ctx context.Context, in *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+					assert.Equal(t, "user1", in.RecipientUserId)
+					assert.True(t, in.UnseenOnly)
+					return &pb.ListLikedYouResponse{}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"likers":[]}`,
+		},
+		{
+			name:   "success - count likers",
+			method: http.MethodGet,
+			target: "/v1/users/user1/likers/count",
+			mockBehavior: func(m *mockExploreClient) {
+				m.countLikedYou = func(ctx context.Context, in *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
+					assert.Equal(t, "user1", in.RecipientUserId)
+					return &pb.CountLikedYouResponse{Count: 5, UnseenCount: 2}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"count":"5","unseenCount":"2"}`,
+		},
+		{
+			name:    "success - put decision forwards authorization",
+			method:  http.MethodPut,
+			target:  "/v1/decisions",
+			body:    `{"actorUserId":"user1","recipient_user_id":"user2","decision":"DECISION_SUPER_LIKE"}`,
+			headers: map[string]string{"Authorization": "Bearer token"},
+			mockBehavior: func(m *mockExploreClient) {
+				m.putDecision = func(ctx context.Context, in *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+					assert.Equal(t, "user1", in.ActorUserId)
+					assert.Equal(t, "user2", in.RecipientUserId)
+					assert.Equal(t, pb.Decision_DECISION_SUPER_LIKE, in.Decision)
+					return &pb.PutDecisionResponse{MutualLikes: true}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"mutualLikes":true}`,
+		},
+		{
+			name:         "error - unknown query parameter",
+			method:       http.MethodGet,
+			target:       "/v1/users/user1/likers?limit=10",
+			mockBehavior: func(m *mockExploreClient) {},
+			wantStatus:   http.StatusBadRequest,
+			wantBody:     `{"code":3,"message":"unknown query parameter \"limit\"","details":[]}`,
+		},
+		{
+			name:         "error - invalid enum value",
+			method:       http.MethodGet,
+			target:       "/v1/users/user1/likers?filter=NOPE",
+			mockBehavior: func(m *mockExploreClient) {},
+			wantStatus:   http.StatusBadRequest,
+			wantBody:     `{"code":3,"message":"invalid query parameter \"filter\": unknown LikerFilter value \"NOPE\"","details":[]}`,
+		},
+		{
+			name:         "error - malformed body",
+			method:       http.MethodPut,
+			target:       "/v1/decisions",
+			body:         `{"actorUserId":`,
+			mockBehavior: func(m *mockExploreClient) {},
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:   "error - grpc status is mapped",
+			method: http.MethodPut,
+			target: "/v1/decisions",
+			body:   `{"actorUserId":"user1","recipientUserId":"user2","decision":"DECISION_SUPER_LIKE"}`,
+			mockBehavior: func(m *mockExploreClient) {
+				m.putDecision = func(ctx context.Context, in *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
+					return nil, status.Error(codes.ResourceExhausted, "super like quota exceeded")
+				}
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"code":8,"message":"super like quota exceeded","details":[]}`,
+		},
+		{
+			name:         "error - method not allowed",
+			method:       http.MethodPost,
+			target:       "/v1/decisions",
+			mockBehavior: func(m *mockExploreClient) {},
+			wantStatus:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockExploreClient{}
+			tt.mockBehavior(client)
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			NewGateway("0", client, &mockLogger{}).Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	spec, err := OpenAPISpec()
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(spec))
+
+	committed, err := os.ReadFile("../../../../api/openapi.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(committed), string(spec)+"\n", "api/openapi.json is stale, run make generate-openapi")
+}
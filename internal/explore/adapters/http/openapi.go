@@ -0,0 +1,190 @@
+package http
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// OpenAPISpec describes the gateway routes. It is built from the route table
+// and the proto descriptors so it can't drift from what the gateway serves;
+// `make generate-openapi` writes it to api/openapi.json.
+func OpenAPISpec() ([]byte, error) {
+	schemas := map[string]any{
+		"Status": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "format": "int32"},
+				"message": map[string]any{"type": "string"},
+				"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+			},
+		},
+	}
+
+	paths := map[string]any{}
+	for _, r := range routes {
+		request := r.newRequest().ProtoReflect().Descriptor()
+		response := r.newResponse().ProtoReflect().Descriptor()
+		addMessageSchema(schemas, response)
+
+		operation := map[string]any{
+			"operationId": r.rpc,
+			"summary":     r.summary,
+			"responses": map[string]any{
+				"200": jsonContent("OK", schemaRef(response.FullName())),
+				"default": jsonContent("Error, mapped from the gRPC status code",
+					map[string]any{"$ref": "#/components/schemas/Status"}),
+			},
+		}
+
+		parameters := []any{}
+		if r.pathParam != "" {
+			parameters = append(parameters, map[string]any{
+				"name":     "id",
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+
+		if r.body {
+			addMessageSchema(schemas, request)
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaRef(request.FullName())},
+				},
+			}
+		} else {
+			fields := request.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				field := fields.Get(i)
+				if string(field.Name()) == r.pathParam || !isQueryField(field) {
+					continue
+				}
+				if field.Enum() != nil {
+					addEnumSchema(schemas, field.Enum())
+				}
+				parameters = append(parameters, map[string]any{
+					"name":   string(field.Name()),
+					"in":     "query",
+					"schema": fieldSchema(field),
+				})
+			}
+		}
+		operation["parameters"] = parameters
+
+		path, ok := paths[r.path].(map[string]any)
+		if !ok {
+			path = map[string]any{}
+			paths[r.path] = path
+		}
+		path[strings.ToLower(r.method)] = operation
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Explore Service",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}, "", "  ")
+}
+
+func jsonContent(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": schema},
+		},
+	}
+}
+
+func addMessageSchema(schemas map[string]any, desc protoreflect.MessageDescriptor) {
+	name := schemaName(desc.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := map[string]any{}
+	schemas[name] = map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() != nil {
+			addMessageSchema(schemas, field.Message())
+		}
+		if field.Enum() != nil {
+			addEnumSchema(schemas, field.Enum())
+		}
+
+		schema := fieldSchema(field)
+		if field.IsList() {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+		properties[field.JSONName()] = schema
+	}
+}
+
+func addEnumSchema(schemas map[string]any, desc protoreflect.EnumDescriptor) {
+	values := make([]string, desc.Values().Len())
+	for i := range values {
+		values[i] = string(desc.Values().Get(i).Name())
+	}
+
+	schemas[schemaName(desc.FullName())] = map[string]any{
+		"type": "string",
+		"enum": values,
+	}
+}
+
+// fieldSchema follows the protojson mapping, which renders 64-bit integers as
+// strings.
+func fieldSchema(field protoreflect.FieldDescriptor) map[string]any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		return schemaRef(field.Enum().FullName())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.Message().FullName() == fieldMaskName {
+			return map[string]any{"type": "string", "description": "Comma-separated field paths"}
+		}
+		return schemaRef(field.Message().FullName())
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func schemaRef(name protoreflect.FullName) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + schemaName(name)}
+}
+
+// schemaName drops the proto package and flattens nested names, so
+// explore.ListLikedYouResponse.Liker becomes ListLikedYouResponseLiker.
+func schemaName(name protoreflect.FullName) string {
+	_, local, _ := strings.Cut(string(name), ".")
+	return strings.ReplaceAll(local, ".", "")
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	pb "muzz-homework/pkg/proto"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type route struct {
+	method  string
+	path    string
+	rpc     string
+	summary string
+	// pathParam is the request field populated from the {id} path segment.
+	pathParam   string
+	body        bool
+	newRequest  func() proto.Message
+	newResponse func() proto.Message
+	call        func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error)
+}
+
+var routes = []route{
+	{
+		method:      http.MethodGet,
+		path:        "/v1/users/{id}/likers",
+		rpc:         "ListLikedYou",
+		summary:     "List the users who liked the recipient",
+		pathParam:   "recipient_user_id",
+		newRequest:  func() proto.Message { return &pb.ListLikedYouRequest{} },
+		newResponse: func() proto.Message { return &pb.ListLikedYouResponse{} },
+		call: func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error) {
+			return client.ListLikedYou(ctx, req.(*pb.ListLikedYouRequest))
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/v1/users/{id}/likers/new",
+		rpc:         "ListNewLikedYou",
+		summary:     "List the users who liked the recipient and haven't been decided on yet",
+		pathParam:   "recipient_user_id",
+		newRequest:  func() proto.Message { return &pb.ListLikedYouRequest{} },
+		newResponse: func() proto.Message { return &pb.ListLikedYouResponse{} },
+		call: func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error) {
+			return client.ListNewLikedYou(ctx, req.(*pb.ListLikedYouRequest))
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/v1/users/{id}/likers/count",
+		rpc:         "CountLikedYou",
+		summary:     "Count the users who liked the recipient",
+		pathParam:   "recipient_user_id",
+		newRequest:  func() proto.Message { return &pb.CountLikedYouRequest{} },
+		newResponse: func() proto.Message { return &pb.CountLikedYouResponse{} },
+		call: func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error) {
+			return client.CountLikedYou(ctx, req.(*pb.CountLikedYouRequest))
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/v1/users/{id}/candidates",
+		rpc:         "GetCandidates",
+		summary:     "List users the actor hasn't decided on yet, likers of the actor first",
+		pathParam:   "actor_user_id",
+		newRequest:  func() proto.Message { return &pb.GetCandidatesRequest{} },
+		newResponse: func() proto.Message { return &pb.GetCandidatesResponse{} },
+		call: func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error) {
+			return client.GetCandidates(ctx, req.(*pb.GetCandidatesRequest))
+		},
+	},
+	{
+		method:      http.MethodPut,
+		path:        "/v1/decisions",
+		rpc:         "PutDecision",
+		summary:     "Record the actor's decision on the recipient",
+		body:        true,
+		newRequest:  func() proto.Message { return &pb.PutDecisionRequest{} },
+		newResponse: func() proto.Message { return &pb.PutDecisionResponse{} },
+		call: func(ctx context.Context, client pb.ExploreServiceClient, req proto.Message) (proto.Message, error) {
+			return client.PutDecision(ctx, req.(*pb.PutDecisionRequest))
+		},
+	},
+}
+
+// fieldMaskName is the only message type bound from a query parameter. As in
+// protojson, its paths are comma-separated.
+const fieldMaskName protoreflect.FullName = "google.protobuf.FieldMask"
+
+// bindQuery sets scalar and field mask request fields from query parameters,
+// accepting both the proto field name and its JSON name.
+func bindQuery(msg proto.Message, values url.Values, pathParam string) error {
+	fields := msg.ProtoReflect().Descriptor().Fields()
+
+	for key, vals := range values {
+		field := fields.ByName(protoreflect.Name(key))
+		if field == nil {
+			field = fields.ByJSONName(key)
+		}
+		if field == nil || string(field.Name()) == pathParam || !isQueryField(field) {
+			return fmt.Errorf("unknown query parameter %q", key)
+		}
+
+		value, err := parseQueryValue(field, vals[len(vals)-1])
+		if err != nil {
+			return fmt.Errorf("invalid query parameter %q: %v", key, err)
+		}
+		msg.ProtoReflect().Set(field, value)
+	}
+
+	return nil
+}
+
+func isQueryField(field protoreflect.FieldDescriptor) bool {
+	if field.IsList() || field.IsMap() {
+		return false
+	}
+
+	switch field.Kind() {
+	case protoreflect.StringKind, protoreflect.BoolKind, protoreflect.Uint64Kind, protoreflect.EnumKind:
+		return true
+	case protoreflect.MessageKind:
+		return field.Message().FullName() == fieldMaskName
+	default:
+		return false
+	}
+}
+
+func parseQueryValue(field protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Uint64Kind:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.EnumKind:
+		if v := field.Enum().Values().ByName(protoreflect.Name(raw)); v != nil {
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil || field.Enum().Values().ByNumber(protoreflect.EnumNumber(n)) == nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", field.Enum().Name(), raw)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind:
+		var paths []string
+		for _, path := range strings.Split(raw, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, camelToSnake(path))
+			}
+		}
+		return protoreflect.ValueOfMessage((&fieldmaskpb.FieldMask{Paths: paths}).ProtoReflect()), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+}
+
+// camelToSnake turns the JSON form of a field mask path, photoUrl, into the
+// proto form, photo_url.
+func camelToSnake(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func setField(msg proto.Message, name string, value string) {
+	field := msg.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name))
+	msg.ProtoReflect().Set(field, protoreflect.ValueOfString(value))
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+const (
+	defaultAbuseFlagsPageSize = 100
+	maxAbuseFlagsPageSize     = 1000
+)
+
+// abuseCounters track each actor's recent decisions, in Redis in production.
+type abuseCounters interface {
+	RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error)
+	MarkFlagged(ctx context.Context, userID domain.UserID) error
+	ResetUser(ctx context.Context, userID domain.UserID) error
+}
+
+type abuseFlagRepository interface {
+	FlagUser(ctx context.Context, flag domain.AbuseFlag) error
+	IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error)
+	ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error)
+	ClearAbuseFlag(ctx context.Context, userID domain.UserID) error
+}
+
+// AbuseDetectorConfig holds the thresholds an actor's activity in one counter
+// window is held against. A zero threshold disables its check.
+type AbuseDetectorConfig struct {
+	// MaxLikes is how many users an actor may like within a window.
+	MaxLikes uint64
+	// MaxLikeRatio is the highest share of likes among an actor's decisions,
+	// checked once they made MinDecisionsForRatio decisions in the window.
+	MaxLikeRatio         float64
+	MinDecisionsForRatio uint64
+	// MaxSequentialRun is how many decisions in a row may go to users in
+	// ascending ID order. The candidates feed is ordered by ID, so people
+	// browsing it climb too; the counters only extend a run while decisions
+	// come faster than people make them.
+	MaxSequentialRun uint64
+}
+
+func (c AbuseDetectorConfig) enabled() bool {
+	return c.MaxLikes > 0 || c.MaxLikeRatio > 0 || c.MaxSequentialRun > 0
+}
+
+// AbuseDetector flags actors whose decisions look automated. Flagged actors
+// are shadow-banned: their decisions are saved as usual, but the repositories
+// hide them from everyone's likers until the flag is cleared.
+type AbuseDetector struct {
+	counters abuseCounters
+	flags    abuseFlagRepository
+	listings likerListings
+	config   AbuseDetectorConfig
+	audit    auditLogger
+}
+
+func NewAbuseDetector(counters abuseCounters, flags abuseFlagRepository, decisions likerListingsRepository, cache likerListingsCache, config AbuseDetectorConfig, audit auditLogger) *AbuseDetector {
+	return &AbuseDetector{
+		counters: counters,
+		flags:    flags,
+		listings: likerListings{repo: decisions, cache: cache},
+		config:   config,
+		audit:    audit,
+	}
+}
+
+// CheckDecision counts a saved decision and flags the actor once their
+// activity crosses a threshold. It reports whether the actor is flagged, so
+// their decisions can be kept out of caches updated in place. Flagging purges
+// the cached likers of everyone the actor liked, which would show them until
+// they expire.
+func (d *AbuseDetector) CheckDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+	if !d.config.enabled() {
+		return false, nil
+	}
+
+	activity, err := d.counters.RecordDecision(ctx, actorID, recipientID, decision)
+	if err != nil {
+		return false, fmt.Errorf("failed to count decision: %w", err)
+	}
+
+	if activity.Flagged {
+		return true, nil
+	}
+
+	// The counters forget the flag once the actor made no decision for a
+	// window, so the first decision of each window reads it back.
+	if activity.Decisions == 1 {
+		flagged, err := d.flags.IsUserFlagged(ctx, actorID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check abuse flag: %w", err)
+		}
+		if flagged {
+			if err := d.counters.MarkFlagged(ctx, actorID); err != nil {
+				return true, fmt.Errorf("failed to mark user as flagged: %w", err)
+			}
+			return true, nil
+		}
+	}
+
+	reason, details, ok := d.evaluate(activity)
+	if !ok {
+		return false, nil
+	}
+
+	err = d.flags.FlagUser(ctx, domain.AbuseFlag{
+		UserID:    actorID,
+		Reason:    reason,
+		Details:   details,
+		FlaggedAt: uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to flag user: %w", err)
+	}
+
+	d.audit.Info("user flagged for abuse", "user_id", actorID, "reason", reason, "details", details)
+
+	// The counters only remember the flag to skip evaluating the actor again,
+	// the repository stays authoritative for hiding them.
+	if err := d.counters.MarkFlagged(ctx, actorID); err != nil {
+		return true, fmt.Errorf("failed to mark user as flagged: %w", err)
+	}
+
+	if _, err := d.listings.purgeLikedBy(ctx, actorID); err != nil {
+		return true, fmt.Errorf("failed to refresh liker listings: %w", err)
+	}
+
+	return true, nil
+}
+
+// evaluate returns the first threshold the activity crosses, if any.
+func (d *AbuseDetector) evaluate(activity domain.DecisionActivity) (domain.AbuseReason, string, bool) {
+	if d.config.MaxLikes > 0 && activity.Likes > d.config.MaxLikes {
+		return domain.AbuseReasonVelocity,
+			fmt.Sprintf("%d likes in one window, limit %d", activity.Likes, d.config.MaxLikes), true
+	}
+
+	if d.config.MaxLikeRatio > 0 && activity.Decisions > 0 && activity.Decisions >= d.config.MinDecisionsForRatio {
+		ratio := float64(activity.Likes) / float64(activity.Decisions)
+		if ratio > d.config.MaxLikeRatio {
+			return domain.AbuseReasonLikeRatio,
+				fmt.Sprintf("liked %d of %d users in one window, limit %.2f", activity.Likes, activity.Decisions, d.config.MaxLikeRatio), true
+		}
+	}
+
+	if d.config.MaxSequentialRun > 0 && activity.SequentialRun > d.config.MaxSequentialRun {
+		return domain.AbuseReasonSequentialIDs,
+			fmt.Sprintf("%d decisions in a row on ascending user IDs, limit %d", activity.SequentialRun, d.config.MaxSequentialRun), true
+	}
+
+	return "", "", false
+}
+
+// ListFlags returns up to pageSize flags, most recent first.
+func (d *AbuseDetector) ListFlags(ctx context.Context, pageSize uint64) ([]domain.AbuseFlag, error) {
+	if pageSize == 0 {
+		pageSize = defaultAbuseFlagsPageSize
+	}
+	pageSize = min(pageSize, maxAbuseFlagsPageSize)
+
+	flags, err := d.flags.ListAbuseFlags(ctx, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list abuse flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// ClearFlag lifts the user's shadow-ban and resets their counters, so the
+// activity that got them flagged doesn't flag them again straight away.
+// The cached likers of everyone the user liked are purged so their likes
+// show again straight away. The counters are reset even when the user isn't flagged, so a
+// marker left behind by a flag cleared elsewhere is dropped too.
+func (d *AbuseDetector) ClearFlag(ctx context.Context, userID domain.UserID, requestedBy string, reason string) error {
+	if userID == "" || requestedBy == "" {
+		return domain.ErrInvalidInput
+	}
+
+	clearErr := d.flags.ClearAbuseFlag(ctx, userID)
+	switch {
+	case clearErr == nil:
+		d.audit.Info("abuse flag cleared", "user_id", userID, "requested_by", requestedBy, "reason", reason)
+	case !errors.Is(clearErr, domain.ErrAbuseFlagNotFound):
+		return fmt.Errorf("failed to clear abuse flag: %w", clearErr)
+	}
+
+	if err := d.counters.ResetUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to reset abuse counters: %w", err)
+	}
+
+	if clearErr != nil {
+		return fmt.Errorf("failed to clear abuse flag: %w", clearErr)
+	}
+
+	if _, err := d.listings.purgeLikedBy(ctx, userID); err != nil {
+		return fmt.Errorf("failed to refresh liker listings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+type mockAbuseCounters struct {
+	activity domain.DecisionActivity
+	err      error
+	recorded int
+	marked   []domain.UserID
+	reset    []domain.UserID
+}
+
+func (m *mockAbuseCounters) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error) {
+	m.recorded++
+	return m.activity, m.err
+}
+
+func (m *mockAbuseCounters) MarkFlagged(ctx context.Context, userID domain.UserID) error {
+	m.marked = append(m.marked, userID)
+	return nil
+}
+
+func (m *mockAbuseCounters) ResetUser(ctx context.Context, userID domain.UserID) error {
+	m.reset = append(m.reset, userID)
+	return nil
+}
+
+type mockAbuseFlagRepo struct {
+	flagged    []domain.AbuseFlag
+	flagErr    error
+	isFlagged  bool
+	checked    int
+	listLimit  uint64
+	clearErr   error
+	clearedIDs []domain.UserID
+}
+
+func (m *mockAbuseFlagRepo) FlagUser(ctx context.Context, flag domain.AbuseFlag) error {
+	if m.flagErr != nil {
+		return m.flagErr
+	}
+	m.flagged = append(m.flagged, flag)
+	return nil
+}
+
+func (m *mockAbuseFlagRepo) IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error) {
+	m.checked++
+	return m.isFlagged, nil
+}
+
+func (m *mockAbuseFlagRepo) ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error) {
+	m.listLimit = limit
+	return m.flagged, nil
+}
+
+func (m *mockAbuseFlagRepo) ClearAbuseFlag(ctx context.Context, userID domain.UserID) error {
+	if m.clearErr != nil {
+		return m.clearErr
+	}
+	m.clearedIDs = append(m.clearedIDs, userID)
+	return nil
+}
+
+var testAbuseConfig = AbuseDetectorConfig{
+	MaxLikes:             100,
+	MaxLikeRatio:         0.9,
+	MinDecisionsForRatio: 50,
+	MaxSequentialRun:     10,
+}
+
+func TestAbuseDetector_CheckDecision(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      AbuseDetectorConfig
+		activity    domain.DecisionActivity
+		countErr    error
+		flagErr     error
+		isFlagged   bool
+		want        bool
+		wantReason  domain.AbuseReason
+		wantErr     bool
+		wantCounted bool
+		wantChecked bool
+		wantMarked  bool
+	}{
+		{
+			name:        "normal activity",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 80, Likes: 40, SequentialRun: 3},
+			wantCounted: true,
+		},
+		{
+			name:        "too many likes",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 500, Likes: 101},
+			want:        true,
+			wantReason:  domain.AbuseReasonVelocity,
+			wantCounted: true,
+		},
+		{
+			name:        "likes at the limit",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 500, Likes: 100},
+			wantCounted: true,
+		},
+		{
+			name:        "liking nearly everyone",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 60, Likes: 58},
+			want:        true,
+			wantReason:  domain.AbuseReasonLikeRatio,
+			wantCounted: true,
+		},
+		{
+			name:        "ratio ignored below the minimum sample",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 49, Likes: 49},
+			wantCounted: true,
+		},
+		{
+			name:        "ascending IDs",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 11, Likes: 2, SequentialRun: 11},
+			want:        true,
+			wantReason:  domain.AbuseReasonSequentialIDs,
+			wantCounted: true,
+		},
+		{
+			name:        "ascending IDs at the limit",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 10, Likes: 2, SequentialRun: 10},
+			wantCounted: true,
+		},
+		{
+			name:        "already flagged",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 500, Likes: 500, Flagged: true},
+			want:        true,
+			wantCounted: true,
+		},
+		{
+			name:        "first decision of a window reads the flag back",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 1, Likes: 1},
+			isFlagged:   true,
+			want:        true,
+			wantCounted: true,
+			wantChecked: true,
+			wantMarked:  true,
+		},
+		{
+			name:        "first decision of a window of an actor who isn't flagged",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 1, Likes: 1},
+			wantCounted: true,
+			wantChecked: true,
+		},
+		{
+			name:        "disabled check",
+			config:      AbuseDetectorConfig{MaxLikes: 100},
+			activity:    domain.DecisionActivity{Decisions: 60, Likes: 60, SequentialRun: 60},
+			wantCounted: true,
+		},
+		{
+			name:     "all checks disabled",
+			config:   AbuseDetectorConfig{},
+			activity: domain.DecisionActivity{Decisions: 500, Likes: 500},
+		},
+		{
+			name:        "counter error",
+			config:      testAbuseConfig,
+			countErr:    errors.New("redis down"),
+			wantErr:     true,
+			wantCounted: true,
+		},
+		{
+			name:        "flag error",
+			config:      testAbuseConfig,
+			activity:    domain.DecisionActivity{Decisions: 500, Likes: 500},
+			flagErr:     errors.New("db error"),
+			wantErr:     true,
+			wantCounted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counters := &mockAbuseCounters{activity: tt.activity, err: tt.countErr}
+			flags := &mockAbuseFlagRepo{flagErr: tt.flagErr, isFlagged: tt.isFlagged}
+			listings := &mockLikerListings{records: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+			}}
+			audit := &mockAuditLogger{}
+
+			detector := NewAbuseDetector(counters, flags, listings, listings, tt.config, audit)
+			got, err := detector.CheckDecision(context.Background(), "user1", "user2", domain.DecisionLike)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCounted, counters.recorded == 1)
+			assert.Equal(t, tt.wantChecked, flags.checked == 1)
+
+			if tt.wantReason == "" {
+				assert.Empty(t, flags.flagged)
+				assert.Equal(t, tt.wantMarked, len(counters.marked) == 1)
+				assert.Empty(t, audit.messages)
+				assert.Empty(t, listings.purged)
+				return
+			}
+
+			if assert.Len(t, flags.flagged, 1) {
+				assert.Equal(t, domain.UserID("user1"), flags.flagged[0].UserID)
+				assert.Equal(t, tt.wantReason, flags.flagged[0].Reason)
+				assert.NotEmpty(t, flags.flagged[0].Details)
+				assert.NotZero(t, flags.flagged[0].FlaggedAt)
+			}
+			assert.Equal(t, []domain.UserID{"user1"}, counters.marked)
+			assert.Equal(t, []string{"user flagged for abuse"}, audit.messages)
+			assert.Equal(t, []domain.UserID{"user2"}, listings.purged)
+		})
+	}
+}
+
+func TestAbuseDetector_ListFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageSize  uint64
+		wantLimit uint64
+	}{
+		{name: "default page size", pageSize: 0, wantLimit: defaultAbuseFlagsPageSize},
+		{name: "requested page size", pageSize: 10, wantLimit: 10},
+		{name: "capped page size", pageSize: 5000, wantLimit: maxAbuseFlagsPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &mockAbuseFlagRepo{flagged: []domain.AbuseFlag{{UserID: "user1", Reason: domain.AbuseReasonVelocity}}}
+
+			detector := NewAbuseDetector(&mockAbuseCounters{}, flags, &mockLikerListings{}, &mockLikerListings{}, testAbuseConfig, &mockAuditLogger{})
+			got, err := detector.ListFlags(context.Background(), tt.pageSize)
+
+			assert.NoError(t, err)
+			assert.Equal(t, flags.flagged, got)
+			assert.Equal(t, tt.wantLimit, flags.listLimit)
+		})
+	}
+}
+
+func TestAbuseDetector_ClearFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      domain.UserID
+		requestedBy string
+		clearErr    error
+		wantErr     error
+		wantReset   bool
+		wantPurged  []domain.UserID
+		wantAudit   []string
+	}{
+		{
+			name:        "success",
+			userID:      "user1",
+			requestedBy: "oncall",
+			wantReset:   true,
+			wantPurged:  []domain.UserID{"user2"},
+			wantAudit:   []string{"abuse flag cleared"},
+		},
+		{
+			name:    "missing requester",
+			userID:  "user1",
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:        "not flagged",
+			userID:      "user1",
+			requestedBy: "oncall",
+			clearErr:    domain.ErrAbuseFlagNotFound,
+			wantErr:     domain.ErrAbuseFlagNotFound,
+			wantReset:   true,
+		},
+		{
+			name:        "repository error",
+			userID:      "user1",
+			requestedBy: "oncall",
+			clearErr:    errors.New("db error"),
+			wantErr:     errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counters := &mockAbuseCounters{}
+			flags := &mockAbuseFlagRepo{clearErr: tt.clearErr}
+			listings := &mockLikerListings{records: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+			}}
+			audit := &mockAuditLogger{}
+
+			detector := NewAbuseDetector(counters, flags, listings, listings, testAbuseConfig, audit)
+			err := detector.ClearFlag(context.Background(), tt.userID, tt.requestedBy, "false positive")
+
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAudit, audit.messages)
+			assert.Equal(t, tt.wantPurged, listings.purged)
+			if !tt.wantReset {
+				assert.Empty(t, counters.reset)
+				return
+			}
+
+			assert.Equal(t, []domain.UserID{"user1"}, counters.reset)
+		})
+	}
+}
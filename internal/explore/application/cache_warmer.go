@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/time/rate"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+type hotRecipientsRepository interface {
+	GetTopRecipients(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error)
+}
+
+// likersReader fills the cache on a miss as a side effect of reading, so
+// warming goes through the same path, and cache strategy, as live traffic.
+type likersReader interface {
+	ListLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	ListNewLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	CountLikedYou(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error)
+}
+
+// warmupLock lets a single replica warm per interval. TryAcquire holds the
+// lock for ttl and reports false while another replica holds it.
+type warmupLock interface {
+	TryAcquire(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+type CacheWarmerConfig struct {
+	// TopN is how many recipients with the most recent likes are warmed.
+	TopN uint64
+	// Window is how far back likes are counted to rank recipients.
+	Window   time.Duration
+	Interval time.Duration
+	// RecipientsPerSecond caps how fast recipients are warmed so a warm-up
+	// after a flush doesn't compete with live traffic for Postgres. 0 means
+	// no limit.
+	RecipientsPerSecond float64
+}
+
+type CacheWarmer struct {
+	repo    hotRecipientsRepository
+	reader  likersReader
+	lock    warmupLock
+	config  CacheWarmerConfig
+	logger  sweeperLogger
+	limiter *rate.Limiter
+}
+
+// NewCacheWarmer takes an optional lock shared by every replica, so the fleet
+// warms once per interval rather than once per replica.
+func NewCacheWarmer(repo hotRecipientsRepository, reader likersReader, lock warmupLock, config CacheWarmerConfig, logger sweeperLogger) (*CacheWarmer, error) {
+	if config.Interval <= 0 {
+		return nil, errors.New("warm-up interval must be positive")
+	}
+
+	limit := rate.Inf
+	if config.RecipientsPerSecond > 0 {
+		limit = rate.Limit(config.RecipientsPerSecond)
+	}
+
+	return &CacheWarmer{
+		repo:    repo,
+		reader:  reader,
+		lock:    lock,
+		config:  config,
+		logger:  logger,
+		limiter: rate.NewLimiter(limit, 1),
+	}, nil
+}
+
+// Run warms the cache right away and then every Interval until ctx is done.
+func (w *CacheWarmer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.warmOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// warmOnce warms the cache unless another replica took the lock for this
+// interval. The lock is never released early, so it also spaces out warm-ups
+// across replicas.
+func (w *CacheWarmer) warmOnce(ctx context.Context) bool {
+	if w.lock != nil {
+		acquired, err := w.lock.TryAcquire(ctx, w.config.Interval)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("cache warm-up lock failed", "error", err)
+			}
+			return false
+		}
+		if !acquired {
+			w.logger.Info("cache warm-up skipped, another replica is warming")
+			return false
+		}
+	}
+
+	warmed, err := w.Warm(ctx)
+	if err != nil && ctx.Err() == nil {
+		w.logger.Error("cache warm-up failed", "error", err, "warmed", warmed)
+	} else if err == nil {
+		w.logger.Info("cache warm-up finished", "warmed", warmed)
+	}
+
+	return true
+}
+
+// Warm reads the first page of ListLikedYou and ListNewLikedYou and the counts
+// of the busiest recipients, which caches whatever is missing. A recipient
+// that fails is logged and skipped.
+func (w *CacheWarmer) Warm(ctx context.Context) (int, error) {
+	since := uint64(time.Now().Add(-w.config.Window).Unix())
+
+	recipients, err := w.repo.GetTopRecipients(ctx, since, w.config.TopN)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get top recipients: %w", err)
+	}
+
+	var warmed int
+	for _, recipientID := range recipients {
+		if err := w.limiter.Wait(ctx); err != nil {
+			return warmed, err
+		}
+
+		if err := w.warmRecipient(ctx, recipientID); err != nil {
+			if ctx.Err() != nil {
+				return warmed, ctx.Err()
+			}
+			w.logger.Error("cache warm-up of recipient failed", "recipient_id", recipientID, "error", err)
+			continue
+		}
+		warmed++
+	}
+
+	return warmed, nil
+}
+
+func (w *CacheWarmer) warmRecipient(ctx context.Context, recipientID domain.UserID) error {
+	if _, _, err := w.reader.ListLikedYou(ctx, recipientID, "", domain.ListLikersOptions{}); err != nil {
+		return err
+	}
+
+	if _, _, err := w.reader.ListNewLikedYou(ctx, recipientID, "", domain.ListLikersOptions{}); err != nil {
+		return err
+	}
+
+	_, err := w.reader.CountLikedYou(ctx, recipientID, domain.CountLikersOptions{})
+	return err
+}
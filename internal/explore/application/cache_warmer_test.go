@@ -0,0 +1,225 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+type mockHotRecipientsRepo struct {
+	getTopRecipients func(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error)
+}
+
+func (m *mockHotRecipientsRepo) GetTopRecipients(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+	return m.getTopRecipients(ctx, since, limit)
+}
+
+type mockLikersReader struct {
+	listLikedYou    func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	listNewLikedYou func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error)
+	countLikedYou   func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error)
+}
+
+func (m *mockLikersReader) ListLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	return m.listLikedYou(ctx, recipientID, encodedToken, opts)
+}
+
+func (m *mockLikersReader) ListNewLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	return m.listNewLikedYou(ctx, recipientID, encodedToken, opts)
+}
+
+func (m *mockLikersReader) CountLikedYou(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+	return m.countLikedYou(ctx, recipientID, opts)
+}
+
+type mockWarmupLock struct {
+	tryAcquire func(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+func (m *mockWarmupLock) TryAcquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	return m.tryAcquire(ctx, ttl)
+}
+
+// newRecordingReader records which recipients were read, failing for those
+// listed in failing.
+func newRecordingReader(warmed *[]domain.UserID, failing ...domain.UserID) *mockLikersReader {
+	fail := func(recipientID domain.UserID) error {
+		for _, id := range failing {
+			if id == recipientID {
+				return errors.New("db error")
+			}
+		}
+		return nil
+	}
+
+	return &mockLikersReader{
+		listLikedYou: func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+			return nil, "", fail(recipientID)
+		},
+		listNewLikedYou: func(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+			return nil, "", nil
+		},
+		countLikedYou: func(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
+			*warmed = append(*warmed, recipientID)
+			return domain.LikersCount{}, nil
+		},
+	}
+}
+
+func TestCacheWarmer_Warm(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipients []domain.UserID
+		repoErr    error
+		failing    []domain.UserID
+		wantWarmed []domain.UserID
+		wantErr    error
+	}{
+		{
+			name:       "success - warms every top recipient",
+			recipients: []domain.UserID{"user1", "user2", "user3"},
+			wantWarmed: []domain.UserID{"user1", "user2", "user3"},
+		},
+		{
+			name:       "success - failing recipient is skipped",
+			recipients: []domain.UserID{"user1", "user2", "user3"},
+			failing:    []domain.UserID{"user2"},
+			wantWarmed: []domain.UserID{"user1", "user3"},
+		},
+		{
+			name:    "error - repository error",
+			repoErr: errors.New("db error"),
+			wantErr: errors.New("failed to get top recipients: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := 24 * time.Hour
+			repo := &mockHotRecipientsRepo{
+				getTopRecipients: func(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+					assert.InDelta(t, time.Now().Add(-window).Unix(), since, 2)
+					assert.Equal(t, uint64(10), limit)
+					return tt.recipients, tt.repoErr
+				},
+			}
+
+			var warmed []domain.UserID
+			warmer, err := NewCacheWarmer(repo, newRecordingReader(&warmed, tt.failing...), nil, CacheWarmerConfig{
+				TopN:     10,
+				Window:   window,
+				Interval: time.Hour,
+			}, &mockSweeperLogger{})
+			require.NoError(t, err)
+
+			gotWarmed, err := warmer.Warm(context.Background())
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, len(tt.wantWarmed), gotWarmed)
+			assert.Equal(t, tt.wantWarmed, warmed)
+		})
+	}
+}
+
+func TestCacheWarmer_RateLimitAndCancel(t *testing.T) {
+	repo := &mockHotRecipientsRepo{
+		getTopRecipients: func(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+			return []domain.UserID{"user1", "user2", "user3", "user4", "user5"}, nil
+		},
+	}
+
+	var warmed []domain.UserID
+	warmer, err := NewCacheWarmer(repo, newRecordingReader(&warmed), nil, CacheWarmerConfig{
+		TopN:                5,
+		Window:              time.Hour,
+		Interval:            time.Hour,
+		RecipientsPerSecond: 20,
+	}, &mockSweeperLogger{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+
+	// At 20 per second, only the first few recipients fit before the deadline.
+	gotWarmed, err := warmer.Warm(ctx)
+
+	assert.Error(t, err)
+	assert.Less(t, gotWarmed, 5)
+	assert.GreaterOrEqual(t, gotWarmed, 1)
+	assert.Equal(t, gotWarmed, len(warmed))
+}
+
+func TestCacheWarmer_Lock(t *testing.T) {
+	tests := []struct {
+		name       string
+		noLock     bool
+		acquired   bool
+		lockErr    error
+		wantWarmed bool
+	}{
+		{
+			name:       "no lock - always warms",
+			noLock:     true,
+			wantWarmed: true,
+		},
+		{
+			name:       "lock acquired - warms",
+			acquired:   true,
+			wantWarmed: true,
+		},
+		{
+			name: "lock held by another replica - skips",
+		},
+		{
+			name:    "lock error - skips",
+			lockErr: errors.New("redis down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockHotRecipientsRepo{
+				getTopRecipients: func(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+					return []domain.UserID{"user1"}, nil
+				},
+			}
+
+			var lock warmupLock
+			if !tt.noLock {
+				lock = &mockWarmupLock{
+					tryAcquire: func(ctx context.Context, ttl time.Duration) (bool, error) {
+						assert.Equal(t, time.Hour, ttl, "held for the interval")
+						return tt.acquired, tt.lockErr
+					},
+				}
+			}
+
+			var warmed []domain.UserID
+			warmer, err := NewCacheWarmer(repo, newRecordingReader(&warmed), lock, CacheWarmerConfig{
+				TopN:     10,
+				Window:   time.Hour,
+				Interval: time.Hour,
+			}, &mockSweeperLogger{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantWarmed, warmer.warmOnce(context.Background()))
+			assert.Equal(t, tt.wantWarmed, len(warmed) == 1)
+		})
+	}
+}
+
+func TestNewCacheWarmer_InvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		_, err := NewCacheWarmer(&mockHotRecipientsRepo{}, &mockLikersReader{}, nil, CacheWarmerConfig{TopN: 10, Interval: interval}, &mockSweeperLogger{})
+		assert.EqualError(t, err, "warm-up interval must be positive")
+	}
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+)
+
+const (
+	defaultCandidatesPageSize = 20
+	maxCandidatesPageSize     = 100
+)
+
+type candidateSource interface {
+	// GetUndecidedLikers returns users who liked the actor and whom the actor
+	// hasn't decided on yet.
+	GetUndecidedLikers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+	// GetUndecidedUsers returns users other than the actor whom the actor
+	// hasn't decided on yet. It may include the actor's likers.
+	GetUndecidedUsers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+}
+
+type CandidateProvider struct {
+	source candidateSource
+}
+
+func NewCandidateProvider(source candidateSource) *CandidateProvider {
+	return &CandidateProvider{
+		source: source,
+	}
+}
+
+// GetCandidates returns up to pageSize users the actor hasn't decided on yet.
+// Users who already liked the actor come first, as deciding on them can
+// produce a match straight away.
+func (p *CandidateProvider) GetCandidates(ctx context.Context, actorID domain.UserID, pageSize uint64) ([]domain.UserID, error) {
+	if pageSize == 0 {
+		pageSize = defaultCandidatesPageSize
+	}
+	pageSize = min(pageSize, maxCandidatesPageSize)
+
+	likers, err := p.source.GetUndecidedLikers(ctx, actorID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undecided likers: %w", err)
+	}
+
+	if uint64(len(likers)) >= pageSize {
+		return likers[:pageSize], nil
+	}
+
+	// The likers may show up again among the other users, so fetch enough to
+	// fill the page after they are skipped.
+	others, err := p.source.GetUndecidedUsers(ctx, actorID, pageSize+uint64(len(likers)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undecided users: %w", err)
+	}
+
+	seen := make(map[domain.UserID]struct{}, len(likers))
+	for _, id := range likers {
+		seen[id] = struct{}{}
+	}
+
+	candidates := likers
+	for _, id := range others {
+		if uint64(len(candidates)) == pageSize {
+			break
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		candidates = append(candidates, id)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+type mockCandidateSource struct {
+	getUndecidedLikers func(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+	getUndecidedUsers  func(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error)
+}
+
+func (m *mockCandidateSource) GetUndecidedLikers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	return m.getUndecidedLikers(ctx, actorID, limit)
+}
+
+func (m *mockCandidateSource) GetUndecidedUsers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	return m.getUndecidedUsers(ctx, actorID, limit)
+}
+
+func TestCandidateProvider_GetCandidates(t *testing.T) {
+	tests := []struct {
+		name           string
+		pageSize       uint64
+		likers         []domain.UserID
+		likersErr      error
+		others         []domain.UserID
+		othersErr      error
+		wantLikerLimit uint64
+		wantOtherLimit uint64
+		want           []domain.UserID
+		wantErr        error
+	}{
+		{
+			name:           "success - likers first, then other users without duplicates",
+			pageSize:       4,
+			likers:         []domain.UserID{"liker1", "liker2"},
+			others:         []domain.UserID{"liker1", "user1", "liker2", "user2", "user3"},
+			wantLikerLimit: 4,
+			wantOtherLimit: 6,
+			want:           []domain.UserID{"liker1", "liker2", "user1", "user2"},
+		},
+		{
+			name:           "success - page filled by likers",
+			pageSize:       2,
+			likers:         []domain.UserID{"liker1", "liker2"},
+			wantLikerLimit: 2,
+			want:           []domain.UserID{"liker1", "liker2"},
+		},
+		{
+			name:           "success - fewer candidates than the page size",
+			pageSize:       5,
+			likers:         []domain.UserID{"liker1"},
+			others:         []domain.UserID{"user1", "liker1"},
+			wantLikerLimit: 5,
+			wantOtherLimit: 6,
+			want:           []domain.UserID{"liker1", "user1"},
+		},
+		{
+			name:           "success - default page size",
+			others:         []domain.UserID{"user1"},
+			wantLikerLimit: defaultCandidatesPageSize,
+			wantOtherLimit: defaultCandidatesPageSize,
+			want:           []domain.UserID{"user1"},
+		},
+		{
+			name:           "success - page size is capped",
+			pageSize:       1000,
+			wantLikerLimit: maxCandidatesPageSize,
+			wantOtherLimit: maxCandidatesPageSize,
+			want:           nil,
+		},
+		{
+			name:           "error - likers error",
+			pageSize:       5,
+			likersErr:      errors.New("db error"),
+			wantLikerLimit: 5,
+			wantErr:        errors.New("failed to get undecided likers: db error"),
+		},
+		{
+			name:           "error - users error",
+			pageSize:       5,
+			othersErr:      errors.New("db error"),
+			wantLikerLimit: 5,
+			wantOtherLimit: 5,
+			wantErr:        errors.New("failed to get undecided users: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &mockCandidateSource{
+				getUndecidedLikers: func(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+					assert.Equal(t, domain.UserID("actor"), actorID)
+					assert.Equal(t, tt.wantLikerLimit, limit)
+					return tt.likers, tt.likersErr
+				},
+				getUndecidedUsers: func(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+					assert.Equal(t, domain.UserID("actor"), actorID)
+					assert.Equal(t, tt.wantOtherLimit, limit)
+					return tt.others, tt.othersErr
+				},
+			}
+
+			got, err := NewCandidateProvider(source).GetCandidates(context.Background(), "actor", tt.pageSize)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+type countReconcilerRepository interface {
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+}
+
+type countReconcilerCache interface {
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+	CountedRecipients(ctx context.Context) ([]domain.UserID, error)
+}
+
+// CountReconciler recounts cached likers counters that drifted from the
+// database, e.g. after a failed write or a manual fix in Postgres.
+type CountReconciler struct {
+	repo  countReconcilerRepository
+	cache countReconcilerCache
+}
+
+func NewCountReconciler(repo countReconcilerRepository, cache countReconcilerCache) *CountReconciler {
+	return &CountReconciler{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// Reconcile checks every cached counter of the recipient, total and unseen,
+// with and without expired likes. Drifted counters are overwritten unless
+// dryRun is set. Counters that aren't cached are skipped.
+func (r *CountReconciler) Reconcile(ctx context.Context, recipientID domain.UserID, dryRun bool) ([]domain.CounterCheck, error) {
+	if recipientID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	watermark, err := r.repo.GetSeenWatermark(ctx, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seen watermark: %w", err)
+	}
+
+	var checks []domain.CounterCheck
+	for _, includeExpired := range []bool{false, true} {
+		for _, seenUpTo := range []*domain.SeenWatermark{nil, &watermark} {
+			query := domain.LikersCountQuery{RecipientID: recipientID, SeenUpTo: seenUpTo, IncludeExpired: includeExpired}
+
+			cached, err := r.cache.GetLikersCount(ctx, query)
+			if err != nil {
+				continue
+			}
+
+			start := time.Now()
+			actual, err := r.repo.GetLikersCount(ctx, query)
+			if err != nil {
+				return checks, fmt.Errorf("failed to count likers: %w", err)
+			}
+
+			check := domain.CounterCheck{Query: query, Cached: cached, Actual: actual}
+			if check.Drifted() && !dryRun {
+				if err := r.cache.SetLikersCount(ctx, query, actual, time.Since(start)); err != nil {
+					return checks, fmt.Errorf("failed to repair counter: %w", err)
+				}
+				check.Repaired = true
+			}
+			checks = append(checks, check)
+		}
+	}
+
+	return checks, nil
+}
+
+// ReconcileAll reconciles every recipient with a cached counter.
+func (r *CountReconciler) ReconcileAll(ctx context.Context, dryRun bool) ([]domain.CounterCheck, error) {
+	recipients, err := r.cache.CountedRecipients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached counters: %w", err)
+	}
+
+	var checks []domain.CounterCheck
+	for _, recipientID := range recipients {
+		recipientChecks, err := r.Reconcile(ctx, recipientID, dryRun)
+		checks = append(checks, recipientChecks...)
+		if err != nil {
+			return checks, err
+		}
+	}
+
+	return checks, nil
+}
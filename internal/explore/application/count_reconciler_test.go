@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+// countKey identifies a counter by value, as queries hold a pointer.
+func countKey(query domain.LikersCountQuery) string {
+	key := fmt.Sprintf("%s:expired=%t", query.RecipientID, query.IncludeExpired)
+	if query.SeenUpTo != nil {
+		key += fmt.Sprintf(":seen=%d:%s", query.SeenUpTo.Timestamp, query.SeenUpTo.ActorID)
+	}
+	return key
+}
+
+type mockCountReconcilerRepo struct {
+	counts    map[string]uint64
+	watermark domain.SeenWatermark
+	err       error
+}
+
+func (m *mockCountReconcilerRepo) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	return m.counts[countKey(query)], m.err
+}
+
+func (m *mockCountReconcilerRepo) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	return m.watermark, nil
+}
+
+type mockCountReconcilerCache struct {
+	counts     map[string]uint64
+	recipients []domain.UserID
+}
+
+func (m *mockCountReconcilerCache) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	count, ok := m.counts[countKey(query)]
+	if !ok {
+		return 0, errors.New("cache miss")
+	}
+	return count, nil
+}
+
+func (m *mockCountReconcilerCache) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	m.counts[countKey(query)] = count
+	return nil
+}
+
+func (m *mockCountReconcilerCache) CountedRecipients(ctx context.Context) ([]domain.UserID, error) {
+	return m.recipients, nil
+}
+
+func TestCountReconciler_Reconcile(t *testing.T) {
+	watermark := domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}
+	total := domain.LikersCountQuery{RecipientID: "user1"}
+	unseen := domain.LikersCountQuery{RecipientID: "user1", SeenUpTo: &watermark}
+
+	tests := []struct {
+		name       string
+		dryRun     bool
+		repoErr    error
+		wantChecks []domain.CounterCheck
+		wantCache  map[string]uint64
+		wantErr    error
+	}{
+		{
+			name: "drifted counters are repaired",
+			wantChecks: []domain.CounterCheck{
+				{Query: total, Cached: 7, Actual: 5, Repaired: true},
+				{Query: unseen, Cached: 2, Actual: 2},
+			},
+			wantCache: map[string]uint64{countKey(total): 5, countKey(unseen): 2},
+		},
+		{
+			name:   "dry run leaves the cache alone",
+			dryRun: true,
+			wantChecks: []domain.CounterCheck{
+				{Query: total, Cached: 7, Actual: 5},
+				{Query: unseen, Cached: 2, Actual: 2},
+			},
+			wantCache: map[string]uint64{countKey(total): 7, countKey(unseen): 2},
+		},
+		{
+			name:      "repository error",
+			repoErr:   errors.New("db error"),
+			wantCache: map[string]uint64{countKey(total): 7, countKey(unseen): 2},
+			wantErr:   errors.New("failed to count likers: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockCountReconcilerRepo{
+				counts: map[string]uint64{
+					countKey(total):  5,
+					countKey(unseen): 2,
+					countKey(domain.LikersCountQuery{RecipientID: "user1", IncludeExpired: true}): 9,
+				},
+				watermark: watermark,
+				err:       tt.repoErr,
+			}
+			cache := &mockCountReconcilerCache{counts: map[string]uint64{countKey(total): 7, countKey(unseen): 2}}
+
+			checks, err := NewCountReconciler(repo, cache).Reconcile(context.Background(), "user1", tt.dryRun)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantChecks, checks)
+			}
+			assert.Equal(t, tt.wantCache, cache.counts)
+		})
+	}
+}
+
+func TestCountReconciler_ReconcileAll(t *testing.T) {
+	repo := &mockCountReconcilerRepo{counts: map[string]uint64{"user1:expired=false": 1, "user2:expired=false": 3}}
+	cache := &mockCountReconcilerCache{
+		counts:     map[string]uint64{"user1:expired=false": 1, "user2:expired=false": 2},
+		recipients: []domain.UserID{"user1", "user2"},
+	}
+
+	checks, err := NewCountReconciler(repo, cache).ReconcileAll(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CounterCheck{
+		{Query: domain.LikersCountQuery{RecipientID: "user1"}, Cached: 1, Actual: 1},
+		{Query: domain.LikersCountQuery{RecipientID: "user2"}, Cached: 2, Actual: 3, Repaired: true},
+	}, checks)
+}
@@ -2,27 +2,157 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"time"
 )
 
+const superLikeQuotaWindow = 24 * time.Hour
+
 type decisionCreatorRepository interface {
-	InsertDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error)
+	// InsertDecision checks a super-like against the quota, when one is
+	// given, atomically with saving it. It returns whether the decision
+	// completes a mutual like and the timestamp it was stored with.
+	InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error)
+}
+
+type userLookup interface {
+	GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error)
+}
+
+// likerIndex is a cache updated in place with every saved decision instead of
+// being invalidated. RecordOwnDecision leaves the recipient's likers alone.
+type likerIndex interface {
+	RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error
+	RecordOwnDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error
+}
+
+// abuseChecker is told about every saved decision and reports whether the
+// actor is shadow-banned.
+type abuseChecker interface {
+	CheckDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error)
+}
+
+type errorLogger interface {
+	Error(msg string, args ...any)
+}
+
+type DecisionCreatorConfig struct {
+	SuperLikeDailyLimit uint64
+	// AllowUnknownUsers treats users without a row as active instead of
+	// rejecting decisions involving them as not found. It is only meant for
+	// deployments whose sign-ups don't provision a row yet.
+	AllowUnknownUsers bool
 }
 
 type DecisionCreator struct {
-	repo decisionCreatorRepository
+	repo   decisionCreatorRepository
+	users  userLookup
+	index  likerIndex
+	abuse  abuseChecker
+	config DecisionCreatorConfig
+	logger errorLogger
 }
 
-func NewDecisionCreator(decisionRepo decisionCreatorRepository) *DecisionCreator {
+// NewDecisionCreator takes a nil index when likers are cached per page, and a
+// nil abuse checker to save decisions unchecked.
+func NewDecisionCreator(decisionRepo decisionCreatorRepository, users userLookup, index likerIndex, abuse abuseChecker, config DecisionCreatorConfig, logger errorLogger) *DecisionCreator {
 	return &DecisionCreator{
-		repo: decisionRepo,
+		repo:   decisionRepo,
+		users:  users,
+		index:  index,
+		abuse:  abuse,
+		config: config,
+		logger: logger,
 	}
 }
-func (c *DecisionCreator) SaveDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-	mutualLike, err := c.repo.InsertDecision(ctx, actorID, recipientID, liked)
+
+func (c *DecisionCreator) SaveDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+	if !decision.Valid() {
+		return false, domain.ErrInvalidInput
+	}
+
+	if err := c.checkUsers(ctx, actorID, recipientID); err != nil {
+		return false, err
+	}
+
+	// Super-likes are limited over a rolling day.
+	var quota *domain.SuperLikeQuota
+	if decision == domain.DecisionSuperLike {
+		quota = &domain.SuperLikeQuota{
+			Limit: c.config.SuperLikeDailyLimit,
+			Since: uint64(time.Now().Add(-superLikeQuotaWindow).Unix()),
+		}
+	}
+
+	mutualLike, timestamp, err := c.repo.InsertDecision(ctx, actorID, recipientID, decision, quota)
+	if errors.Is(err, domain.ErrSuperLikeQuotaExceeded) {
+		return false, err
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to save decision: %w", err)
 	}
 
+	// A decision is saved whatever the detector makes of it, shadow-banned
+	// actors only stay out of their recipients' likers in the index, as the
+	// repositories hide them. A failed check is logged rather than failing a
+	// decision already saved.
+	var shadowBanned bool
+	if c.abuse != nil {
+		shadowBanned, err = c.abuse.CheckDecision(ctx, actorID, recipientID, decision)
+		if err != nil {
+			c.logger.Error("abuse check failed", "actor_id", actorID, "error", err)
+		}
+	}
+
+	// The index is only a cache, so a failed update is logged and left to
+	// the next rebuild.
+	if c.index != nil {
+		if shadowBanned {
+			err = c.index.RecordOwnDecision(ctx, actorID, recipientID, decision)
+		} else {
+			err = c.index.RecordDecision(ctx, actorID, recipientID, decision, timestamp)
+		}
+		if err != nil {
+			c.logger.Error("liker index update failed", "actor_id", actorID, "recipient_id", recipientID, "error", err)
+		}
+	}
+
 	return mutualLike, nil
 }
+
+// checkUsers rejects users who don't exist or are paused, banned or deleted.
+// Users without a row don't exist unless AllowUnknownUsers is set. A deleted
+// recipient is reported as not found rather than revealing that the account
+// existed.
+func (c *DecisionCreator) checkUsers(ctx context.Context, actorID domain.UserID, recipientID domain.UserID) error {
+	users, err := c.users.GetUsers(ctx, actorID, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to look up users: %w", err)
+	}
+
+	if actor, ok := users[actorID]; ok {
+		if actor.Status == domain.UserStatusDeleted {
+			return fmt.Errorf("actor: %w", domain.ErrUserNotFound)
+		}
+		if !actor.Active() {
+			return fmt.Errorf("actor is %s: %w", actor.Status, domain.ErrUserNotActive)
+		}
+	} else if !c.config.AllowUnknownUsers {
+		return fmt.Errorf("actor: %w", domain.ErrUserNotFound)
+	}
+
+	if recipient, ok := users[recipientID]; ok {
+		if recipient.Status == domain.UserStatusDeleted {
+			return fmt.Errorf("recipient: %w", domain.ErrUserNotFound)
+		}
+		if !recipient.Active() {
+			return fmt.Errorf("recipient: %w", domain.ErrUserNotActive)
+		}
+	} else if !c.config.AllowUnknownUsers {
+		return fmt.Errorf("recipient: %w", domain.ErrUserNotFound)
+	}
+
+	return nil
+}
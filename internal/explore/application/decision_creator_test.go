@@ -4,23 +4,76 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
 	"testing"
+	"time"
 )
 
 type mockDecisionCreatorRepo struct {
-	insertDecision func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error)
+	insertDecision func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error)
 }
 
-func (m *mockDecisionCreatorRepo) InsertDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-	return m.insertDecision(ctx, actorID, recipientID, liked)
+func (m *mockDecisionCreatorRepo) InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+	return m.insertDecision(ctx, actorID, recipientID, decision, quota)
+}
+
+type mockUserLookup struct {
+	getUsers func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error)
+}
+
+func (m *mockUserLookup) GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+	return m.getUsers(ctx, userIDs...)
+}
+
+// activeUsers returns a lookup that finds every requested user and reports
+// them as active.
+func activeUsers() *mockUserLookup {
+	return &mockUserLookup{
+		getUsers: func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+			users := make(map[domain.UserID]domain.User, len(userIDs))
+			for _, id := range userIDs {
+				users[id] = domain.User{ID: id, Status: domain.UserStatusActive}
+			}
+			return users, nil
+		},
+	}
+}
+
+type mockLikerIndex struct {
+	recordDecision    func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error
+	recordOwnDecision func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error
+}
+
+func (m *mockLikerIndex) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error {
+	return m.recordDecision(ctx, actorID, recipientID, decision, timestamp)
+}
+
+func (m *mockLikerIndex) RecordOwnDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error {
+	return m.recordOwnDecision(ctx, actorID, recipientID, decision)
+}
+
+type mockErrorLogger struct {
+	messages []string
+}
+
+func (m *mockErrorLogger) Error(msg string, args ...any) {
+	m.messages = append(m.messages, msg)
+}
+
+type mockAbuseChecker struct {
+	checkDecision func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error)
+}
+
+func (m *mockAbuseChecker) CheckDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+	return m.checkDecision(ctx, actorID, recipientID, decision)
 }
 
 func TestDecisionCreator_SaveDecision(t *testing.T) {
 	tests := []struct {
 		name         string
-		actorID      string
-		recipientID  string
-		liked        bool
+		actorID      domain.UserID
+		recipientID  domain.UserID
+		decision     domain.Decision
 		mockBehavior func(*mockDecisionCreatorRepo)
 		wantMutual   bool
 		wantErr      error
@@ -29,10 +82,11 @@ func TestDecisionCreator_SaveDecision(t *testing.T) {
 			name:        "success - mutual like",
 			actorID:     "user1",
 			recipientID: "user2",
-			liked:       true,
+			decision:    domain.DecisionLike,
 			mockBehavior: func(m *mockDecisionCreatorRepo) {
-				m.insertDecision = func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-					return true, nil
+				m.insertDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					assert.Nil(t, quota, "only super-likes are limited")
+					return true, 100, nil
 				}
 			},
 			wantMutual: true,
@@ -42,23 +96,63 @@ func TestDecisionCreator_SaveDecision(t *testing.T) {
 			name:        "success - no mutual like",
 			actorID:     "user1",
 			recipientID: "user2",
-			liked:       true,
+			decision:    domain.DecisionLike,
 			mockBehavior: func(m *mockDecisionCreatorRepo) {
-				m.insertDecision = func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-					return false, nil
+				m.insertDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					return false, 100, nil
 				}
 			},
 			wantMutual: false,
 			wantErr:    nil,
 		},
+		{
+			name:        "success - super like within quota",
+			actorID:     "user1",
+			recipientID: "user2",
+			decision:    domain.DecisionSuperLike,
+			mockBehavior: func(m *mockDecisionCreatorRepo) {
+				m.insertDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					assert.Equal(t, domain.DecisionSuperLike, decision)
+					if assert.NotNil(t, quota) {
+						assert.Equal(t, uint64(3), quota.Limit)
+						assert.InDelta(t, time.Now().Add(-24*time.Hour).Unix(), int64(quota.Since), 5)
+					}
+					return false, 100, nil
+				}
+			},
+			wantMutual: false,
+			wantErr:    nil,
+		},
+		{
+			name:        "error - super like quota exceeded",
+			actorID:     "user1",
+			recipientID: "user2",
+			decision:    domain.DecisionSuperLike,
+			mockBehavior: func(m *mockDecisionCreatorRepo) {
+				m.insertDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					return false, 0, domain.ErrSuperLikeQuotaExceeded
+				}
+			},
+			wantMutual: false,
+			wantErr:    domain.ErrSuperLikeQuotaExceeded,
+		},
+		{
+			name:         "error - invalid decision",
+			actorID:      "user1",
+			recipientID:  "user2",
+			decision:     domain.Decision(7),
+			mockBehavior: func(m *mockDecisionCreatorRepo) {},
+			wantMutual:   false,
+			wantErr:      domain.ErrInvalidInput,
+		},
 		{
 			name:        "error - repository error",
 			actorID:     "user1",
 			recipientID: "user2",
-			liked:       true,
+			decision:    domain.DecisionLike,
 			mockBehavior: func(m *mockDecisionCreatorRepo) {
-				m.insertDecision = func(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
-					return false, errors.New("db error")
+				m.insertDecision = func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					return false, 0, errors.New("db error")
 				}
 			},
 			wantMutual: false,
@@ -71,8 +165,8 @@ func TestDecisionCreator_SaveDecision(t *testing.T) {
 			mockRepo := &mockDecisionCreatorRepo{}
 			tt.mockBehavior(mockRepo)
 
-			creator := NewDecisionCreator(mockRepo)
-			gotMutual, err := creator.SaveDecision(context.Background(), tt.actorID, tt.recipientID, tt.liked)
+			creator := NewDecisionCreator(mockRepo, activeUsers(), nil, nil, DecisionCreatorConfig{SuperLikeDailyLimit: 3}, &mockErrorLogger{})
+			gotMutual, err := creator.SaveDecision(context.Background(), tt.actorID, tt.recipientID, tt.decision)
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
@@ -84,3 +178,267 @@ func TestDecisionCreator_SaveDecision(t *testing.T) {
 		})
 	}
 }
+
+func TestDecisionCreator_SaveDecision_LikerIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		insertErr   error
+		indexErr    error
+		wantIndexed bool
+		wantLogged  []string
+		wantErr     bool
+	}{
+		{
+			name:        "decision recorded in index",
+			wantIndexed: true,
+		},
+		{
+			name:        "index error is logged and does not fail the decision",
+			indexErr:    errors.New("redis down"),
+			wantIndexed: true,
+			wantLogged:  []string{"liker index update failed"},
+		},
+		{
+			name:      "failed decision is not recorded",
+			insertErr: errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockDecisionCreatorRepo{
+				insertDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					return false, 100, tt.insertErr
+				},
+			}
+
+			var indexed bool
+			index := &mockLikerIndex{
+				recordDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error {
+					indexed = true
+					assert.Equal(t, domain.UserID("user1"), actorID)
+					assert.Equal(t, domain.UserID("user2"), recipientID)
+					assert.Equal(t, domain.DecisionPass, decision)
+					assert.Equal(t, uint64(100), timestamp, "stored timestamp")
+					return tt.indexErr
+				},
+			}
+
+			logger := &mockErrorLogger{}
+			creator := NewDecisionCreator(repo, activeUsers(), index, nil, DecisionCreatorConfig{SuperLikeDailyLimit: 3}, logger)
+			_, err := creator.SaveDecision(context.Background(), "user1", "user2", domain.DecisionPass)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantIndexed, indexed)
+			assert.Equal(t, tt.wantLogged, logger.messages)
+		})
+	}
+}
+
+func TestDecisionCreator_SaveDecision_AbuseCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		insertErr    error
+		shadowBanned bool
+		checkErr     error
+		wantChecked  bool
+		wantIndexed  bool
+		wantOwnOnly  bool
+		wantLogged   []string
+		wantErr      bool
+	}{
+		{
+			name:        "decision checked and indexed",
+			wantChecked: true,
+			wantIndexed: true,
+		},
+		{
+			name:         "shadow-banned actor only updates their own index",
+			shadowBanned: true,
+			wantChecked:  true,
+			wantOwnOnly:  true,
+		},
+		{
+			name:        "check error is logged and does not fail the decision",
+			checkErr:    errors.New("redis down"),
+			wantChecked: true,
+			wantIndexed: true,
+			wantLogged:  []string{"abuse check failed"},
+		},
+		{
+			name:      "failed decision is not checked",
+			insertErr: errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockDecisionCreatorRepo{
+				insertDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					return true, 100, tt.insertErr
+				},
+			}
+
+			var checked bool
+			abuse := &mockAbuseChecker{
+				checkDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (bool, error) {
+					checked = true
+					assert.Equal(t, domain.UserID("user1"), actorID)
+					assert.Equal(t, domain.UserID("user2"), recipientID)
+					assert.Equal(t, domain.DecisionLike, decision)
+					return tt.shadowBanned, tt.checkErr
+				},
+			}
+
+			var indexed, ownOnly bool
+			index := &mockLikerIndex{
+				recordDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error {
+					indexed = true
+					return nil
+				},
+				recordOwnDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error {
+					ownOnly = true
+					assert.Equal(t, domain.UserID("user1"), actorID)
+					assert.Equal(t, domain.UserID("user2"), recipientID)
+					return nil
+				},
+			}
+
+			logger := &mockErrorLogger{}
+			mutual, err := NewDecisionCreator(repo, activeUsers(), index, abuse, DecisionCreatorConfig{SuperLikeDailyLimit: 3}, logger).SaveDecision(context.Background(), "user1", "user2", domain.DecisionLike)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, !tt.wantErr, mutual)
+			assert.Equal(t, tt.wantChecked, checked)
+			assert.Equal(t, tt.wantIndexed, indexed)
+			assert.Equal(t, tt.wantOwnOnly, ownOnly)
+			assert.Equal(t, tt.wantLogged, logger.messages)
+		})
+	}
+}
+
+func TestDecisionCreator_SaveDecision_UserChecks(t *testing.T) {
+	tests := []struct {
+		name         string
+		users        map[domain.UserID]domain.User
+		allowUnknown bool
+		lookupErr    error
+		wantErr      error
+		wantErrMsg   string
+	}{
+		{
+			name: "success - both users active",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusActive},
+				"user2": {ID: "user2", Status: domain.UserStatusActive},
+			},
+		},
+		{
+			name: "success - users without a row are active when allowed",
+			users: map[domain.UserID]domain.User{
+				"user2": {ID: "user2", Status: domain.UserStatusActive},
+			},
+			allowUnknown: true,
+		},
+		{
+			name: "error - unknown actor",
+			users: map[domain.UserID]domain.User{
+				"user2": {ID: "user2", Status: domain.UserStatusActive},
+			},
+			wantErr:    domain.ErrUserNotFound,
+			wantErrMsg: "actor: user not found",
+		},
+		{
+			name: "error - unknown recipient",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusActive},
+			},
+			wantErr:    domain.ErrUserNotFound,
+			wantErrMsg: "recipient: user not found",
+		},
+		{
+			name: "error - deleted actor is not found",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusDeleted},
+				"user2": {ID: "user2", Status: domain.UserStatusActive},
+			},
+			wantErr:    domain.ErrUserNotFound,
+			wantErrMsg: "actor: user not found",
+		},
+		{
+			name: "error - banned actor",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusBanned},
+				"user2": {ID: "user2", Status: domain.UserStatusActive},
+			},
+			wantErr:    domain.ErrUserNotActive,
+			wantErrMsg: "actor is banned: user is not active",
+		},
+		{
+			name: "error - banned recipient without an actor row",
+			users: map[domain.UserID]domain.User{
+				"user2": {ID: "user2", Status: domain.UserStatusBanned},
+			},
+			allowUnknown: true,
+			wantErr:      domain.ErrUserNotActive,
+			wantErrMsg:   "recipient: user is not active",
+		},
+		{
+			name: "error - deleted recipient is not found",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusActive},
+				"user2": {ID: "user2", Status: domain.UserStatusDeleted},
+			},
+			wantErr:    domain.ErrUserNotFound,
+			wantErrMsg: "recipient: user not found",
+		},
+		{
+			name: "error - paused recipient",
+			users: map[domain.UserID]domain.User{
+				"user1": {ID: "user1", Status: domain.UserStatusActive},
+				"user2": {ID: "user2", Status: domain.UserStatusPaused},
+			},
+			wantErr:    domain.ErrUserNotActive,
+			wantErrMsg: "recipient: user is not active",
+		},
+		{
+			name:       "error - lookup error",
+			lookupErr:  errors.New("db error"),
+			wantErrMsg: "failed to look up users: db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inserted bool
+			repo := &mockDecisionCreatorRepo{
+				insertDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+					inserted = true
+					return false, 100, nil
+				},
+			}
+			users := &mockUserLookup{
+				getUsers: func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+					assert.Equal(t, []domain.UserID{"user1", "user2"}, userIDs)
+					return tt.users, tt.lookupErr
+				},
+			}
+
+			_, err := NewDecisionCreator(repo, users, nil, nil, DecisionCreatorConfig{SuperLikeDailyLimit: 3, AllowUnknownUsers: tt.allowUnknown}, &mockErrorLogger{}).SaveDecision(context.Background(), "user1", "user2", domain.DecisionLike)
+
+			if tt.wantErrMsg != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErrMsg, err.Error())
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assert.False(t, inserted)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, inserted)
+			}
+		})
+	}
+}
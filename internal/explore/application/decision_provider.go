@@ -3,117 +3,301 @@ package application
 import (
 	"context"
 	"fmt"
+	"golang.org/x/sync/singleflight"
 	"muzz-homework/internal/explore/domain"
+	"muzz-homework/pkg/ctxutil"
+	"time"
 )
 
 type decisionProviderRepository interface {
-	GetLikers(ctx context.Context, recipientID string, paginationToken *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error)
-	GetLikersCount(ctx context.Context, recipientID string) (uint64, error)
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error)
 }
 
 type cacheRepository interface {
-	GetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error)
-	SetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error
-	GetLikersCount(ctx context.Context, recipientID string) (uint64, error)
-	SetLikersCount(ctx context.Context, recipientID string, count uint64) error
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+}
+
+type profileSource interface {
+	GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error)
 }
 
 type DecisionProvider struct {
-	repo  decisionProviderRepository
-	cache cacheRepository
+	repo     decisionProviderRepository
+	cache    cacheRepository
+	profiles profileSource
+	tokens   *domain.TokenCodec
+	// misses coalesces concurrent cache misses for the same query into a
+	// single repository query.
+	misses singleflight.Group
 }
 
-func NewDecisionProvider(repo decisionProviderRepository, cache cacheRepository) *DecisionProvider {
+type likersPage struct {
+	likers []domain.LikerInfo
+	next   *domain.Cursor
+}
+
+func NewDecisionProvider(repo decisionProviderRepository, cache cacheRepository, profiles profileSource, tokens *domain.TokenCodec) *DecisionProvider {
 	return &DecisionProvider{
-		repo:  repo,
-		cache: cache,
+		repo:     repo,
+		cache:    cache,
+		profiles: profiles,
+		tokens:   tokens,
+	}
+}
+
+func (p *DecisionProvider) ListLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	if opts.Filter == "" {
+		opts.Filter = domain.LikersFilterAll
+	}
+
+	return p.listLikers(ctx, recipientID, encodedToken, opts)
+}
+
+func (p *DecisionProvider) ListNewLikedYou(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
+	if opts.Filter != "" && opts.Filter != domain.LikersFilterPending {
+		return nil, "", domain.ErrInvalidFilter
 	}
+	opts.Filter = domain.LikersFilterPending
+
+	return p.listLikers(ctx, recipientID, encodedToken, opts)
 }
 
-func (p *DecisionProvider) ListLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error) {
+func (p *DecisionProvider) listLikers(ctx context.Context, recipientID domain.UserID, encodedToken string, opts domain.ListLikersOptions) ([]domain.LikerInfo, string, error) {
 	if recipientID == "" {
 		return nil, "", domain.ErrInvalidInput
 	}
 
-	timestamp, err := domain.DecodePaginationToken(encodedToken)
+	if !opts.Filter.Valid() {
+		return nil, "", domain.ErrInvalidFilter
+	}
+
+	kind := domain.LikersTokenKind(opts)
+	cursor, err := p.tokens.Decode(encodedToken, recipientID, kind)
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid pagination token: %w", err)
+		return nil, "", err
 	}
 
-	likers, nextTimestamp, err := p.cache.GetLikers(ctx, recipientID, timestamp, false)
-	if err == nil {
-		var nextToken string
-		if nextTimestamp != nil {
-			nextToken = domain.EncodePaginationToken(*nextTimestamp)
+	query := domain.LikersQuery{
+		RecipientID:     recipientID,
+		Cursor:          cursor,
+		Filter:          opts.Filter,
+		SuperLikesFirst: opts.SuperLikesFirst,
+		IncludeExpired:  opts.IncludeExpired,
+	}
+
+	if opts.UnseenOnly {
+		watermark, err := p.seenWatermark(ctx, recipientID)
+		if err != nil {
+			return nil, "", err
 		}
-		return likers, nextToken, nil
+		query.SeenUpTo = &watermark
 	}
 
-	likers, nextTimestamp, err = p.repo.GetLikers(ctx, recipientID, timestamp, false)
+	likers, nextCursor, err := p.cache.GetLikers(ctx, query)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to list likers: %w", err)
+		likers, nextCursor, err = p.loadLikers(ctx, query)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list likers: %w", err)
+		}
 	}
 
-	p.cache.SetLikers(ctx, recipientID, timestamp, false, likers, nextTimestamp)
+	if opts.IncludeProfile && len(likers) > 0 {
+		likers, err = p.attachProfiles(ctx, likers)
+		if err != nil {
+			return nil, "", err
+		}
+	}
 
 	var nextToken string
-	if nextTimestamp != nil {
-		nextToken = domain.EncodePaginationToken(*nextTimestamp)
+	if nextCursor != nil {
+		nextToken = p.tokens.Encode(recipientID, kind, *nextCursor)
 	}
 
 	return likers, nextToken, nil
 }
 
-func (p *DecisionProvider) ListNewLikedYou(ctx context.Context, recipientID string, encodedToken string) ([]domain.LikerInfo, string, error) {
+// attachProfiles returns a copy of likers with their profiles, as cached
+// pages may be shared with other callers.
+func (p *DecisionProvider) attachProfiles(ctx context.Context, likers []domain.LikerInfo) ([]domain.LikerInfo, error) {
+	ids := make([]domain.UserID, len(likers))
+	for i, liker := range likers {
+		ids[i] = liker.ActorID
+	}
+
+	profiles, err := p.profiles.GetProfiles(ctx, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profiles: %w", err)
+	}
+
+	enriched := make([]domain.LikerInfo, len(likers))
+	for i, liker := range likers {
+		if profile, ok := profiles[liker.ActorID]; ok {
+			liker.Profile = &profile
+		}
+		enriched[i] = liker
+	}
+
+	return enriched, nil
+}
+
+func (p *DecisionProvider) CountLikedYou(ctx context.Context, recipientID domain.UserID, opts domain.CountLikersOptions) (domain.LikersCount, error) {
 	if recipientID == "" {
-		return nil, "", domain.ErrInvalidInput
+		return domain.LikersCount{}, domain.ErrInvalidInput
+	}
+
+	query := domain.LikersCountQuery{
+		RecipientID:    recipientID,
+		IncludeExpired: opts.IncludeExpired,
+	}
+
+	total, err := p.countLikers(ctx, query)
+	if err != nil {
+		return domain.LikersCount{}, err
+	}
+
+	watermark, err := p.seenWatermark(ctx, recipientID)
+	if err != nil {
+		return domain.LikersCount{}, err
 	}
 
-	timestamp, err := domain.DecodePaginationToken(encodedToken)
+	query.SeenUpTo = &watermark
+	unseen, err := p.countLikers(ctx, query)
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid pagination token: %w", err)
+		return domain.LikersCount{}, err
 	}
 
-	likers, nextTimestamp, err := p.cache.GetLikers(ctx, recipientID, timestamp, true)
+	return domain.LikersCount{
+		Total:  total,
+		Unseen: unseen,
+	}, nil
+}
+
+func (p *DecisionProvider) countLikers(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	count, err := p.cache.GetLikersCount(ctx, query)
 	if err == nil {
-		var nextToken string
-		if nextTimestamp != nil {
-			nextToken = domain.EncodePaginationToken(*nextTimestamp)
+		return count, nil
+	}
+
+	result, err := p.coalesce(ctx, countFlightKey(query), func(ctx context.Context) (any, error) {
+		start := time.Now()
+		count, err := p.repo.GetLikersCount(ctx, query)
+		if err != nil {
+			return nil, err
 		}
-		return likers, nextToken, nil
+
+		p.cache.SetLikersCount(ctx, query, count, time.Since(start))
+		return count, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count likers: %w", err)
 	}
 
-	likers, nextTimestamp, err = p.repo.GetLikers(ctx, recipientID, timestamp, true)
+	return result.(uint64), nil
+}
+
+func (p *DecisionProvider) loadLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	result, err := p.coalesce(ctx, likersFlightKey(query), func(ctx context.Context) (any, error) {
+		start := time.Now()
+		likers, next, err := p.repo.GetLikers(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		p.cache.SetLikers(ctx, query, likers, next, time.Since(start))
+		return likersPage{likers: likers, next: next}, nil
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to list new likers: %w", err)
+		return nil, nil, err
 	}
 
-	p.cache.SetLikers(ctx, recipientID, timestamp, true, likers, nextTimestamp)
+	page := result.(likersPage)
+	return page.likers, page.next, nil
+}
 
-	var nextToken string
-	if nextTimestamp != nil {
-		nextToken = domain.EncodePaginationToken(*nextTimestamp)
+// coalesce runs load once for every concurrent caller with the same key.
+func (p *DecisionProvider) coalesce(ctx context.Context, key string, load func(ctx context.Context) (any, error)) (any, error) {
+	results := p.misses.DoChan(key, func() (any, error) {
+		loadCtx, cancel := ctxutil.Detach(ctx)
+		defer cancel()
+
+		return load(loadCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		return result.Val, result.Err
 	}
+}
 
-	return likers, nextToken, nil
+// likersFlightKey identifies a likers query for coalescing. It covers every
+// field of the query, independently of how the cache keys its entries.
+func likersFlightKey(query domain.LikersQuery) string {
+	var cursor domain.Cursor
+	if query.Cursor != nil {
+		cursor = *query.Cursor
+	}
+
+	return fmt.Sprintf("likers:%q:%d:%q:%d:%s:%s:%t:%t", query.RecipientID, cursor.Timestamp, cursor.ActorID, cursor.Decision,
+		query.Filter, flightWatermark(query.SeenUpTo), query.SuperLikesFirst, query.IncludeExpired)
 }
 
-func (p *DecisionProvider) CountLikedYou(ctx context.Context, recipientID string) (uint64, error) {
-	if recipientID == "" {
-		return 0, domain.ErrInvalidInput
+func countFlightKey(query domain.LikersCountQuery) string {
+	return fmt.Sprintf("count:%q:%s:%t", query.RecipientID, flightWatermark(query.SeenUpTo), query.IncludeExpired)
+}
+
+func flightWatermark(seenUpTo *domain.SeenWatermark) string {
+	if seenUpTo == nil {
+		return "-"
 	}
 
-	count, err := p.cache.GetLikersCount(ctx, recipientID)
+	return fmt.Sprintf("%d:%q", seenUpTo.Timestamp, seenUpTo.ActorID)
+}
+
+// MarkLikesSeen moves the recipient's seen watermark forward to upTo, the
+// newest liker they have seen. The watermark never moves backwards and never
+// past the current time.
+func (p *DecisionProvider) MarkLikesSeen(ctx context.Context, recipientID domain.UserID, upTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+	if recipientID == "" || upTo.Timestamp == 0 || upTo.ActorID == "" {
+		return domain.SeenWatermark{}, domain.ErrInvalidInput
+	}
+
+	if now := uint64(time.Now().Unix()); upTo.Timestamp > now {
+		upTo = domain.SeenWatermark{Timestamp: now}
+	}
+
+	watermark, err := p.repo.SetSeenWatermark(ctx, recipientID, upTo)
+	if err != nil {
+		return domain.SeenWatermark{}, fmt.Errorf("failed to mark likes seen: %w", err)
+	}
+
+	p.cache.UpdateSeenWatermark(ctx, recipientID, watermark)
+
+	return watermark, nil
+}
+
+func (p *DecisionProvider) seenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	watermark, err := p.cache.GetSeenWatermark(ctx, recipientID)
 	if err == nil {
-		return count, nil
+		return watermark, nil
 	}
 
-	count, err = p.repo.GetLikersCount(ctx, recipientID)
+	watermark, err = p.repo.GetSeenWatermark(ctx, recipientID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count likers: %w", err)
+		return domain.SeenWatermark{}, fmt.Errorf("failed to get seen watermark: %w", err)
 	}
 
-	p.cache.SetLikersCount(ctx, recipientID, count)
+	p.cache.SetSeenWatermark(ctx, recipientID, watermark)
 
-	return count, nil
+	return watermark, nil
 }
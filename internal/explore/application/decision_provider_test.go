@@ -4,56 +4,96 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"muzz-homework/internal/explore/domain"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type mockDecisionProviderRepo struct {
-	getLikers      func(ctx context.Context, recipientID string, paginationToken *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error)
-	getLikersCount func(ctx context.Context, recipientID string) (uint64, error)
+	getLikers        func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	getLikersCount   func(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	getSeenWatermark func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	setSeenWatermark func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error)
 }
 
-func (m *mockDecisionProviderRepo) GetLikers(ctx context.Context, recipientID string, paginationToken *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-	return m.getLikers(ctx, recipientID, paginationToken, excludeMutual)
+func (m *mockDecisionProviderRepo) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	return m.getLikers(ctx, query)
 }
 
-func (m *mockDecisionProviderRepo) GetLikersCount(ctx context.Context, recipientID string) (uint64, error) {
-	return m.getLikersCount(ctx, recipientID)
+func (m *mockDecisionProviderRepo) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	return m.getLikersCount(ctx, query)
+}
+
+func (m *mockDecisionProviderRepo) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	return m.getSeenWatermark(ctx, recipientID)
+}
+
+func (m *mockDecisionProviderRepo) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+	return m.setSeenWatermark(ctx, recipientID, seenUpTo)
 }
 
 type mockCacheRepo struct {
-	getLikers      func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error)
-	setLikers      func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error
-	getLikersCount func(ctx context.Context, recipientID string) (uint64, error)
-	setLikersCount func(ctx context.Context, recipientID string, count uint64) error
+	getLikers           func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	setLikers           func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error
+	getLikersCount      func(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	setLikersCount      func(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+	getSeenWatermark    func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	setSeenWatermark    func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	updateSeenWatermark func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+}
+
+func (m *mockCacheRepo) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	return m.getLikers(ctx, query)
+}
+
+func (m *mockCacheRepo) SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+	return m.setLikers(ctx, query, likers, next, computeTime)
+}
+
+func (m *mockCacheRepo) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	return m.getLikersCount(ctx, query)
+}
+
+func (m *mockCacheRepo) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	return m.setLikersCount(ctx, query, count, computeTime)
+}
+
+func (m *mockCacheRepo) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	return m.getSeenWatermark(ctx, recipientID)
 }
 
-func (m *mockCacheRepo) GetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-	return m.getLikers(ctx, recipientID, timestamp, excludeMutual)
+func (m *mockCacheRepo) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return m.setSeenWatermark(ctx, recipientID, seenUpTo)
 }
 
-func (m *mockCacheRepo) SetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error {
-	return m.setLikers(ctx, recipientID, timestamp, excludeMutual, likers, nextTS)
+func (m *mockCacheRepo) UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return m.updateSeenWatermark(ctx, recipientID, seenUpTo)
 }
 
-func (m *mockCacheRepo) GetLikersCount(ctx context.Context, recipientID string) (uint64, error) {
-	return m.getLikersCount(ctx, recipientID)
+type mockProfileSource struct {
+	getProfiles func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error)
 }
 
-func (m *mockCacheRepo) SetLikersCount(ctx context.Context, recipientID string, count uint64) error {
-	return m.setLikersCount(ctx, recipientID, count)
+func (m *mockProfileSource) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	return m.getProfiles(ctx, userIDs...)
 }
 
 func TestDecisionProvider_ListLikedYou(t *testing.T) {
+	otherRecipientToken := newTestTokenCodec(t).Encode("user3", domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterAll}), domain.Cursor{Timestamp: 123456})
+
 	tests := []struct {
-		name          string
-		recipientID   string
-		encodedToken  string
-		setCache      bool
-		mockBehavior  func(*mockDecisionProviderRepo, *mockCacheRepo)
-		wantLikers    []domain.LikerInfo
-		wantNextToken string
-		wantErr       error
+		name           string
+		recipientID    domain.UserID
+		encodedToken   string
+		setCache       bool
+		mockBehavior   func(*mockDecisionProviderRepo, *mockCacheRepo)
+		wantLikers     []domain.LikerInfo
+		wantNextCursor *domain.Cursor
+		wantErr        error
 	}{
 		{
 			name:         "success - from cache",
@@ -61,13 +101,13 @@ func TestDecisionProvider_ListLikedYou(t *testing.T) {
 			encodedToken: "",
 			setCache:     true,
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, uint64Ptr(123456), nil
+				mc.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, &domain.Cursor{Timestamp: 123456}, nil
 				}
 			},
-			wantLikers:    []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
-			wantNextToken: "eyJ0IjoxMjM0NTZ9",
-			wantErr:       nil,
+			wantLikers:     []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
+			wantNextCursor: &domain.Cursor{Timestamp: 123456},
+			wantErr:        nil,
 		},
 		{
 			name:         "success - from db",
@@ -75,19 +115,19 @@ func TestDecisionProvider_ListLikedYou(t *testing.T) {
 			encodedToken: "",
 			setCache:     false,
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
+				mc.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
 					return nil, nil, errors.New("cache miss")
 				}
-				mr.getLikers = func(ctx context.Context, recipientID string, paginationToken *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, uint64Ptr(123456), nil
+				mr.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, &domain.Cursor{Timestamp: 123456}, nil
 				}
-				mc.setLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error {
+				mc.setLikers = func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
 					return nil
 				}
 			},
-			wantLikers:    []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
-			wantNextToken: "eyJ0IjoxMjM0NTZ9",
-			wantErr:       nil,
+			wantLikers:     []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
+			wantNextCursor: &domain.Cursor{Timestamp: 123456},
+			wantErr:        nil,
 		},
 		{
 			name:         "error - empty recipient ID",
@@ -105,6 +145,14 @@ func TestDecisionProvider_ListLikedYou(t *testing.T) {
 			wantLikers:   nil,
 			wantErr:      errors.New("invalid pagination token"),
 		},
+		{
+			name:         "error - token issued for another recipient",
+			recipientID:  "user1",
+			encodedToken: otherRecipientToken,
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {},
+			wantLikers:   nil,
+			wantErr:      domain.ErrTokenRecipientMismatch,
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,8 +161,9 @@ func TestDecisionProvider_ListLikedYou(t *testing.T) {
 			mockCache := &mockCacheRepo{}
 			tt.mockBehavior(mockRepo, mockCache)
 
-			provider := NewDecisionProvider(mockRepo, mockCache)
-			gotLikers, gotNextToken, err := provider.ListLikedYou(context.Background(), tt.recipientID, tt.encodedToken)
+			tokens := newTestTokenCodec(t)
+			provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, tokens)
+			gotLikers, gotNextToken, err := provider.ListLikedYou(context.Background(), tt.recipientID, tt.encodedToken, domain.ListLikersOptions{})
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
@@ -122,22 +171,26 @@ func TestDecisionProvider_ListLikedYou(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.wantLikers, gotLikers)
-				assert.Equal(t, tt.wantNextToken, gotNextToken)
+				gotNextCursor, err := tokens.Decode(gotNextToken, tt.recipientID, domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterAll}))
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantNextCursor, gotNextCursor)
 			}
 		})
 	}
 }
 
 func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
+	otherRecipientToken := newTestTokenCodec(t).Encode("user3", domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterPending}), domain.Cursor{Timestamp: 123456})
+
 	tests := []struct {
-		name          string
-		recipientID   string
-		encodedToken  string
-		setCache      bool
-		mockBehavior  func(*mockDecisionProviderRepo, *mockCacheRepo)
-		wantLikers    []domain.LikerInfo
-		wantNextToken string
-		wantErr       error
+		name           string
+		recipientID    domain.UserID
+		encodedToken   string
+		setCache       bool
+		mockBehavior   func(*mockDecisionProviderRepo, *mockCacheRepo)
+		wantLikers     []domain.LikerInfo
+		wantNextCursor *domain.Cursor
+		wantErr        error
 	}{
 		{
 			name:         "success - from cache",
@@ -145,14 +198,14 @@ func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
 			encodedToken: "",
 			setCache:     true,
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-					assert.True(t, excludeMutual)
-					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, uint64Ptr(123456), nil
+				mc.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					assert.Equal(t, domain.LikersFilterPending, query.Filter)
+					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, &domain.Cursor{Timestamp: 123456}, nil
 				}
 			},
-			wantLikers:    []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
-			wantNextToken: "eyJ0IjoxMjM0NTZ9",
-			wantErr:       nil,
+			wantLikers:     []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
+			wantNextCursor: &domain.Cursor{Timestamp: 123456},
+			wantErr:        nil,
 		},
 		{
 			name:         "success - from db",
@@ -160,20 +213,20 @@ func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
 			encodedToken: "",
 			setCache:     false,
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
+				mc.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
 					return nil, nil, errors.New("cache miss")
 				}
-				mr.getLikers = func(ctx context.Context, recipientID string, paginationToken *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-					assert.True(t, excludeMutual)
-					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, uint64Ptr(123456), nil
+				mr.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					assert.Equal(t, domain.LikersFilterPending, query.Filter)
+					return []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}}, &domain.Cursor{Timestamp: 123456}, nil
 				}
-				mc.setLikers = func(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error {
+				mc.setLikers = func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
 					return nil
 				}
 			},
-			wantLikers:    []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
-			wantNextToken: "eyJ0IjoxMjM0NTZ9",
-			wantErr:       nil,
+			wantLikers:     []domain.LikerInfo{{ActorID: "user2", Timestamp: 123456}},
+			wantNextCursor: &domain.Cursor{Timestamp: 123456},
+			wantErr:        nil,
 		},
 		{
 			name:         "error - empty recipient ID",
@@ -191,6 +244,14 @@ func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
 			wantLikers:   nil,
 			wantErr:      errors.New("invalid pagination token"),
 		},
+		{
+			name:         "error - token issued for another recipient",
+			recipientID:  "user1",
+			encodedToken: otherRecipientToken,
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {},
+			wantLikers:   nil,
+			wantErr:      domain.ErrTokenRecipientMismatch,
+		},
 	}
 
 	for _, tt := range tests {
@@ -199,8 +260,9 @@ func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
 			mockCache := &mockCacheRepo{}
 			tt.mockBehavior(mockRepo, mockCache)
 
-			provider := NewDecisionProvider(mockRepo, mockCache)
-			gotLikers, gotNextToken, err := provider.ListNewLikedYou(context.Background(), tt.recipientID, tt.encodedToken)
+			tokens := newTestTokenCodec(t)
+			provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, tokens)
+			gotLikers, gotNextToken, err := provider.ListNewLikedYou(context.Background(), tt.recipientID, tt.encodedToken, domain.ListLikersOptions{})
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
@@ -208,67 +270,350 @@ func TestDecisionProvider_ListNewLikedYou(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.wantLikers, gotLikers)
-				assert.Equal(t, tt.wantNextToken, gotNextToken)
+				gotNextCursor, err := tokens.Decode(gotNextToken, tt.recipientID, domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterPending}))
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantNextCursor, gotNextCursor)
+			}
+		})
+	}
+}
+
+func TestDecisionProvider_Filters(t *testing.T) {
+	tests := []struct {
+		name       string
+		newLikedMe bool
+		filter     domain.LikersFilter
+		wantFilter domain.LikersFilter
+		wantErr    error
+	}{
+		{name: "ListLikedYou - defaults to all", filter: "", wantFilter: domain.LikersFilterAll},
+		{name: "ListLikedYou - matched", filter: domain.LikersFilterMatched, wantFilter: domain.LikersFilterMatched},
+		{name: "ListLikedYou - rejected", filter: domain.LikersFilterRejected, wantFilter: domain.LikersFilterRejected},
+		{name: "ListLikedYou - unknown filter", filter: "unknown", wantErr: domain.ErrInvalidFilter},
+		{name: "ListNewLikedYou - defaults to pending", newLikedMe: true, filter: "", wantFilter: domain.LikersFilterPending},
+		{name: "ListNewLikedYou - pending", newLikedMe: true, filter: domain.LikersFilterPending, wantFilter: domain.LikersFilterPending},
+		{name: "ListNewLikedYou - other filter", newLikedMe: true, filter: domain.LikersFilterMatched, wantErr: domain.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.LikersFilter
+			mockRepo := &mockDecisionProviderRepo{
+				getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					gotFilter = query.Filter
+					return nil, nil, nil
+				},
+			}
+			mockCache := &mockCacheRepo{
+				getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					return nil, nil, errors.New("cache miss")
+				},
+				setLikers: func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+					return nil
+				},
+			}
+
+			provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, newTestTokenCodec(t))
+			opts := domain.ListLikersOptions{Filter: tt.filter}
+
+			var err error
+			if tt.newLikedMe {
+				_, _, err = provider.ListNewLikedYou(context.Background(), "user1", "", opts)
+			} else {
+				_, _, err = provider.ListLikedYou(context.Background(), "user1", "", opts)
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFilter, gotFilter)
 			}
 		})
 	}
 }
 
+func TestDecisionProvider_ListLikedYou_UnseenOnly(t *testing.T) {
+	watermark := domain.SeenWatermark{Timestamp: 100, ActorID: "user3"}
+	mockRepo := &mockDecisionProviderRepo{}
+	mockCache := &mockCacheRepo{
+		getSeenWatermark: func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+			return domain.SeenWatermark{}, errors.New("cache miss")
+		},
+		setSeenWatermark: func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+			assert.Equal(t, watermark, seenUpTo)
+			return nil
+		},
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			return nil, nil, errors.New("cache miss")
+		},
+		setLikers: func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+			assert.Equal(t, &watermark, query.SeenUpTo)
+			return nil
+		},
+	}
+	mockRepo.getSeenWatermark = func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+		return watermark, nil
+	}
+	mockRepo.getLikers = func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+		assert.Equal(t, &watermark, query.SeenUpTo)
+		return []domain.LikerInfo{{ActorID: "user2", Timestamp: 150}}, &domain.Cursor{Timestamp: 150}, nil
+	}
+
+	tokens := newTestTokenCodec(t)
+	provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, tokens)
+	opts := domain.ListLikersOptions{UnseenOnly: true}
+
+	gotLikers, gotNextToken, err := provider.ListLikedYou(context.Background(), "user1", "", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LikerInfo{{ActorID: "user2", Timestamp: 150}}, gotLikers)
+
+	_, err = tokens.Decode(gotNextToken, "user1", domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterAll}))
+	assert.ErrorIs(t, err, domain.ErrTokenKindMismatch)
+
+	gotNextCursor, err := tokens.Decode(gotNextToken, "user1", domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterAll, UnseenOnly: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Cursor{Timestamp: 150}, gotNextCursor)
+}
+
+func TestDecisionProvider_ListLikedYou_SuperLikesFirst(t *testing.T) {
+	tokens := newTestTokenCodec(t)
+	opts := domain.ListLikersOptions{SuperLikesFirst: true}
+	kind := domain.LikersTokenKind(domain.ListLikersOptions{Filter: domain.LikersFilterAll, SuperLikesFirst: true})
+	token := tokens.Encode("user1", kind, domain.Cursor{Timestamp: 200, Decision: domain.DecisionSuperLike})
+
+	mockRepo := &mockDecisionProviderRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			assert.True(t, query.SuperLikesFirst)
+			assert.Equal(t, &domain.Cursor{Timestamp: 200, Decision: domain.DecisionSuperLike}, query.Cursor)
+			return []domain.LikerInfo{{ActorID: "user2", Timestamp: 100, Decision: domain.DecisionLike}},
+				&domain.Cursor{Timestamp: 100, Decision: domain.DecisionLike}, nil
+		},
+	}
+	mockCache := &mockCacheRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			return nil, nil, errors.New("cache miss")
+		},
+		setLikers: func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+			return nil
+		},
+	}
+
+	provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, tokens)
+	_, gotNextToken, err := provider.ListLikedYou(context.Background(), "user1", token, opts)
+	assert.NoError(t, err)
+
+	gotNextCursor, err := tokens.Decode(gotNextToken, "user1", kind)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Cursor{Timestamp: 100, Decision: domain.DecisionLike}, gotNextCursor)
+}
+
+func TestDecisionProvider_ListLikedYou_CoalescesCacheMisses(t *testing.T) {
+	const callers = 10
+
+	var repoCalls, cacheWrites atomic.Int32
+	release := make(chan struct{})
+	mockRepo := &mockDecisionProviderRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			repoCalls.Add(1)
+			<-release
+			return []domain.LikerInfo{{ActorID: "user2", Timestamp: 100}}, nil, nil
+		},
+	}
+	mockCache := &mockCacheRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			return nil, nil, errors.New("cache miss")
+		},
+		setLikers: func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+			cacheWrites.Add(1)
+			assert.GreaterOrEqual(t, computeTime, 50*time.Millisecond)
+			return nil
+		},
+	}
+
+	provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, newTestTokenCodec(t))
+
+	var wg sync.WaitGroup
+	results := make([][]domain.LikerInfo, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, errs[i] = provider.ListLikedYou(context.Background(), "user1", "", domain.ListLikersOptions{})
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), repoCalls.Load())
+	assert.Equal(t, int32(1), cacheWrites.Load())
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, []domain.LikerInfo{{ActorID: "user2", Timestamp: 100}}, results[i])
+	}
+}
+
+func TestDecisionProvider_ListLikedYou_CoalescedCallerCancelled(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo := &mockDecisionProviderRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			close(started)
+			<-release
+			assert.NoError(t, ctx.Err())
+			return []domain.LikerInfo{{ActorID: "user2", Timestamp: 100}}, nil, nil
+		},
+	}
+	mockCache := &mockCacheRepo{
+		getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+			return nil, nil, errors.New("cache miss")
+		},
+		setLikers: func(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+			return nil
+		},
+	}
+
+	provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, newTestTokenCodec(t))
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := provider.ListLikedYou(leaderCtx, "user1", "", domain.ListLikersOptions{})
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan []domain.LikerInfo, 1)
+	go func() {
+		likers, _, err := provider.ListLikedYou(context.Background(), "user1", "", domain.ListLikersOptions{})
+		assert.NoError(t, err)
+		followerResult <- likers
+	}()
+
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.Equal(t, []domain.LikerInfo{{ActorID: "user2", Timestamp: 100}}, <-followerResult)
+}
+
+func TestFlightKeys(t *testing.T) {
+	seen, sameSeen := domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}, domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}
+	otherSeen := domain.SeenWatermark{Timestamp: 100, ActorID: "other"}
+	queries := []domain.LikersQuery{
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll},
+		{RecipientID: "recipient", Filter: domain.LikersFilterPending},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &seen},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &otherSeen},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: &domain.Cursor{Timestamp: 50}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: &domain.Cursor{Timestamp: 50, ActorID: "liker"}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true, Cursor: &domain.Cursor{Timestamp: 50, Decision: domain.DecisionSuperLike}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, IncludeExpired: true},
+		{RecipientID: "other", Filter: domain.LikersFilterAll},
+	}
+	counts := []domain.LikersCountQuery{
+		{RecipientID: "recipient"},
+		{RecipientID: "recipient", SeenUpTo: &seen},
+		{RecipientID: "recipient", SeenUpTo: &otherSeen},
+		{RecipientID: "recipient", IncludeExpired: true},
+		{RecipientID: "other"},
+	}
+
+	keys := make(map[string]bool)
+	for i, query := range queries {
+		key := likersFlightKey(query)
+		assert.False(t, keys[key], "query %d shares key %s", i, key)
+		keys[key] = true
+	}
+	for i, query := range counts {
+		key := countFlightKey(query)
+		assert.False(t, keys[key], "count query %d shares key %s", i, key)
+		keys[key] = true
+	}
+
+	assert.Equal(t, likersFlightKey(queries[2]), likersFlightKey(domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &sameSeen}),
+		"equal watermarks behind different pointers share a key")
+}
+
 func TestDecisionProvider_CountLikedYou(t *testing.T) {
 	tests := []struct {
 		name         string
-		recipientID  string
+		recipientID  domain.UserID
 		mockBehavior func(*mockDecisionProviderRepo, *mockCacheRepo)
-		wantCount    uint64
+		wantCount    domain.LikersCount
 		wantErr      error
 	}{
 		{
 			name:        "success - from cache",
 			recipientID: "user1",
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikersCount = func(ctx context.Context, recipientID string) (uint64, error) {
+				mc.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+					if query.SeenUpTo != nil {
+						return 2, nil
+					}
 					return 42, nil
 				}
+				mc.getSeenWatermark = func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+					return domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}, nil
+				}
 			},
-			wantCount: 42,
+			wantCount: domain.LikersCount{Total: 42, Unseen: 2},
 			wantErr:   nil,
 		},
 		{
 			name:        "success - from db",
 			recipientID: "user1",
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikersCount = func(ctx context.Context, recipientID string) (uint64, error) {
+				mc.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
 					return 0, errors.New("cache miss")
 				}
-				mr.getLikersCount = func(ctx context.Context, recipientID string) (uint64, error) {
+				mc.getSeenWatermark = func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+					return domain.SeenWatermark{}, errors.New("cache miss")
+				}
+				mr.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+					if query.SeenUpTo != nil {
+						assert.Equal(t, domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}, *query.SeenUpTo)
+						return 2, nil
+					}
 					return 42, nil
 				}
-				mc.setLikersCount = func(ctx context.Context, recipientID string, count uint64) error {
+				mr.getSeenWatermark = func(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+					return domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}, nil
+				}
+				mc.setLikersCount = func(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+					return nil
+				}
+				mc.setSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
 					return nil
 				}
 			},
-			wantCount: 42,
+			wantCount: domain.LikersCount{Total: 42, Unseen: 2},
 			wantErr:   nil,
 		},
 		{
 			name:         "error - empty recipient ID",
 			recipientID:  "",
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {},
-			wantCount:    0,
+			wantCount:    domain.LikersCount{},
 			wantErr:      domain.ErrInvalidInput,
 		},
 		{
 			name:        "error - db error",
 			recipientID: "user1",
 			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
-				mc.getLikersCount = func(ctx context.Context, recipientID string) (uint64, error) {
+				mc.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
 					return 0, errors.New("cache miss")
 				}
-				mr.getLikersCount = func(ctx context.Context, recipientID string) (uint64, error) {
+				mr.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
 					return 0, errors.New("db error")
 				}
 			},
-			wantCount: 0,
+			wantCount: domain.LikersCount{},
 			wantErr:   errors.New("failed to count likers: db error"),
 		},
 	}
@@ -279,8 +624,8 @@ func TestDecisionProvider_CountLikedYou(t *testing.T) {
 			mockCache := &mockCacheRepo{}
 			tt.mockBehavior(mockRepo, mockCache)
 
-			provider := NewDecisionProvider(mockRepo, mockCache)
-			gotCount, err := provider.CountLikedYou(context.Background(), tt.recipientID)
+			provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, newTestTokenCodec(t))
+			gotCount, err := provider.CountLikedYou(context.Background(), tt.recipientID, domain.CountLikersOptions{})
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
@@ -293,6 +638,172 @@ func TestDecisionProvider_CountLikedYou(t *testing.T) {
 	}
 }
 
-func uint64Ptr(v uint64) *uint64 {
-	return &v
+func TestDecisionProvider_MarkLikesSeen(t *testing.T) {
+	seen := domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}
+
+	tests := []struct {
+		name         string
+		recipientID  domain.UserID
+		upTo         domain.SeenWatermark
+		mockBehavior func(*mockDecisionProviderRepo, *mockCacheRepo)
+		wantSeenUpTo domain.SeenWatermark
+		wantErr      error
+	}{
+		{
+			name:        "success - watermark moved forward",
+			recipientID: "user1",
+			upTo:        seen,
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
+				mr.setSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+					return seenUpTo, nil
+				}
+				mc.updateSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+					assert.Equal(t, seen, seenUpTo)
+					return nil
+				}
+			},
+			wantSeenUpTo: seen,
+		},
+		{
+			name:        "success - watermark kept when older cursor is given",
+			recipientID: "user1",
+			upTo:        domain.SeenWatermark{Timestamp: 100, ActorID: "user1"},
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
+				mr.setSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+					return seen, nil
+				}
+				mc.updateSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+					assert.Equal(t, seen, seenUpTo)
+					return nil
+				}
+			},
+			wantSeenUpTo: seen,
+		},
+		{
+			name:        "success - cursor in the future is clamped to the start of the current second",
+			recipientID: "user1",
+			upTo:        domain.SeenWatermark{Timestamp: math.MaxUint32, ActorID: "user2"},
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
+				mr.setSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+					assert.LessOrEqual(t, seenUpTo.Timestamp, uint64(time.Now().Unix()))
+					assert.Empty(t, seenUpTo.ActorID)
+					return seen, nil
+				}
+				mc.updateSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+					return nil
+				}
+			},
+			wantSeenUpTo: seen,
+		},
+		{
+			name:         "error - missing cursor",
+			recipientID:  "user1",
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {},
+			wantErr:      domain.ErrInvalidInput,
+		},
+		{
+			name:         "error - missing actor",
+			recipientID:  "user1",
+			upTo:         domain.SeenWatermark{Timestamp: 100},
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {},
+			wantErr:      domain.ErrInvalidInput,
+		},
+		{
+			name:        "error - db error",
+			recipientID: "user1",
+			upTo:        seen,
+			mockBehavior: func(mr *mockDecisionProviderRepo, mc *mockCacheRepo) {
+				mr.setSeenWatermark = func(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+					return domain.SeenWatermark{}, errors.New("db error")
+				}
+			},
+			wantErr: errors.New("failed to mark likes seen: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockDecisionProviderRepo{}
+			mockCache := &mockCacheRepo{}
+			tt.mockBehavior(mockRepo, mockCache)
+
+			provider := NewDecisionProvider(mockRepo, mockCache, &mockProfileSource{}, newTestTokenCodec(t))
+			gotSeenUpTo, err := provider.MarkLikesSeen(context.Background(), tt.recipientID, tt.upTo)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantSeenUpTo, gotSeenUpTo)
+			}
+		})
+	}
+}
+
+func newTestTokenCodec(t *testing.T) *domain.TokenCodec {
+	codec, err := domain.NewTokenCodec([]domain.SigningKey{{ID: "test", Secret: []byte("secret")}}, time.Hour)
+	if err != nil {
+		t.Fatalf("creating token codec: %v", err)
+	}
+	return codec
+}
+
+func TestDecisionProvider_ListLikedYou_IncludeProfile(t *testing.T) {
+	cached := []domain.LikerInfo{{ActorID: "user2", Timestamp: 200}, {ActorID: "user3", Timestamp: 100}}
+	ann := domain.Profile{UserID: "user2", Name: "Ann"}
+
+	tests := []struct {
+		name        string
+		opts        domain.ListLikersOptions
+		getProfiles func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error)
+		wantLikers  []domain.LikerInfo
+		wantErr     error
+	}{
+		{
+			name: "profiles attached to likers that have one",
+			opts: domain.ListLikersOptions{IncludeProfile: true},
+			getProfiles: func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+				assert.Equal(t, []domain.UserID{"user2", "user3"}, userIDs)
+				return map[domain.UserID]domain.Profile{"user2": ann}, nil
+			},
+			wantLikers: []domain.LikerInfo{{ActorID: "user2", Timestamp: 200, Profile: &ann}, {ActorID: "user3", Timestamp: 100}},
+		},
+		{
+			name:       "profiles not requested",
+			opts:       domain.ListLikersOptions{},
+			wantLikers: cached,
+		},
+		{
+			name: "profile source error",
+			opts: domain.ListLikersOptions{IncludeProfile: true},
+			getProfiles: func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+				return nil, errors.New("db error")
+			},
+			wantErr: errors.New("failed to load profiles: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := slices.Clone(cached)
+			mockCache := &mockCacheRepo{
+				getLikers: func(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+					return page, nil, nil
+				},
+			}
+
+			provider := NewDecisionProvider(&mockDecisionProviderRepo{}, mockCache, &mockProfileSource{getProfiles: tt.getProfiles}, newTestTokenCodec(t))
+			gotLikers, _, err := provider.ListLikedYou(context.Background(), "user1", "", tt.opts)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantLikers, gotLikers)
+			}
+			// The cached page is shared and must be left without profiles.
+			assert.Equal(t, cached, page)
+		})
+	}
 }
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type expiredLikesRepository interface {
+	SweepExpiredLikes(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error)
+}
+
+type sweeperLogger interface {
+	Info(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+type LikeSweeperConfig struct {
+	LikeLifetime time.Duration
+	Interval     time.Duration
+	BatchSize    uint64
+	// BatchPause is the delay between consecutive batches of a single sweep.
+	BatchPause time.Duration
+	Archive    bool
+}
+
+type LikeSweeper struct {
+	repo   expiredLikesRepository
+	config LikeSweeperConfig
+	logger sweeperLogger
+}
+
+// NewLikeSweeper rejects a zero interval, which would make the ticker panic,
+// and a zero batch size, which would never sweep anything.
+func NewLikeSweeper(repo expiredLikesRepository, config LikeSweeperConfig, logger sweeperLogger) (*LikeSweeper, error) {
+	if config.Interval <= 0 {
+		return nil, errors.New("sweep interval must be positive")
+	}
+	if config.BatchSize == 0 {
+		return nil, errors.New("sweep batch size must be positive")
+	}
+
+	return &LikeSweeper{
+		repo:   repo,
+		config: config,
+		logger: logger,
+	}, nil
+}
+
+func (s *LikeSweeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		swept, err := s.Sweep(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("like sweep failed", "error", err, "swept", swept)
+		} else if swept > 0 {
+			s.logger.Info("like sweep finished", "swept", swept)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep removes every like that expired before now in batches, pausing
+// between batches so the sweep doesn't compete with live traffic.
+func (s *LikeSweeper) Sweep(ctx context.Context) (int64, error) {
+	before := uint64(time.Now().Add(-s.config.LikeLifetime).Unix())
+
+	var total int64
+	for {
+		swept, err := s.repo.SweepExpiredLikes(ctx, before, s.config.BatchSize, s.config.Archive)
+		if err != nil {
+			return total, fmt.Errorf("failed to sweep expired likes: %w", err)
+		}
+
+		total += swept
+		if uint64(swept) < s.config.BatchSize {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(s.config.BatchPause):
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type mockExpiredLikesRepo struct {
+	sweepExpiredLikes func(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error)
+}
+
+func (m *mockExpiredLikesRepo) SweepExpiredLikes(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error) {
+	return m.sweepExpiredLikes(ctx, before, batchSize, archive)
+}
+
+type mockSweeperLogger struct{}
+
+func (m *mockSweeperLogger) Info(msg string, args ...any)  {}
+func (m *mockSweeperLogger) Error(msg string, args ...any) {}
+
+func TestLikeSweeper_Sweep(t *testing.T) {
+	tests := []struct {
+		name        string
+		batches     []int64
+		batchErr    error
+		wantSwept   int64
+		wantBatches int
+		wantErr     error
+	}{
+		{
+			name:        "success - nothing expired",
+			batches:     []int64{0},
+			wantSwept:   0,
+			wantBatches: 1,
+		},
+		{
+			name:        "success - stops after partial batch",
+			batches:     []int64{10, 10, 3},
+			wantSwept:   23,
+			wantBatches: 3,
+		},
+		{
+			name:        "error - repository error",
+			batches:     []int64{10},
+			batchErr:    errors.New("db error"),
+			wantSwept:   10,
+			wantBatches: 2,
+			wantErr:     errors.New("failed to sweep expired likes: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			lifetime := 30 * 24 * time.Hour
+			repo := &mockExpiredLikesRepo{
+				sweepExpiredLikes: func(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error) {
+					assert.InDelta(t, time.Now().Add(-lifetime).Unix(), before, 2)
+					assert.Equal(t, uint64(10), batchSize)
+					assert.True(t, archive)
+
+					calls++
+					if calls > len(tt.batches) {
+						return 0, tt.batchErr
+					}
+					return tt.batches[calls-1], nil
+				},
+			}
+
+			sweeper, err := NewLikeSweeper(repo, LikeSweeperConfig{
+				LikeLifetime: lifetime,
+				Interval:     time.Hour,
+				BatchSize:    10,
+				BatchPause:   time.Millisecond,
+				Archive:      true,
+			}, &mockSweeperLogger{})
+			require.NoError(t, err)
+
+			gotSwept, err := sweeper.Sweep(context.Background())
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSwept, gotSwept)
+			assert.Equal(t, tt.wantBatches, calls)
+		})
+	}
+}
+
+func TestNewLikeSweeper_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  LikeSweeperConfig
+		wantErr string
+	}{
+		{
+			name:    "zero interval",
+			config:  LikeSweeperConfig{LikeLifetime: time.Hour, BatchSize: 10},
+			wantErr: "sweep interval must be positive",
+		},
+		{
+			name:    "negative interval",
+			config:  LikeSweeperConfig{LikeLifetime: time.Hour, Interval: -time.Second, BatchSize: 10},
+			wantErr: "sweep interval must be positive",
+		},
+		{
+			name:    "zero batch size",
+			config:  LikeSweeperConfig{LikeLifetime: time.Hour, Interval: time.Hour},
+			wantErr: "sweep batch size must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLikeSweeper(&mockExpiredLikesRepo{}, tt.config, &mockSweeperLogger{})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+)
+
+type likerListingsRepository interface {
+	StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error
+}
+
+type likerListingsCache interface {
+	PurgeUser(ctx context.Context, userID domain.UserID) error
+}
+
+// likerListings drops the cached likers of everyone a user liked, so a change
+// to whether the user is hidden shows in those listings straight away rather
+// than once they expire.
+type likerListings struct {
+	repo  likerListingsRepository
+	cache likerListingsCache
+}
+
+// purgeLikedBy purges the cached data of every recipient the user liked and
+// returns how many were purged. The recipients are collected before purging
+// so the decisions aren't held open while the cache is written to.
+func (l likerListings) purgeLikedBy(ctx context.Context, userID domain.UserID) (uint64, error) {
+	affected := make(map[domain.UserID]struct{})
+	err := l.repo.StreamUserDecisions(ctx, userID, func(record domain.DecisionRecord) error {
+		if record.Archived {
+			return nil
+		}
+
+		if record.ActorID == userID && record.Decision.Liked() {
+			affected[record.RecipientID] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read decisions: %w", err)
+	}
+
+	var purged uint64
+	for recipientID := range affected {
+		if err := l.cache.PurgeUser(ctx, recipientID); err != nil {
+			return purged, fmt.Errorf("failed to purge cache: %w", err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
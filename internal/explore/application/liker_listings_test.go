@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
+	"sort"
+	"testing"
+)
+
+// mockLikerListings is both the repository and the cache of likerListings.
+type mockLikerListings struct {
+	records   []domain.DecisionRecord
+	streamErr error
+	purgeErr  error
+	purged    []domain.UserID
+}
+
+func (m *mockLikerListings) StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+	if m.streamErr != nil {
+		return m.streamErr
+	}
+	for _, record := range m.records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockLikerListings) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	m.purged = append(m.purged, userID)
+	return m.purgeErr
+}
+
+func TestLikerListings_PurgeLikedBy(t *testing.T) {
+	records := []domain.DecisionRecord{
+		{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+		{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionSuperLike},
+		{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+		{ActorID: "user1", RecipientID: "user4", Decision: domain.DecisionPass},
+		{ActorID: "user1", RecipientID: "user5", Decision: domain.DecisionLike, Archived: true},
+		{ActorID: "user6", RecipientID: "user1", Decision: domain.DecisionLike},
+	}
+
+	tests := []struct {
+		name       string
+		streamErr  error
+		purgeErr   error
+		wantPurged []domain.UserID
+		wantCount  uint64
+		wantErr    string
+	}{
+		{
+			name:       "purges recipients of live likes once",
+			wantPurged: []domain.UserID{"user2", "user3"},
+			wantCount:  2,
+		},
+		{
+			name:      "stream error",
+			streamErr: errors.New("db error"),
+			wantErr:   "failed to read decisions: db error",
+		},
+		{
+			name:     "purge error",
+			purgeErr: errors.New("redis down"),
+			wantErr:  "failed to purge cache: redis down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockLikerListings{records: records, streamErr: tt.streamErr, purgeErr: tt.purgeErr}
+
+			count, err := likerListings{repo: mock, cache: mock}.purgeLikedBy(context.Background(), "user1")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.LessOrEqual(t, len(mock.purged), 1, "stops at the first failed purge")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+			sort.Slice(mock.purged, func(i, j int) bool { return mock.purged[i] < mock.purged[j] })
+			assert.Equal(t, tt.wantPurged, mock.purged)
+		})
+	}
+}
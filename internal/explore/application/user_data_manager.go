@@ -0,0 +1,215 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+type userDataRepository interface {
+	EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error)
+	EraseUserMetadata(ctx context.Context, userID domain.UserID) error
+	StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+}
+
+type userDataCache interface {
+	PurgeUser(ctx context.Context, userID domain.UserID) error
+	SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+}
+
+// userDataCounters are the abuse counters, which remember a flagged user
+// apart from the repository.
+type userDataCounters interface {
+	ResetUser(ctx context.Context, userID domain.UserID) error
+}
+
+type auditLogger interface {
+	Info(msg string, args ...any)
+}
+
+type exportedDecision struct {
+	ActorUserID     domain.UserID `json:"actor_user_id"`
+	RecipientUserID domain.UserID `json:"recipient_user_id"`
+	Decision        string        `json:"decision"`
+	UnixTimestamp   uint64        `json:"unix_timestamp"`
+	Archived        bool          `json:"archived"`
+}
+
+type UserDataManager struct {
+	repo           userDataRepository
+	cache          userDataCache
+	counters       userDataCounters
+	audit          auditLogger
+	eraseBatchSize uint64
+}
+
+func NewUserDataManager(repo userDataRepository, cache userDataCache, counters userDataCounters, audit auditLogger, eraseBatchSize uint64) *UserDataManager {
+	return &UserDataManager{
+		repo:           repo,
+		cache:          cache,
+		counters:       counters,
+		audit:          audit,
+		eraseBatchSize: eraseBatchSize,
+	}
+}
+
+// EraseUser marks the user deleted, deletes every decision the user made or
+// received in bounded batches, then drops the user's cached data and abuse
+// counters and recounts the likers of everyone the user had liked.
+func (m *UserDataManager) EraseUser(ctx context.Context, userID domain.UserID, requestedBy string, reason string) (domain.ErasureResult, error) {
+	if userID == "" || requestedBy == "" {
+		return domain.ErasureResult{}, domain.ErrInvalidInput
+	}
+
+	m.audit.Info("user erasure started", "user_id", userID, "requested_by", requestedBy, "reason", reason)
+
+	result, err := m.eraseUser(ctx, userID)
+	if err != nil {
+		m.audit.Info("user erasure failed", "user_id", userID, "requested_by", requestedBy,
+			"decisions_deleted", result.DecisionsDeleted, "error", err.Error())
+		return result, err
+	}
+
+	m.audit.Info("user erasure finished", "user_id", userID, "requested_by", requestedBy,
+		"decisions_deleted", result.DecisionsDeleted, "counters_recounted", result.CountersRecounted)
+
+	return result, nil
+}
+
+// PreviewErasure returns what EraseUser would report for the user without
+// changing anything.
+func (m *UserDataManager) PreviewErasure(ctx context.Context, userID domain.UserID) (domain.ErasureResult, error) {
+	if userID == "" {
+		return domain.ErasureResult{}, domain.ErrInvalidInput
+	}
+
+	var result domain.ErasureResult
+	affected := make(map[domain.UserID]struct{})
+	err := m.repo.StreamUserDecisions(ctx, userID, func(record domain.DecisionRecord) error {
+		if record.Archived {
+			return nil
+		}
+
+		result.DecisionsDeleted++
+		if record.ActorID == userID && record.Decision.Liked() {
+			affected[record.RecipientID] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.ErasureResult{}, fmt.Errorf("failed to read decisions: %w", err)
+	}
+
+	result.CountersRecounted = uint64(len(affected))
+
+	return result, nil
+}
+
+func (m *UserDataManager) eraseUser(ctx context.Context, userID domain.UserID) (domain.ErasureResult, error) {
+	var result domain.ErasureResult
+	affected := make(map[domain.UserID]struct{})
+
+	// Marking the user deleted first makes PutDecision reject new decisions
+	// involving them while their decisions are erased.
+	if err := m.repo.EraseUserMetadata(ctx, userID); err != nil {
+		return result, fmt.Errorf("failed to erase user metadata: %w", err)
+	}
+
+	if err := m.eraseDecisions(ctx, userID, &result, affected); err != nil {
+		return result, err
+	}
+
+	// A decision checked just before the user was marked deleted may have
+	// been saved behind the batches, and a like may have been archived while
+	// they ran, so the metadata and decisions are erased once more.
+	if err := m.repo.EraseUserMetadata(ctx, userID); err != nil {
+		return result, fmt.Errorf("failed to erase user metadata: %w", err)
+	}
+
+	if err := m.eraseDecisions(ctx, userID, &result, affected); err != nil {
+		return result, err
+	}
+
+	if err := m.cache.PurgeUser(ctx, userID); err != nil {
+		return result, fmt.Errorf("failed to purge cache: %w", err)
+	}
+
+	if err := m.counters.ResetUser(ctx, userID); err != nil {
+		return result, fmt.Errorf("failed to reset abuse counters: %w", err)
+	}
+
+	for recipientID := range affected {
+		if err := m.cache.PurgeUser(ctx, recipientID); err != nil {
+			return result, fmt.Errorf("failed to purge cache: %w", err)
+		}
+
+		query := domain.LikersCountQuery{RecipientID: recipientID}
+		start := time.Now()
+		count, err := m.repo.GetLikersCount(ctx, query)
+		if err != nil {
+			return result, fmt.Errorf("failed to recount likers: %w", err)
+		}
+
+		m.cache.SetLikersCount(ctx, query, count, time.Since(start))
+		result.CountersRecounted++
+	}
+
+	return result, nil
+}
+
+// eraseDecisions deletes the user's decisions in batches until none are
+// left, adding the recipients the user liked to affected.
+func (m *UserDataManager) eraseDecisions(ctx context.Context, userID domain.UserID, result *domain.ErasureResult, affected map[domain.UserID]struct{}) error {
+	for {
+		deleted, recipients, err := m.repo.EraseUserDecisions(ctx, userID, m.eraseBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to erase decisions: %w", err)
+		}
+
+		result.DecisionsDeleted += uint64(deleted)
+		for _, recipientID := range recipients {
+			affected[recipientID] = struct{}{}
+		}
+
+		if deleted == 0 {
+			return nil
+		}
+	}
+}
+
+// ExportUserData writes every decision the user made or received to w as
+// JSON lines, one decision per Write call.
+func (m *UserDataManager) ExportUserData(ctx context.Context, userID domain.UserID, requestedBy string, w io.Writer) error {
+	if userID == "" || requestedBy == "" {
+		return domain.ErrInvalidInput
+	}
+
+	m.audit.Info("user data export started", "user_id", userID, "requested_by", requestedBy)
+
+	var exported uint64
+	encoder := json.NewEncoder(w)
+	err := m.repo.StreamUserDecisions(ctx, userID, func(record domain.DecisionRecord) error {
+		exported++
+		return encoder.Encode(exportedDecision{
+			ActorUserID:     record.ActorID,
+			RecipientUserID: record.RecipientID,
+			Decision:        record.Decision.String(),
+			UnixTimestamp:   record.Timestamp,
+			Archived:        record.Archived,
+		})
+	})
+	if err != nil {
+		m.audit.Info("user data export failed", "user_id", userID, "requested_by", requestedBy,
+			"decisions_exported", exported, "error", err.Error())
+		return fmt.Errorf("failed to export user data: %w", err)
+	}
+
+	m.audit.Info("user data export finished", "user_id", userID, "requested_by", requestedBy,
+		"decisions_exported", exported)
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+type mockUserDataRepo struct {
+	eraseUserDecisions  func(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error)
+	eraseUserMetadata   func(ctx context.Context, userID domain.UserID) error
+	streamUserDecisions func(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error
+	getLikersCount      func(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+}
+
+func (m *mockUserDataRepo) EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+	return m.eraseUserDecisions(ctx, userID, batchSize)
+}
+
+func (m *mockUserDataRepo) EraseUserMetadata(ctx context.Context, userID domain.UserID) error {
+	return m.eraseUserMetadata(ctx, userID)
+}
+
+func (m *mockUserDataRepo) StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+	return m.streamUserDecisions(ctx, userID, fn)
+}
+
+func (m *mockUserDataRepo) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	return m.getLikersCount(ctx, query)
+}
+
+type mockUserDataCache struct {
+	purgeUser      func(ctx context.Context, userID domain.UserID) error
+	setLikersCount func(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+}
+
+func (m *mockUserDataCache) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	return m.purgeUser(ctx, userID)
+}
+
+func (m *mockUserDataCache) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	return m.setLikersCount(ctx, query, count, computeTime)
+}
+
+type mockAuditLogger struct {
+	messages []string
+}
+
+func (m *mockAuditLogger) Info(msg string, args ...any) {
+	m.messages = append(m.messages, msg)
+}
+
+func TestUserDataManager_EraseUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       domain.UserID
+		requestedBy  string
+		mockBehavior func(*mockUserDataRepo, *mockUserDataCache)
+		wantResult   domain.ErasureResult
+		wantPurged   []domain.UserID
+		wantAudit    []string
+		wantErr      error
+	}{
+		{
+			name:        "success - erased in batches",
+			userID:      "user1",
+			requestedBy: "oncall",
+			mockBehavior: func(mr *mockUserDataRepo, mc *mockUserDataCache) {
+				batches := []struct {
+					deleted    int64
+					recipients []domain.UserID
+				}{
+					{deleted: 2, recipients: []domain.UserID{"user2", "user3"}},
+					{deleted: 1, recipients: []domain.UserID{"user2"}},
+					{deleted: 0},
+				}
+				mr.eraseUserDecisions = func(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+					assert.Equal(t, uint64(2), batchSize)
+					if len(batches) == 0 {
+						return 0, nil, nil
+					}
+					batch := batches[0]
+					batches = batches[1:]
+					return batch.deleted, batch.recipients, nil
+				}
+				mr.eraseUserMetadata = func(ctx context.Context, userID domain.UserID) error {
+					return nil
+				}
+				mr.getLikersCount = func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+					return 5, nil
+				}
+				mc.setLikersCount = func(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+					assert.Equal(t, uint64(5), count)
+					return nil
+				}
+			},
+			wantResult: domain.ErasureResult{DecisionsDeleted: 3, CountersRecounted: 2},
+			wantPurged: []domain.UserID{"user1", "user2", "user3"},
+			wantAudit:  []string{"user erasure started", "user erasure finished"},
+		},
+		{
+			name:         "error - missing requester",
+			userID:       "user1",
+			requestedBy:  "",
+			mockBehavior: func(mr *mockUserDataRepo, mc *mockUserDataCache) {},
+			wantErr:      domain.ErrInvalidInput,
+		},
+		{
+			name:        "error - repository error",
+			userID:      "user1",
+			requestedBy: "oncall",
+			mockBehavior: func(mr *mockUserDataRepo, mc *mockUserDataCache) {
+				mr.eraseUserMetadata = func(ctx context.Context, userID domain.UserID) error {
+					return nil
+				}
+				mr.eraseUserDecisions = func(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+					return 0, nil, errors.New("db error")
+				}
+			},
+			wantAudit: []string{"user erasure started", "user erasure failed"},
+			wantErr:   errors.New("failed to erase decisions: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var purged []domain.UserID
+			mockRepo := &mockUserDataRepo{}
+			mockCache := &mockUserDataCache{
+				purgeUser: func(ctx context.Context, userID domain.UserID) error {
+					purged = append(purged, userID)
+					return nil
+				},
+			}
+			counters := &mockAbuseCounters{}
+			audit := &mockAuditLogger{}
+			tt.mockBehavior(mockRepo, mockCache)
+
+			manager := NewUserDataManager(mockRepo, mockCache, counters, audit, 2)
+			gotResult, err := manager.EraseUser(context.Background(), tt.userID, tt.requestedBy, "account deleted")
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantResult, gotResult)
+				slices.Sort(purged)
+				assert.Equal(t, tt.wantPurged, purged)
+				assert.Equal(t, []domain.UserID{tt.userID}, counters.reset)
+			}
+			assert.Equal(t, tt.wantAudit, audit.messages)
+		})
+	}
+}
+
+// TestUserDataManager_EraseUser_ConcurrentDecision interleaves decisions with
+// an erasure: one arriving while the batches run is rejected, and one checked
+// just before the user was marked deleted but saved behind the batches is
+// still erased and its recipient recounted.
+func TestUserDataManager_EraseUser_ConcurrentDecision(t *testing.T) {
+	ctx := context.Background()
+
+	deleted := false
+	var stored []domain.DecisionRecord
+	users := &mockUserLookup{
+		getUsers: func(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+			found := make(map[domain.UserID]domain.User, len(userIDs))
+			for _, id := range userIDs {
+				status := domain.UserStatusActive
+				if id == "user1" && deleted {
+					status = domain.UserStatusDeleted
+				}
+				found[id] = domain.User{ID: id, Status: status}
+			}
+			return found, nil
+		},
+	}
+	creator := NewDecisionCreator(&mockDecisionCreatorRepo{
+		insertDecision: func(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+			stored = append(stored, domain.DecisionRecord{ActorID: actorID, RecipientID: recipientID, Decision: decision})
+			return false, 100, nil
+		},
+	}, users, nil, nil, DecisionCreatorConfig{SuperLikeDailyLimit: 3}, &mockErrorLogger{})
+
+	_, err := creator.SaveDecision(ctx, "user1", "user2", domain.DecisionLike)
+	require.NoError(t, err)
+
+	var batches int
+	repo := &mockUserDataRepo{
+		eraseUserMetadata: func(ctx context.Context, userID domain.UserID) error {
+			deleted = true
+			return nil
+		},
+		eraseUserDecisions: func(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+			batches++
+			if batches == 1 {
+				_, err := creator.SaveDecision(ctx, "user4", "user1", domain.DecisionLike)
+				assert.ErrorIs(t, err, domain.ErrUserNotFound, "decisions are rejected once erasure started")
+			}
+			if batches == 2 {
+				// Checked before the erasure started, saved after the batch
+				// that found nothing left.
+				defer func() {
+					stored = append(stored, domain.DecisionRecord{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionLike})
+				}()
+			}
+
+			var recipients []domain.UserID
+			for _, record := range stored {
+				if record.ActorID == userID && record.Decision.Liked() {
+					recipients = append(recipients, record.RecipientID)
+				}
+			}
+			erased := int64(len(stored))
+			stored = nil
+			return erased, recipients, nil
+		},
+		getLikersCount: func(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+			return 0, nil
+		},
+	}
+	cache := &mockUserDataCache{
+		purgeUser: func(ctx context.Context, userID domain.UserID) error { return nil },
+		setLikersCount: func(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+			return nil
+		},
+	}
+
+	result, err := NewUserDataManager(repo, cache, &mockAbuseCounters{}, &mockAuditLogger{}, 10).EraseUser(ctx, "user1", "oncall", "account deleted")
+
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+	assert.Equal(t, domain.ErasureResult{DecisionsDeleted: 2, CountersRecounted: 2}, result)
+	assert.Equal(t, 4, batches, "a final pass follows the batches")
+}
+
+func TestUserDataManager_ExportUserData(t *testing.T) {
+	mockRepo := &mockUserDataRepo{
+		streamUserDecisions: func(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+			if err := fn(domain.DecisionRecord{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionSuperLike, Timestamp: 100}); err != nil {
+				return err
+			}
+			return fn(domain.DecisionRecord{ActorID: "user3", RecipientID: "user1", Decision: domain.DecisionPass, Timestamp: 50, Archived: true})
+		},
+	}
+	audit := &mockAuditLogger{}
+
+	var buf bytes.Buffer
+	manager := NewUserDataManager(mockRepo, &mockUserDataCache{}, &mockAbuseCounters{}, audit, 100)
+	err := manager.ExportUserData(context.Background(), "user1", "oncall", &buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"actor_user_id":"user1","recipient_user_id":"user2","decision":"super_like","unix_timestamp":100,"archived":false}
+{"actor_user_id":"user3","recipient_user_id":"user1","decision":"pass","unix_timestamp":50,"archived":true}
+`, buf.String())
+	assert.Equal(t, []string{"user data export started", "user data export finished"}, audit.messages)
+}
+
+func TestUserDataManager_PreviewErasure(t *testing.T) {
+	mockRepo := &mockUserDataRepo{
+		streamUserDecisions: func(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+			for _, record := range []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+				{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionSuperLike},
+				{ActorID: "user1", RecipientID: "user4", Decision: domain.DecisionPass},
+				{ActorID: "user5", RecipientID: "user1", Decision: domain.DecisionLike},
+				{ActorID: "user1", RecipientID: "user6", Decision: domain.DecisionLike, Archived: true},
+			} {
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	audit := &mockAuditLogger{}
+
+	manager := NewUserDataManager(mockRepo, &mockUserDataCache{}, &mockAbuseCounters{}, audit, 100)
+	result, err := manager.PreviewErasure(context.Background(), "user1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ErasureResult{DecisionsDeleted: 4, CountersRecounted: 2}, result)
+	assert.Empty(t, audit.messages)
+}
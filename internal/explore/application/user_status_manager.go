@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+)
+
+type userStatusRepository interface {
+	GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error)
+	SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error
+}
+
+type UserStatusManager struct {
+	users    userStatusRepository
+	cache    likerListingsCache
+	listings likerListings
+	audit    auditLogger
+}
+
+func NewUserStatusManager(users userStatusRepository, decisions likerListingsRepository, cache likerListingsCache, audit auditLogger) *UserStatusManager {
+	return &UserStatusManager{
+		users:    users,
+		cache:    cache,
+		listings: likerListings{repo: decisions, cache: cache},
+		audit:    audit,
+	}
+}
+
+// GetStatus returns the user's status. Users without a row are active.
+func (m *UserStatusManager) GetStatus(ctx context.Context, userID domain.UserID) (domain.UserStatus, error) {
+	if userID == "" {
+		return "", domain.ErrInvalidInput
+	}
+
+	users, err := m.users.GetUsers(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	if user, ok := users[userID]; ok {
+		return user.Status, nil
+	}
+
+	return domain.UserStatusActive, nil
+}
+
+// SetStatus pauses, bans, deletes or reinstates the user. Decisions involving
+// a user who isn't active are rejected, and their likes are hidden from
+// listings. The user's own cached data, their profile included, and the
+// cached likers of everyone the user liked are purged, so the change shows
+// straight away.
+func (m *UserStatusManager) SetStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus, requestedBy string, reason string) error {
+	if userID == "" || requestedBy == "" {
+		return domain.ErrInvalidInput
+	}
+	if !status.Valid() {
+		return fmt.Errorf("%w: unknown user status %q", domain.ErrInvalidInput, status)
+	}
+
+	if err := m.users.SetUserStatus(ctx, userID, status); err != nil {
+		return fmt.Errorf("failed to set user status: %w", err)
+	}
+
+	m.audit.Info("user status changed", "user_id", userID, "status", status, "requested_by", requestedBy, "reason", reason)
+
+	if err := m.cache.PurgeUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to purge cache: %w", err)
+	}
+
+	if _, err := m.listings.purgeLikedBy(ctx, userID); err != nil {
+		return fmt.Errorf("failed to refresh liker listings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+type mockUserStatusRepo struct {
+	users map[domain.UserID]domain.User
+	err   error
+	set   []domain.UserStatus
+}
+
+func (m *mockUserStatusRepo) GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+	return m.users, m.err
+}
+
+func (m *mockUserStatusRepo) SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error {
+	m.set = append(m.set, status)
+	return m.err
+}
+
+func TestUserStatusManager_GetStatus(t *testing.T) {
+	repo := &mockUserStatusRepo{users: map[domain.UserID]domain.User{
+		"user1": {ID: "user1", Status: domain.UserStatusPaused},
+	}}
+	manager := NewUserStatusManager(repo, &mockLikerListings{}, &mockLikerListings{}, &mockAuditLogger{})
+
+	status, err := manager.GetStatus(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserStatusPaused, status)
+
+	status, err = manager.GetStatus(context.Background(), "user2")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserStatusActive, status)
+}
+
+func TestUserStatusManager_SetStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      domain.UserStatus
+		requestedBy string
+		repoErr     error
+		streamErr   error
+		wantSet     []domain.UserStatus
+		wantPurged  []domain.UserID
+		wantAudit   []string
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			status:      domain.UserStatusBanned,
+			requestedBy: "oncall",
+			wantSet:     []domain.UserStatus{domain.UserStatusBanned},
+			wantPurged:  []domain.UserID{"user1", "user2"},
+			wantAudit:   []string{"user status changed"},
+		},
+		{
+			name:        "reinstating refreshes listings too",
+			status:      domain.UserStatusActive,
+			requestedBy: "oncall",
+			wantSet:     []domain.UserStatus{domain.UserStatusActive},
+			wantPurged:  []domain.UserID{"user1", "user2"},
+			wantAudit:   []string{"user status changed"},
+		},
+		{
+			name:        "error - no requester",
+			status:      domain.UserStatusBanned,
+			requestedBy: "",
+			wantErr:     domain.ErrInvalidInput,
+		},
+		{
+			name:        "error - unknown status",
+			status:      "frozen",
+			requestedBy: "oncall",
+			wantErr:     domain.ErrInvalidInput,
+		},
+		{
+			name:        "error - repository error is not audited",
+			status:      domain.UserStatusPaused,
+			requestedBy: "oncall",
+			repoErr:     errors.New("db error"),
+			wantSet:     []domain.UserStatus{domain.UserStatusPaused},
+			wantErr:     errors.New("db error"),
+		},
+		{
+			name:        "error - listings not refreshed",
+			status:      domain.UserStatusBanned,
+			requestedBy: "oncall",
+			streamErr:   errors.New("db error"),
+			wantSet:     []domain.UserStatus{domain.UserStatusBanned},
+			wantPurged:  []domain.UserID{"user1"},
+			wantAudit:   []string{"user status changed"},
+			wantErr:     errors.New("failed to refresh liker listings"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockUserStatusRepo{err: tt.repoErr}
+			listings := &mockLikerListings{
+				records: []domain.DecisionRecord{
+					{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike},
+				},
+				streamErr: tt.streamErr,
+			}
+			audit := &mockAuditLogger{}
+
+			err := NewUserStatusManager(repo, listings, listings, audit).SetStatus(context.Background(), "user1", tt.status, tt.requestedBy, "spam")
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, domain.ErrInvalidInput) {
+					assert.ErrorIs(t, err, domain.ErrInvalidInput)
+				} else {
+					assert.ErrorContains(t, err, tt.wantErr.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSet, repo.set)
+			assert.Equal(t, tt.wantPurged, listings.purged)
+			assert.Equal(t, tt.wantAudit, audit.messages)
+		})
+	}
+}
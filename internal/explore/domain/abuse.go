@@ -0,0 +1,32 @@
+package domain
+
+type AbuseReason string
+
+const (
+	AbuseReasonVelocity      AbuseReason = "velocity"
+	AbuseReasonLikeRatio     AbuseReason = "like_ratio"
+	AbuseReasonSequentialIDs AbuseReason = "sequential_ids"
+)
+
+// AbuseFlag marks an actor as shadow-banned: their decisions are still saved
+// but they are hidden from everyone's likers.
+type AbuseFlag struct {
+	UserID    UserID
+	Reason    AbuseReason
+	Details   string
+	FlaggedAt uint64
+}
+
+// DecisionActivity is what the abuse counters know about an actor's recent
+// decisions, including the one just recorded.
+type DecisionActivity struct {
+	// Decisions and Likes are counted over the current counter window.
+	Decisions uint64
+	Likes     uint64
+	// SequentialRun is how many decisions in a row, each soon after the one
+	// before, went to a recipient whose ID sorts after the previous
+	// recipient's.
+	SequentialRun uint64
+	// Flagged is set once the actor was flagged, until the flag is cleared.
+	Flagged bool
+}
@@ -0,0 +1,60 @@
+package domain
+
+type Decision int16
+
+const (
+	DecisionPass      Decision = 0
+	DecisionLike      Decision = 1
+	DecisionSuperLike Decision = 2
+)
+
+func DecisionFromLiked(liked bool) Decision {
+	if liked {
+		return DecisionLike
+	}
+	return DecisionPass
+}
+
+func (d Decision) Valid() bool {
+	return d == DecisionPass || d == DecisionLike || d == DecisionSuperLike
+}
+
+func (d Decision) Liked() bool {
+	return d == DecisionLike || d == DecisionSuperLike
+}
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionPass:
+		return "pass"
+	case DecisionLike:
+		return "like"
+	case DecisionSuperLike:
+		return "super_like"
+	default:
+		return "unknown"
+	}
+}
+
+// SuperLikeQuota caps the super-likes an actor gives since Since. Super-liking
+// the same recipient again doesn't count twice, so retries never hit it.
+type SuperLikeQuota struct {
+	Limit uint64
+	Since uint64
+}
+
+type DecisionRecord struct {
+	ActorID     UserID
+	RecipientID UserID
+	Decision    Decision
+	Timestamp   uint64
+	Archived    bool
+}
+
+// DecisionsQuery selects decisions by actor, recipient or both.
+type DecisionsQuery struct {
+	ActorID         UserID
+	RecipientID     UserID
+	IncludeArchived bool
+	Limit           uint64
+}
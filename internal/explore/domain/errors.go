@@ -1,8 +1,26 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrInvalidInput = errors.New("invalid input")
+	ErrUserNotFound  = errors.New("user not found")
+	ErrUserNotActive = errors.New("user is not active")
+	ErrInvalidInput  = errors.New("invalid input")
+	ErrInvalidFilter = fmt.Errorf("%w: unsupported likers filter", ErrInvalidInput)
+	ErrInvalidUserID = fmt.Errorf("%w: user ID must be a UUID", ErrInvalidInput)
+
+	ErrSuperLikeQuotaExceeded = errors.New("daily super like quota exceeded")
+
+	ErrAbuseFlagNotFound = errors.New("abuse flag not found")
+
+	ErrInvalidPaginationToken = errors.New("invalid pagination token")
+	ErrTokenMalformed         = fmt.Errorf("%w: malformed token", ErrInvalidPaginationToken)
+	ErrTokenUnknownKey        = fmt.Errorf("%w: unknown signing key", ErrInvalidPaginationToken)
+	ErrTokenSignature         = fmt.Errorf("%w: signature mismatch", ErrInvalidPaginationToken)
+	ErrTokenExpired           = fmt.Errorf("%w: token expired", ErrInvalidPaginationToken)
+	ErrTokenRecipientMismatch = fmt.Errorf("%w: token was issued for a different recipient", ErrInvalidPaginationToken)
+	ErrTokenKindMismatch      = fmt.Errorf("%w: token was issued for a different query", ErrInvalidPaginationToken)
 )
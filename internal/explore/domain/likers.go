@@ -1,6 +1,115 @@
 package domain
 
 type LikerInfo struct {
-	ActorID   string
+	ActorID   UserID
 	Timestamp uint64
+	Decision  Decision
+	// Profile is only loaded on request and never cached with the page.
+	Profile *Profile `json:"-"`
+}
+
+// Cursor points at the last liker of a page. Likers given in the same second
+// are ordered by actor ID, descending, so ActorID breaks ties between them.
+// Decision is only significant when super-likes are listed first, as pages
+// are then ordered by decision too.
+type Cursor struct {
+	Timestamp uint64
+	ActorID   UserID
+	Decision  Decision
+}
+
+// SeenWatermark is the newest like the recipient has seen, in the order
+// likers are listed: a like is unseen if it was given later, or in the same
+// second by an actor with a greater ID. The zero value has seen nothing.
+type SeenWatermark struct {
+	Timestamp uint64
+	ActorID   UserID
+}
+
+// Covers reports whether the like given at timestamp by actorID is seen.
+func (w SeenWatermark) Covers(timestamp uint64, actorID UserID) bool {
+	return timestamp < w.Timestamp || timestamp == w.Timestamp && actorID <= w.ActorID
+}
+
+// Before reports whether w has seen less than other.
+func (w SeenWatermark) Before(other SeenWatermark) bool {
+	return w.Timestamp < other.Timestamp || w.Timestamp == other.Timestamp && w.ActorID < other.ActorID
+}
+
+// LikersFilter selects likers by the recipient's own decision about them.
+type LikersFilter string
+
+const (
+	// LikersFilterAll returns every liker the recipient has not passed on.
+	LikersFilterAll LikersFilter = "all"
+	// LikersFilterPending returns likers the recipient has not decided on yet.
+	LikersFilterPending LikersFilter = "pending"
+	// LikersFilterMatched returns likers the recipient liked back.
+	LikersFilterMatched LikersFilter = "matched"
+	// LikersFilterRejected returns likers the recipient passed on.
+	LikersFilterRejected LikersFilter = "rejected"
+)
+
+func (f LikersFilter) Valid() bool {
+	switch f {
+	case LikersFilterAll, LikersFilterPending, LikersFilterMatched, LikersFilterRejected:
+		return true
+	}
+	return false
+}
+
+type LikersQuery struct {
+	RecipientID UserID
+	Cursor      *Cursor
+	Filter      LikersFilter
+	// SeenUpTo restricts the query to likes after the recipient's seen watermark.
+	SeenUpTo        *SeenWatermark
+	SuperLikesFirst bool
+	IncludeExpired  bool
+}
+
+type LikersCountQuery struct {
+	RecipientID    UserID
+	SeenUpTo       *SeenWatermark
+	IncludeExpired bool
+}
+
+type ListLikersOptions struct {
+	Filter          LikersFilter
+	UnseenOnly      bool
+	SuperLikesFirst bool
+	IncludeExpired  bool
+	// IncludeProfile attaches each liker's profile. It doesn't change which
+	// likers are listed, so tokens stay valid whether it is set or not.
+	IncludeProfile bool
+}
+
+type CountLikersOptions struct {
+	IncludeExpired bool
+}
+
+type LikersCount struct {
+	Total  uint64
+	Unseen uint64
+}
+
+// LikerIndex holds everything needed to answer likers queries for one
+// recipient: who liked them, and the recipient's own decisions keyed by the
+// other user.
+type LikerIndex struct {
+	Likers    []LikerInfo
+	Decisions map[UserID]Decision
+}
+
+// CounterCheck compares a cached likers counter with the count in the
+// database.
+type CounterCheck struct {
+	Query    LikersCountQuery
+	Cached   uint64
+	Actual   uint64
+	Repaired bool
+}
+
+func (c CounterCheck) Drifted() bool {
+	return c.Cached != c.Actual
 }
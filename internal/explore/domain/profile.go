@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Profile is the summary of a user shown next to their likes.
+type Profile struct {
+	UserID    UserID
+	Name      string
+	PhotoURL  string
+	BirthDate time.Time // Zero when unknown
+}
+
+// Age returns the age in whole years at now, or 0 when the birth date is
+// unknown.
+func (p Profile) Age(now time.Time) uint32 {
+	if p.BirthDate.IsZero() || now.Before(p.BirthDate) {
+		return 0
+	}
+
+	years := now.Year() - p.BirthDate.Year()
+	if now.Month() < p.BirthDate.Month() || (now.Month() == p.BirthDate.Month() && now.Day() < p.BirthDate.Day()) {
+		years--
+	}
+
+	return uint32(years)
+}
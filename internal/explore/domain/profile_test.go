@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestProfile_Age(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		birthDate time.Time
+		now       time.Time
+		want      uint32
+	}{
+		{name: "unknown birth date", now: date(2024, 6, 1), want: 0},
+		{name: "birthday passed", birthDate: date(1990, 3, 15), now: date(2024, 6, 1), want: 34},
+		{name: "on the birthday", birthDate: date(1990, 6, 1), now: date(2024, 6, 1), want: 34},
+		{name: "day before the birthday", birthDate: date(1990, 6, 2), now: date(2024, 6, 1), want: 33},
+		{name: "leap day birthday in a common year", birthDate: date(2000, 2, 29), now: date(2023, 2, 28), want: 22},
+		{name: "leap year does not shift the birthday", birthDate: date(1990, 3, 1), now: date(2024, 3, 1), want: 34},
+		{name: "birth date in the future", birthDate: date(2030, 1, 1), now: date(2024, 6, 1), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Profile{BirthDate: tt.birthDate}.Age(tt.now))
+		})
+	}
+}
@@ -1,38 +1,153 @@
 package domain
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"strings"
+	"time"
 )
 
+type TokenKind string
+
+func LikersTokenKind(opts ListLikersOptions) TokenKind {
+	kind := TokenKind(opts.Filter)
+	if opts.UnseenOnly {
+		kind += "+unseen"
+	}
+
+	if opts.SuperLikesFirst {
+		kind += "+super"
+	}
+
+	if opts.IncludeExpired {
+		kind += "+expired"
+	}
+
+	return kind
+}
+
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
 type PaginationToken struct {
-	Timestamp uint64 `json:"t"`
+	KeyID       string    `json:"k"`
+	RecipientID UserID    `json:"r"`
+	Kind        TokenKind `json:"q"`
+	Timestamp   uint64    `json:"t"`
+	ActorID     UserID    `json:"a,omitempty"`
+	Decision    Decision  `json:"d,omitempty"`
+	ExpiresAt   int64     `json:"e"`
+}
+
+// TokenCodec issues and verifies HMAC-signed pagination tokens. Tokens are
+// signed with the first (active) key and verified with any configured key,
+// so a key can be rotated out once tokens signed with it have expired.
+type TokenCodec struct {
+	keys      map[string][]byte
+	activeKey string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewTokenCodec(keys []SigningKey, ttl time.Duration) (*TokenCodec, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("token ttl must be positive")
+	}
+
+	keyMap := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ".") {
+			return nil, errors.New("signing key ID must be non-empty and must not contain '.'")
+		}
+		if len(key.Secret) == 0 {
+			return nil, errors.New("signing key secret must not be empty")
+		}
+		if _, ok := keyMap[key.ID]; ok {
+			return nil, errors.New("duplicate signing key ID: " + key.ID)
+		}
+		keyMap[key.ID] = key.Secret
+	}
+
+	return &TokenCodec{
+		keys:      keyMap,
+		activeKey: keys[0].ID,
+		ttl:       ttl,
+		now:       time.Now,
+	}, nil
 }
 
-func EncodePaginationToken(timestamp uint64) string {
+func (c *TokenCodec) Encode(recipientID UserID, kind TokenKind, cursor Cursor) string {
 	token := PaginationToken{
-		Timestamp: timestamp,
+		KeyID:       c.activeKey,
+		RecipientID: recipientID,
+		Kind:        kind,
+		Timestamp:   cursor.Timestamp,
+		ActorID:     cursor.ActorID,
+		Decision:    cursor.Decision,
+		ExpiresAt:   c.now().Add(c.ttl).Unix(),
 	}
 
 	data, _ := json.Marshal(token)
-	return base64.StdEncoding.EncodeToString(data)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+
+	return payload + "." + c.sign(c.keys[c.activeKey], payload)
 }
 
-func DecodePaginationToken(tokenStr string) (*uint64, error) {
+func (c *TokenCodec) Decode(tokenStr string, recipientID UserID, kind TokenKind) (*Cursor, error) {
 	if tokenStr == "" {
 		return nil, nil
 	}
 
-	data, err := base64.StdEncoding.DecodeString(tokenStr)
+	payload, signature, ok := strings.Cut(tokenStr, ".")
+	if !ok || payload == "" || signature == "" {
+		return nil, ErrTokenMalformed
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base64 token: %w", err)
+		return nil, ErrTokenMalformed
 	}
 
 	var token PaginationToken
 	if err := json.Unmarshal(data, &token); err != nil {
-		return nil, fmt.Errorf("invalid token format: %w", err)
+		return nil, ErrTokenMalformed
+	}
+
+	secret, ok := c.keys[token.KeyID]
+	if !ok {
+		return nil, ErrTokenUnknownKey
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(c.sign(secret, payload))) {
+		return nil, ErrTokenSignature
+	}
+
+	if c.now().Unix() >= token.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	if token.RecipientID != recipientID {
+		return nil, ErrTokenRecipientMismatch
 	}
 
-	return &token.Timestamp, nil
+	if token.Kind != kind {
+		return nil, ErrTokenKindMismatch
+	}
+
+	return &Cursor{Timestamp: token.Timestamp, ActorID: token.ActorID, Decision: token.Decision}, nil
+}
+
+func (c *TokenCodec) sign(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
 }
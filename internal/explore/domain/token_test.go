@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenCodec(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	newCodec := func(keys ...SigningKey) *TokenCodec {
+		codec, err := NewTokenCodec(keys, time.Hour)
+		assert.NoError(t, err)
+		codec.now = func() time.Time { return now }
+		return codec
+	}
+
+	kindAll := LikersTokenKind(ListLikersOptions{Filter: LikersFilterAll})
+	kindPending := LikersTokenKind(ListLikersOptions{Filter: LikersFilterPending})
+
+	current := SigningKey{ID: "k2", Secret: []byte("current")}
+	previous := SigningKey{ID: "k1", Secret: []byte("previous")}
+
+	tests := []struct {
+		name      string
+		token     func() string
+		advance   time.Duration
+		recipient UserID
+		kind      TokenKind
+		wantTS    *Cursor
+		wantErr   error
+	}{
+		{
+			name:      "success - round trip",
+			token:     func() string { return newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42}) },
+			recipient: "user1",
+			kind:      kindAll,
+			wantTS:    &Cursor{Timestamp: 42},
+		},
+		{
+			name: "success - round trip with decision",
+			token: func() string {
+				return newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42, Decision: DecisionSuperLike})
+			},
+			recipient: "user1",
+			kind:      kindAll,
+			wantTS:    &Cursor{Timestamp: 42, Decision: DecisionSuperLike},
+		},
+		{
+			name: "success - round trip with actor",
+			token: func() string {
+				return newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42, ActorID: "liker7"})
+			},
+			recipient: "user1",
+			kind:      kindAll,
+			wantTS:    &Cursor{Timestamp: 42, ActorID: "liker7"},
+		},
+		{
+			name:      "success - empty token",
+			token:     func() string { return "" },
+			recipient: "user1",
+			kind:      kindAll,
+			wantTS:    nil,
+		},
+		{
+			name:      "success - signed with rotated key",
+			token:     func() string { return newCodec(previous).Encode("user1", kindPending, Cursor{Timestamp: 42}) },
+			recipient: "user1",
+			kind:      kindPending,
+			wantTS:    &Cursor{Timestamp: 42},
+		},
+		{
+			name:      "error - legacy base64 token",
+			token:     func() string { return "eyJ0IjoxMjM0NTZ9" },
+			recipient: "user1",
+			kind:      kindAll,
+			wantErr:   ErrTokenMalformed,
+		},
+		{
+			name: "error - tampered payload",
+			token: func() string {
+				token := newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42})
+				forged := newCodec(SigningKey{ID: "k2", Secret: []byte("guess")}).Encode("user1", kindAll, Cursor{Timestamp: 1})
+				payload, _, _ := strings.Cut(forged, ".")
+				_, signature, _ := strings.Cut(token, ".")
+				return payload + "." + signature
+			},
+			recipient: "user1",
+			kind:      kindAll,
+			wantErr:   ErrTokenSignature,
+		},
+		{
+			name: "error - unknown key",
+			token: func() string {
+				return newCodec(SigningKey{ID: "k9", Secret: []byte("x")}).Encode("user1", kindAll, Cursor{Timestamp: 42})
+			},
+			recipient: "user1",
+			kind:      kindAll,
+			wantErr:   ErrTokenUnknownKey,
+		},
+		{
+			name:      "error - expired",
+			token:     func() string { return newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42}) },
+			advance:   2 * time.Hour,
+			recipient: "user1",
+			kind:      kindAll,
+			wantErr:   ErrTokenExpired,
+		},
+		{
+			name:      "error - different recipient",
+			token:     func() string { return newCodec(current).Encode("user2", kindAll, Cursor{Timestamp: 42}) },
+			recipient: "user1",
+			kind:      kindAll,
+			wantErr:   ErrTokenRecipientMismatch,
+		},
+		{
+			name:      "error - different kind",
+			token:     func() string { return newCodec(current).Encode("user1", kindAll, Cursor{Timestamp: 42}) },
+			recipient: "user1",
+			kind:      kindPending,
+			wantErr:   ErrTokenKindMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.token()
+			assert.NotContains(t, token, "=")
+
+			codec := newCodec(current, previous)
+			codec.now = func() time.Time { return now.Add(tt.advance) }
+
+			gotTS, err := codec.Decode(token, tt.recipient, tt.kind)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTS, gotTS)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type UserStatus string
+
+const (
+	UserStatusActive  UserStatus = "active"
+	UserStatusPaused  UserStatus = "paused"
+	UserStatusBanned  UserStatus = "banned"
+	UserStatusDeleted UserStatus = "deleted"
+)
+
+func (s UserStatus) Valid() bool {
+	return s == UserStatusActive || s == UserStatusPaused || s == UserStatusBanned || s == UserStatusDeleted
+}
+
+type User struct {
+	ID          UserID
+	Status      UserStatus
+	DisplayName string
+	PhotoURL    string
+	BirthDate   time.Time // Zero when unknown
+	CreatedAt   uint64
+	UpdatedAt   uint64
+}
+
+func (u User) Active() bool {
+	return u.Status == UserStatusActive
+}
@@ -0,0 +1,6 @@
+package domain
+
+type ErasureResult struct {
+	DecisionsDeleted  uint64
+	CountersRecounted uint64
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserID is a user's UUID in its canonical lowercase, hyphenated form, which
+// is how it is stored in Postgres and embedded in cache keys.
+type UserID string
+
+// ParseUserID accepts any form of UUID uuid.Parse does and returns it in
+// canonical form.
+func ParseUserID(s string) (UserID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", ErrInvalidUserID
+	}
+
+	return UserID(id.String()), nil
+}
+
+func (id UserID) String() string {
+	return string(id)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseUserID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    UserID
+		wantErr bool
+	}{
+		{
+			name:  "canonical",
+			input: "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+			want:  "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+		},
+		{
+			name:  "upper case is lowered",
+			input: "6F1C2A8E-3B4D-4C5E-8F90-1A2B3C4D5E01",
+			want:  "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+		},
+		{
+			name:  "urn form is normalised",
+			input: "urn:uuid:6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+			want:  "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "not a UUID",
+			input:   "user1",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			input:   "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e010",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUserID(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidUserID)
+				assert.ErrorIs(t, err, ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package conformance
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+// AbuseCounters is what the abuse detector needs from its counters.
+type AbuseCounters interface {
+	RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error)
+	MarkFlagged(ctx context.Context, userID domain.UserID) error
+	ResetUser(ctx context.Context, userID domain.UserID) error
+}
+
+// AbuseCountersHarness is a set of counters under test together with a way
+// to move their clock.
+type AbuseCountersHarness struct {
+	Counters AbuseCounters
+	// Elapse makes the counters behave as if d had passed.
+	Elapse func(d time.Duration)
+}
+
+// TestAbuseCounters runs the suite against counters built by newCounters,
+// which must return empty counters with the given window and run gap. Both
+// are kept to seconds so counters without a fake clock can sleep in Elapse.
+func TestAbuseCounters(t *testing.T, newCounters func(t *testing.T, window time.Duration, runGap time.Duration) AbuseCountersHarness) {
+	t.Run("counts", func(t *testing.T) {
+		testAbuseCounts(t, newCounters(t, time.Minute, time.Minute))
+	})
+	t.Run("sequential run", func(t *testing.T) {
+		testSequentialRun(t, newCounters(t, time.Minute, time.Minute))
+	})
+	t.Run("run gap", func(t *testing.T) {
+		testRunGap(t, newCounters(t, time.Minute, time.Second))
+	})
+	t.Run("flag and reset", func(t *testing.T) {
+		testFlagAndReset(t, newCounters(t, time.Minute, time.Minute))
+	})
+	t.Run("window", func(t *testing.T) {
+		testAbuseWindow(t, newCounters(t, 2*time.Second, 1200*time.Millisecond))
+	})
+}
+
+func testAbuseCounts(t *testing.T, harness AbuseCountersHarness) {
+	ctx := context.Background()
+	counters := harness.Counters
+
+	var activity domain.DecisionActivity
+	var err error
+	for _, decision := range []domain.Decision{domain.DecisionLike, domain.DecisionPass, domain.DecisionSuperLike, domain.DecisionLike} {
+		activity, err = counters.RecordDecision(ctx, "actor", "recipient", decision)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, domain.DecisionActivity{Decisions: 4, Likes: 3, SequentialRun: 1}, activity)
+
+	// Actors are counted apart.
+	activity, err = counters.RecordDecision(ctx, "other", "recipient", domain.DecisionPass)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DecisionActivity{Decisions: 1, SequentialRun: 1}, activity)
+}
+
+func testSequentialRun(t *testing.T, harness AbuseCountersHarness) {
+	ctx := context.Background()
+	counters := harness.Counters
+
+	steps := []struct {
+		recipient domain.UserID
+		wantRun   uint64
+	}{
+		{recipient: "0b7e9c4a-5d21-4f0e-9a63-2c8d1e7f4b90", wantRun: 1},
+		{recipient: "3c2f8e1d-9a4b-4d7e-8c6f-5b1a0e9d2c47", wantRun: 2},
+		{recipient: "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01", wantRun: 3},
+		// Deciding on the same user again doesn't climb.
+		{recipient: "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01", wantRun: 1},
+		{recipient: "a4d8b2c6-1e3f-4a5b-9c7d-8e0f1a2b3c4d", wantRun: 2},
+		// A lower ID starts a new run.
+		{recipient: "1d9e7f5a-3b2c-4e1d-8f6a-9b0c2d4e6f81", wantRun: 1},
+		{recipient: "f0e1d2c3-b4a5-4968-8776-5a4b3c2d1e0f", wantRun: 2},
+	}
+	for _, step := range steps {
+		activity, err := counters.RecordDecision(ctx, "actor", step.recipient, domain.DecisionPass)
+		require.NoError(t, err)
+		assert.Equal(t, step.wantRun, activity.SequentialRun, step.recipient)
+	}
+
+	// Runs are kept per actor.
+	activity, err := counters.RecordDecision(ctx, "other", "ffffffff-ffff-4fff-bfff-ffffffffffff", domain.DecisionPass)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), activity.SequentialRun)
+}
+
+func testRunGap(t *testing.T, harness AbuseCountersHarness) {
+	ctx := context.Background()
+	counters := harness.Counters
+
+	recipients := []domain.UserID{
+		"0b7e9c4a-5d21-4f0e-9a63-2c8d1e7f4b90",
+		"3c2f8e1d-9a4b-4d7e-8c6f-5b1a0e9d2c47",
+		"6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01",
+		"a4d8b2c6-1e3f-4a5b-9c7d-8e0f1a2b3c4d",
+	}
+
+	// Someone browsing the feed in ID order at human speed never builds a run.
+	for _, recipient := range recipients {
+		activity, err := counters.RecordDecision(ctx, "person", recipient, domain.DecisionLike)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), activity.SequentialRun, recipient)
+		harness.Elapse(1500 * time.Millisecond)
+	}
+
+	// A bot walking it faster climbs.
+	for i, recipient := range recipients {
+		activity, err := counters.RecordDecision(ctx, "bot", recipient, domain.DecisionLike)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), activity.SequentialRun, recipient)
+		harness.Elapse(100 * time.Millisecond)
+	}
+}
+
+func testFlagAndReset(t *testing.T, harness AbuseCountersHarness) {
+	ctx := context.Background()
+	counters := harness.Counters
+
+	_, err := counters.RecordDecision(ctx, "actor", "recipient1", domain.DecisionLike)
+	require.NoError(t, err)
+	require.NoError(t, counters.MarkFlagged(ctx, "actor"))
+
+	activity, err := counters.RecordDecision(ctx, "actor", "recipient2", domain.DecisionLike)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DecisionActivity{Decisions: 2, Likes: 2, SequentialRun: 2, Flagged: true}, activity)
+
+	activity, err = counters.RecordDecision(ctx, "other", "recipient1", domain.DecisionLike)
+	require.NoError(t, err)
+	assert.False(t, activity.Flagged)
+
+	require.NoError(t, counters.ResetUser(ctx, "actor"))
+	activity, err = counters.RecordDecision(ctx, "actor", "recipient3", domain.DecisionLike)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DecisionActivity{Decisions: 1, Likes: 1, SequentialRun: 1}, activity)
+}
+
+func testAbuseWindow(t *testing.T, harness AbuseCountersHarness) {
+	ctx := context.Background()
+	counters := harness.Counters
+
+	_, err := counters.RecordDecision(ctx, "actor", "recipient1", domain.DecisionLike)
+	require.NoError(t, err)
+	require.NoError(t, counters.MarkFlagged(ctx, "actor"))
+
+	// The window starts with the first decision, later ones don't extend it.
+	harness.Elapse(time.Second)
+	activity, err := counters.RecordDecision(ctx, "actor", "recipient2", domain.DecisionLike)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), activity.Decisions)
+	assert.Equal(t, uint64(2), activity.SequentialRun)
+
+	harness.Elapse(1500 * time.Millisecond)
+	activity, err = counters.RecordDecision(ctx, "actor", "recipient3", domain.DecisionPass)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DecisionActivity{Decisions: 1, SequentialRun: 1, Flagged: true}, activity,
+		"counts start over, the run is broken by the pause and the flag stays")
+
+	// The marker is forgotten once the actor made no decision for a window.
+	harness.Elapse(2500 * time.Millisecond)
+	activity, err = counters.RecordDecision(ctx, "actor", "recipient4", domain.DecisionPass)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DecisionActivity{Decisions: 1, SequentialRun: 1}, activity)
+}
@@ -0,0 +1,218 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+// Cache is what the application services need from a likers cache.
+type Cache interface {
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error
+	PurgeUser(ctx context.Context, userID domain.UserID) error
+}
+
+// CacheHarness is a cache under test together with a way to move its clock.
+type CacheHarness struct {
+	Cache Cache
+	// Elapse makes the cache behave as if d had passed.
+	Elapse func(d time.Duration)
+}
+
+// TestCache runs the suite against caches built by newCache, which must
+// return an empty cache whose entries live for ttl. TTLs are kept to seconds
+// so a cache without a fake clock can sleep in Elapse.
+func TestCache(t *testing.T, newCache func(t *testing.T, ttl time.Duration) CacheHarness) {
+	t.Run("round trip", func(t *testing.T) {
+		testCacheRoundTrip(t, newCache(t, time.Minute))
+	})
+	t.Run("distinct keys", func(t *testing.T) {
+		testCacheKeys(t, newCache(t, time.Minute))
+	})
+	t.Run("purge", func(t *testing.T) {
+		testCachePurge(t, newCache(t, time.Minute))
+	})
+	t.Run("ttl", func(t *testing.T) {
+		testCacheTTL(t, newCache(t, 2*time.Second))
+	})
+}
+
+func testCacheRoundTrip(t *testing.T, harness CacheHarness) {
+	ctx := context.Background()
+	cache := harness.Cache
+
+	likersQuery := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll}
+	countQuery := domain.LikersCountQuery{RecipientID: "recipient"}
+
+	_, _, err := cache.GetLikers(ctx, likersQuery)
+	assert.Error(t, err, "likers miss")
+	_, err = cache.GetLikersCount(ctx, countQuery)
+	assert.Error(t, err, "count miss")
+	_, err = cache.GetSeenWatermark(ctx, "recipient")
+	assert.Error(t, err, "watermark miss")
+
+	likers := []domain.LikerInfo{
+		{ActorID: "super", Timestamp: 200, Decision: domain.DecisionSuperLike},
+		{ActorID: "liker", Timestamp: 150, Decision: domain.DecisionLike},
+	}
+	next := &domain.Cursor{Timestamp: 150, ActorID: "liker"}
+
+	require.NoError(t, cache.SetLikers(ctx, likersQuery, likers, next, 0))
+	require.NoError(t, cache.SetLikersCount(ctx, countQuery, 7, 0))
+	require.NoError(t, cache.SetSeenWatermark(ctx, "recipient", domain.SeenWatermark{Timestamp: 120, ActorID: "liker"}))
+
+	cachedLikers, cachedNext, err := cache.GetLikers(ctx, likersQuery)
+	require.NoError(t, err)
+	assert.Equal(t, likers, cachedLikers)
+	assert.Equal(t, next, cachedNext)
+
+	count, err := cache.GetLikersCount(ctx, countQuery)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), count)
+
+	watermark, err := cache.GetSeenWatermark(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeenWatermark{Timestamp: 120, ActorID: "liker"}, watermark)
+
+	require.NoError(t, cache.UpdateSeenWatermark(ctx, "recipient", domain.SeenWatermark{Timestamp: 120, ActorID: "super"}))
+	watermark, err = cache.GetSeenWatermark(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeenWatermark{Timestamp: 120, ActorID: "super"}, watermark, "moved watermark")
+
+	// The last page has no cursor, and an empty page is still a hit.
+	lastQuery := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: next}
+	require.NoError(t, cache.SetLikers(ctx, lastQuery, nil, nil, 0))
+
+	cachedLikers, cachedNext, err = cache.GetLikers(ctx, lastQuery)
+	require.NoError(t, err)
+	assert.Empty(t, cachedLikers)
+	assert.Nil(t, cachedNext)
+}
+
+func testCacheKeys(t *testing.T, harness CacheHarness) {
+	ctx := context.Background()
+	cache := harness.Cache
+
+	seenUpTo := domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}
+	seenUpToOther := domain.SeenWatermark{Timestamp: 100, ActorID: "other"}
+	queries := []domain.LikersQuery{
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll},
+		{RecipientID: "recipient", Filter: domain.LikersFilterPending},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &seenUpTo},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &seenUpToOther},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: &domain.Cursor{Timestamp: 50}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: &domain.Cursor{Timestamp: 50, ActorID: "liker"}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true, Cursor: &domain.Cursor{Timestamp: 50, Decision: domain.DecisionSuperLike}},
+		{RecipientID: "recipient", Filter: domain.LikersFilterAll, IncludeExpired: true},
+		{RecipientID: "other", Filter: domain.LikersFilterAll},
+	}
+
+	for i, query := range queries {
+		require.NoError(t, cache.SetLikers(ctx, query, []domain.LikerInfo{{ActorID: domain.UserID(fmt.Sprintf("query%d", i))}}, nil, 0))
+	}
+
+	for i, query := range queries {
+		likers, _, err := cache.GetLikers(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LikerInfo{{ActorID: domain.UserID(fmt.Sprintf("query%d", i))}}, likers, "query %d shares an entry", i)
+	}
+
+	counts := []domain.LikersCountQuery{
+		{RecipientID: "recipient"},
+		{RecipientID: "recipient", SeenUpTo: &seenUpTo},
+		{RecipientID: "recipient", SeenUpTo: &seenUpToOther},
+		{RecipientID: "recipient", IncludeExpired: true},
+		{RecipientID: "other"},
+	}
+	for i, query := range counts {
+		require.NoError(t, cache.SetLikersCount(ctx, query, uint64(i), 0))
+	}
+
+	for i, query := range counts {
+		count, err := cache.GetLikersCount(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i), count, "count query %d shares an entry", i)
+	}
+}
+
+func testCachePurge(t *testing.T, harness CacheHarness) {
+	ctx := context.Background()
+	cache := harness.Cache
+
+	seenUpTo := domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}
+	for _, userID := range []domain.UserID{"purged", "kept"} {
+		require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: userID, Filter: domain.LikersFilterAll}, nil, nil, 0))
+		require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: userID, Filter: domain.LikersFilterAll, SeenUpTo: &seenUpTo}, nil, nil, 0))
+		require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: userID}, 1, 0))
+		require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: userID, SeenUpTo: &seenUpTo}, 1, 0))
+		require.NoError(t, cache.SetSeenWatermark(ctx, userID, seenUpTo))
+	}
+
+	require.NoError(t, cache.PurgeUser(ctx, "purged"))
+	require.NoError(t, cache.PurgeUser(ctx, "never-cached"))
+
+	for _, tt := range []struct {
+		userID domain.UserID
+		cached bool
+	}{
+		{userID: "purged", cached: false},
+		{userID: "kept", cached: true},
+	} {
+		_, _, err := cache.GetLikers(ctx, domain.LikersQuery{RecipientID: tt.userID, Filter: domain.LikersFilterAll})
+		assert.Equal(t, tt.cached, err == nil, "%s likers", tt.userID)
+		_, _, err = cache.GetLikers(ctx, domain.LikersQuery{RecipientID: tt.userID, Filter: domain.LikersFilterAll, SeenUpTo: &seenUpTo})
+		assert.Equal(t, tt.cached, err == nil, "%s unseen likers", tt.userID)
+		_, err = cache.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: tt.userID})
+		assert.Equal(t, tt.cached, err == nil, "%s count", tt.userID)
+		_, err = cache.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: tt.userID, SeenUpTo: &seenUpTo})
+		assert.Equal(t, tt.cached, err == nil, "%s unseen count", tt.userID)
+		_, err = cache.GetSeenWatermark(ctx, tt.userID)
+		assert.Equal(t, tt.cached, err == nil, "%s watermark", tt.userID)
+	}
+}
+
+func testCacheTTL(t *testing.T, harness CacheHarness) {
+	ctx := context.Background()
+	cache := harness.Cache
+
+	likersQuery := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll}
+	countQuery := domain.LikersCountQuery{RecipientID: "recipient"}
+
+	require.NoError(t, cache.SetLikers(ctx, likersQuery, []domain.LikerInfo{{ActorID: "liker"}}, nil, 0))
+	require.NoError(t, cache.SetLikersCount(ctx, countQuery, 1, 0))
+	require.NoError(t, cache.SetSeenWatermark(ctx, "recipient", domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}))
+
+	harness.Elapse(time.Second)
+
+	_, _, err := cache.GetLikers(ctx, likersQuery)
+	assert.NoError(t, err, "likers before expiry")
+	_, err = cache.GetLikersCount(ctx, countQuery)
+	assert.NoError(t, err, "count before expiry")
+	_, err = cache.GetSeenWatermark(ctx, "recipient")
+	assert.NoError(t, err, "watermark before expiry")
+
+	// Rewriting an entry restarts its TTL.
+	require.NoError(t, cache.SetLikersCount(ctx, countQuery, 2, 0))
+
+	harness.Elapse(1500 * time.Millisecond)
+
+	_, _, err = cache.GetLikers(ctx, likersQuery)
+	assert.Error(t, err, "likers after expiry")
+	_, err = cache.GetSeenWatermark(ctx, "recipient")
+	assert.Error(t, err, "watermark after expiry")
+
+	count, err := cache.GetLikersCount(ctx, countQuery)
+	require.NoError(t, err, "rewritten count")
+	assert.Equal(t, uint64(2), count)
+}
@@ -0,0 +1,524 @@
+// Package conformance holds the behaviour every repository and cache adapter
+// must share, so the in-memory ones can stand in for Postgres and Redis.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DecisionRepository is what the application services need from a decision
+// store.
+type DecisionRepository interface {
+	InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error)
+	GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error)
+	GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error)
+	GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error)
+	SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error)
+}
+
+// AbuseFlagRepository is what the abuse detector needs from a flag store.
+type AbuseFlagRepository interface {
+	FlagUser(ctx context.Context, flag domain.AbuseFlag) error
+	IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error)
+	ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error)
+	ClearAbuseFlag(ctx context.Context, userID domain.UserID) error
+}
+
+// Store is a decision repository under test together with the hooks the
+// suite needs to arrange data the repository itself can't write.
+type Store struct {
+	Repo DecisionRepository
+	// Flags shares its data with Repo.
+	Flags AbuseFlagRepository
+	// AddDecision stores a decision with the given timestamp.
+	AddDecision func(t *testing.T, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64)
+	// SetUserStatus creates the user, or updates it, with the given status.
+	SetUserStatus func(t *testing.T, userID domain.UserID, status domain.UserStatus)
+}
+
+// TestDecisionRepository runs the suite against stores built by newStore,
+// which must return an empty store configured with the given like lifetime.
+func TestDecisionRepository(t *testing.T, newStore func(t *testing.T, likeLifetime time.Duration) Store) {
+	t.Run("pagination", func(t *testing.T) {
+		testPagination(t, newStore(t, 0))
+	})
+	t.Run("pagination within a second", func(t *testing.T) {
+		testPaginationWithinSecond(t, newStore(t, 0))
+	})
+	t.Run("super likes first", func(t *testing.T) {
+		testSuperLikesFirst(t, newStore(t, 0))
+	})
+	t.Run("filters", func(t *testing.T) {
+		testFilters(t, newStore(t, 0))
+	})
+	t.Run("mutual and upsert", func(t *testing.T) {
+		testMutual(t, newStore(t, 0))
+	})
+	t.Run("super like quota", func(t *testing.T) {
+		testSuperLikeQuota(t, newStore(t, 0))
+	})
+	t.Run("concurrent super likes", func(t *testing.T) {
+		testConcurrentSuperLikes(t, newStore(t, 0))
+	})
+	t.Run("seen watermark", func(t *testing.T) {
+		testSeenWatermark(t, newStore(t, 0))
+	})
+	t.Run("hidden users", func(t *testing.T) {
+		testHiddenUsers(t, newStore(t, 0))
+	})
+	t.Run("shadow-banned users", func(t *testing.T) {
+		testShadowBannedUsers(t, newStore(t, 0))
+	})
+	t.Run("like lifetime", func(t *testing.T) {
+		testLikeLifetime(t, newStore(t, time.Hour))
+	})
+}
+
+func testPagination(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	// 41 likers: two full pages and one more liker.
+	var want []domain.UserID
+	for i := range 41 {
+		likerID := domain.UserID(fmt.Sprintf("liker%02d", i))
+		store.AddDecision(t, likerID, "recipient", domain.DecisionLike, now-uint64(i)-1)
+		want = append(want, likerID)
+	}
+
+	var got []domain.UserID
+	var pages []int
+	query := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll}
+	for {
+		likers, next, err := store.Repo.GetLikers(ctx, query)
+		require.NoError(t, err)
+
+		pages = append(pages, len(likers))
+		for _, liker := range likers {
+			got = append(got, liker.ActorID)
+		}
+
+		if next == nil {
+			break
+		}
+		require.Less(t, len(pages), 5, "pagination doesn't end")
+		query.Cursor = next
+	}
+
+	assert.Equal(t, []int{20, 20, 1}, pages)
+	assert.Equal(t, want, got, "newest first, every liker once")
+
+	// A full last page has no next cursor.
+	store.AddDecision(t, "liker-last", "other", domain.DecisionLike, now)
+	for i := range 19 {
+		store.AddDecision(t, domain.UserID(fmt.Sprintf("other%02d", i)), "other", domain.DecisionLike, now-uint64(i)-1)
+	}
+	likers, next, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "other", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Len(t, likers, 20)
+	assert.Nil(t, next)
+
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(41), count)
+}
+
+func testPaginationWithinSecond(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	// 45 likes over three seconds, so every page boundary falls between likes
+	// given in the same second. Those are listed by actor ID, descending.
+	var want []domain.UserID
+	for second := range 3 {
+		for i := 14; i >= 0; i-- {
+			likerID := domain.UserID(fmt.Sprintf("liker%d-%02d", second, i))
+			decision := domain.DecisionLike
+			if i%4 == 0 {
+				decision = domain.DecisionSuperLike
+			}
+			store.AddDecision(t, likerID, "recipient", decision, now-uint64(second)-1)
+			want = append(want, likerID)
+		}
+	}
+
+	listAll := func(query domain.LikersQuery) []domain.LikerInfo {
+		var got []domain.LikerInfo
+		for {
+			likers, next, err := store.Repo.GetLikers(ctx, query)
+			require.NoError(t, err)
+			got = append(got, likers...)
+
+			if next == nil {
+				return got
+			}
+			require.Less(t, len(got), 100, "pagination doesn't end")
+			query.Cursor = next
+		}
+	}
+
+	got := listAll(domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	var gotIDs []domain.UserID
+	for _, liker := range got {
+		gotIDs = append(gotIDs, liker.ActorID)
+	}
+	assert.Equal(t, want, gotIDs, "every liker once, newest first")
+
+	got = listAll(domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true})
+	require.Len(t, got, len(want))
+	seen := make(map[domain.UserID]bool)
+	for i, liker := range got {
+		assert.False(t, seen[liker.ActorID], "%s listed twice", liker.ActorID)
+		seen[liker.ActorID] = true
+
+		if i > 0 {
+			prev := got[i-1]
+			sorted := prev.Decision > liker.Decision ||
+				prev.Decision == liker.Decision && (prev.Timestamp > liker.Timestamp ||
+					prev.Timestamp == liker.Timestamp && prev.ActorID > liker.ActorID)
+			assert.True(t, sorted, "position %d", i)
+		}
+	}
+}
+
+func testSuperLikesFirst(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	for i := range 30 {
+		decision := domain.DecisionLike
+		if i%3 == 0 {
+			decision = domain.DecisionSuperLike
+		}
+		store.AddDecision(t, domain.UserID(fmt.Sprintf("liker%02d", i)), "recipient", decision, now-uint64(i)-1)
+	}
+
+	var got []domain.LikerInfo
+	query := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true}
+	for {
+		likers, next, err := store.Repo.GetLikers(ctx, query)
+		require.NoError(t, err)
+		got = append(got, likers...)
+
+		if next == nil {
+			break
+		}
+		query.Cursor = next
+	}
+
+	require.Len(t, got, 30)
+	for i, liker := range got {
+		if i < 10 {
+			assert.Equal(t, domain.DecisionSuperLike, liker.Decision, "position %d", i)
+		} else {
+			assert.Equal(t, domain.DecisionLike, liker.Decision, "position %d", i)
+		}
+		if i > 0 && liker.Decision == got[i-1].Decision {
+			assert.Less(t, liker.Timestamp, got[i-1].Timestamp, "position %d", i)
+		}
+	}
+}
+
+func testFilters(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	store.AddDecision(t, "pending", "recipient", domain.DecisionLike, now-1)
+	store.AddDecision(t, "matched", "recipient", domain.DecisionSuperLike, now-2)
+	store.AddDecision(t, "rejected", "recipient", domain.DecisionLike, now-3)
+	store.AddDecision(t, "passer", "recipient", domain.DecisionPass, now-4)
+	store.AddDecision(t, "recipient", "matched", domain.DecisionLike, now-5)
+	store.AddDecision(t, "recipient", "rejected", domain.DecisionPass, now-6)
+
+	tests := []struct {
+		filter domain.LikersFilter
+		want   []domain.UserID
+	}{
+		{filter: domain.LikersFilterAll, want: []domain.UserID{"pending", "matched"}},
+		{filter: domain.LikersFilterPending, want: []domain.UserID{"pending"}},
+		{filter: domain.LikersFilterMatched, want: []domain.UserID{"matched"}},
+		{filter: domain.LikersFilterRejected, want: []domain.UserID{"rejected"}},
+	}
+
+	for _, tt := range tests {
+		likers, next, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: tt.filter})
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, tt.want, actorIDs(likers), "filter %s", tt.filter)
+	}
+
+	_, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: "bogus"})
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+
+	// The counter matches the "all" listing: passes and the likers the
+	// recipient passed on are left out.
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func testMutual(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	mutual, _, err := store.Repo.InsertDecision(ctx, "alice", "bob", domain.DecisionLike, nil)
+	require.NoError(t, err)
+	assert.False(t, mutual, "first like")
+
+	mutual, _, err = store.Repo.InsertDecision(ctx, "bob", "alice", domain.DecisionPass, nil)
+	require.NoError(t, err)
+	assert.False(t, mutual, "pass on a liker")
+
+	mutual, timestamp, err := store.Repo.InsertDecision(ctx, "bob", "alice", domain.DecisionSuperLike, nil)
+	require.NoError(t, err)
+	assert.True(t, mutual, "changing a pass into a super like")
+
+	mutual, _, err = store.Repo.InsertDecision(ctx, "alice", "bob", domain.DecisionPass, nil)
+	require.NoError(t, err)
+	assert.False(t, mutual, "pass on a mutual liker")
+
+	// The latest decision wins: alice no longer likes bob.
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "bob", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Empty(t, likers)
+
+	likers, _, err = store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "alice", Filter: domain.LikersFilterRejected})
+	require.NoError(t, err)
+	if assert.Len(t, likers, 1) {
+		assert.Equal(t, domain.UserID("bob"), likers[0].ActorID)
+		assert.Equal(t, domain.DecisionSuperLike, likers[0].Decision)
+		assert.Equal(t, timestamp, likers[0].Timestamp, "returned timestamp is the stored one")
+	}
+}
+
+func testSuperLikeQuota(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+	quota := &domain.SuperLikeQuota{Limit: 2, Since: now - 50}
+
+	store.AddDecision(t, "actor", "old", domain.DecisionSuperLike, now-100)
+	store.AddDecision(t, "actor", "recent", domain.DecisionSuperLike, now-10)
+	store.AddDecision(t, "actor", "liked", domain.DecisionLike, now-5)
+	store.AddDecision(t, "other", "recent", domain.DecisionSuperLike, now-5)
+
+	_, _, err := store.Repo.InsertDecision(ctx, "actor", "first", domain.DecisionSuperLike, quota)
+	require.NoError(t, err)
+
+	_, _, err = store.Repo.InsertDecision(ctx, "actor", "second", domain.DecisionSuperLike, quota)
+	assert.ErrorIs(t, err, domain.ErrSuperLikeQuotaExceeded)
+
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "second", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Empty(t, likers, "a super like over the quota isn't stored")
+
+	// Re-sending a super like to the same recipient doesn't use up quota,
+	// and likes aren't limited.
+	_, _, err = store.Repo.InsertDecision(ctx, "actor", "first", domain.DecisionSuperLike, quota)
+	require.NoError(t, err)
+	_, _, err = store.Repo.InsertDecision(ctx, "actor", "second", domain.DecisionLike, quota)
+	require.NoError(t, err)
+}
+
+// testConcurrentSuperLikes sends more super likes at once than the quota
+// allows; exactly as many as the limit must get through.
+func testConcurrentSuperLikes(t *testing.T, store Store) {
+	ctx := context.Background()
+	quota := &domain.SuperLikeQuota{Limit: 3, Since: uint64(time.Now().Add(-time.Hour).Unix())}
+
+	const attempts = 10
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, errs[i] = store.Repo.InsertDecision(ctx, "actor", domain.UserID(fmt.Sprintf("recipient%02d", i)), domain.DecisionSuperLike, quota)
+		}()
+	}
+	wg.Wait()
+
+	var saved int
+	for _, err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrSuperLikeQuotaExceeded)
+	}
+	assert.Equal(t, int(quota.Limit), saved)
+}
+
+func testSeenWatermark(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	watermark, err := store.Repo.GetSeenWatermark(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Zero(t, watermark)
+
+	seen := domain.SeenWatermark{Timestamp: now - 10, ActorID: "b"}
+	watermark, err = store.Repo.SetSeenWatermark(ctx, "recipient", seen)
+	require.NoError(t, err)
+	assert.Equal(t, seen, watermark)
+
+	// The watermark never moves back, neither to an older second nor to a
+	// lower actor in the same second.
+	for _, older := range []domain.SeenWatermark{{Timestamp: now - 20, ActorID: "z"}, {Timestamp: now - 10, ActorID: "a"}} {
+		watermark, err = store.Repo.SetSeenWatermark(ctx, "recipient", older)
+		require.NoError(t, err)
+		assert.Equal(t, seen, watermark)
+	}
+
+	watermark, err = store.Repo.GetSeenWatermark(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, seen, watermark)
+
+	// Likes given in the watermark's second are seen up to its actor, in the
+	// order pages list them.
+	store.AddDecision(t, "seen", "recipient", domain.DecisionLike, now-15)
+	store.AddDecision(t, "a", "recipient", domain.DecisionLike, now-10)
+	store.AddDecision(t, "b", "recipient", domain.DecisionLike, now-10)
+	store.AddDecision(t, "c", "recipient", domain.DecisionLike, now-10)
+	store.AddDecision(t, "unseen", "recipient", domain.DecisionLike, now-5)
+
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &watermark})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"unseen", "c"}, actorIDs(likers))
+
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient", SeenUpTo: &watermark})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	watermark, err = store.Repo.SetSeenWatermark(ctx, "recipient", domain.SeenWatermark{Timestamp: now - 10, ActorID: "c"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeenWatermark{Timestamp: now - 10, ActorID: "c"}, watermark, "moved within the second")
+
+	likers, _, err = store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &watermark})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"unseen"}, actorIDs(likers))
+}
+
+func testHiddenUsers(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	store.SetUserStatus(t, "active", domain.UserStatusActive)
+	store.SetUserStatus(t, "paused", domain.UserStatusPaused)
+	store.SetUserStatus(t, "banned", domain.UserStatusBanned)
+
+	store.AddDecision(t, "active", "recipient", domain.DecisionLike, now-1)
+	store.AddDecision(t, "paused", "recipient", domain.DecisionLike, now-2)
+	store.AddDecision(t, "banned", "recipient", domain.DecisionLike, now-3)
+	store.AddDecision(t, "unknown", "recipient", domain.DecisionLike, now-4)
+
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"active", "unknown"}, actorIDs(likers), "users without a record are listed")
+
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// Reactivating a user lists their likes again.
+	store.SetUserStatus(t, "paused", domain.UserStatusActive)
+	count, err = store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+func testShadowBannedUsers(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	store.AddDecision(t, "person", "recipient", domain.DecisionLike, now-1)
+	store.AddDecision(t, "bot", "recipient", domain.DecisionSuperLike, now-2)
+	store.AddDecision(t, "spammer", "recipient", domain.DecisionLike, now-3)
+
+	require.NoError(t, store.Flags.FlagUser(ctx, domain.AbuseFlag{UserID: "bot", Reason: domain.AbuseReasonVelocity, Details: "too fast", FlaggedAt: now - 20}))
+	require.NoError(t, store.Flags.FlagUser(ctx, domain.AbuseFlag{UserID: "spammer", Reason: domain.AbuseReasonLikeRatio, FlaggedAt: now - 10}))
+	// Flagging again keeps the first flag.
+	require.NoError(t, store.Flags.FlagUser(ctx, domain.AbuseFlag{UserID: "bot", Reason: domain.AbuseReasonVelocity, FlaggedAt: now}))
+
+	flags, err := store.Flags.ListAbuseFlags(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AbuseFlag{
+		{UserID: "spammer", Reason: domain.AbuseReasonLikeRatio, FlaggedAt: now - 10},
+		{UserID: "bot", Reason: domain.AbuseReasonVelocity, Details: "too fast", FlaggedAt: now - 20},
+	}, flags, "most recent first")
+
+	flags, err = store.Flags.ListAbuseFlags(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, flags, 1)
+
+	flagged, err := store.Flags.IsUserFlagged(ctx, "bot")
+	require.NoError(t, err)
+	assert.True(t, flagged)
+	flagged, err = store.Flags.IsUserFlagged(ctx, "person")
+	require.NoError(t, err)
+	assert.False(t, flagged)
+
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"person"}, actorIDs(likers))
+
+	likers, _, err = store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SuperLikesFirst: true})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"person"}, actorIDs(likers))
+
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// Clearing the flag lists the decisions that were kept meanwhile.
+	require.NoError(t, store.Flags.ClearAbuseFlag(ctx, "bot"))
+	assert.ErrorIs(t, store.Flags.ClearAbuseFlag(ctx, "bot"), domain.ErrAbuseFlagNotFound)
+	flagged, err = store.Flags.IsUserFlagged(ctx, "bot")
+	require.NoError(t, err)
+	assert.False(t, flagged)
+
+	likers, _, err = store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"person", "bot"}, actorIDs(likers))
+
+	count, err = store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func testLikeLifetime(t *testing.T, store Store) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+
+	store.AddDecision(t, "fresh", "recipient", domain.DecisionLike, now-60)
+	store.AddDecision(t, "expired", "recipient", domain.DecisionLike, now-2*3600)
+
+	likers, _, err := store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"fresh"}, actorIDs(likers))
+
+	likers, _, err = store.Repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, IncludeExpired: true})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"fresh", "expired"}, actorIDs(likers))
+
+	count, err := store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	count, err = store.Repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient", IncludeExpired: true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func actorIDs(likers []domain.LikerInfo) []domain.UserID {
+	ids := make([]domain.UserID, 0, len(likers))
+	for _, liker := range likers {
+		ids = append(ids, liker.ActorID)
+	}
+	return ids
+}
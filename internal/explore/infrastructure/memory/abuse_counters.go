@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"sync"
+	"time"
+)
+
+type AbuseCountersConfig struct {
+	// Window is how long decisions are counted together, from an actor's
+	// first decision in it.
+	Window time.Duration
+	// RunGap is the longest pause between two decisions of a run of
+	// ascending recipient IDs. Zero lets a run go on while decisions come
+	// within a window of each other.
+	RunGap time.Duration
+}
+
+type abuseActivity struct {
+	decisions    uint64
+	likes        uint64
+	last         domain.UserID
+	run          uint64
+	runEnd       time.Time
+	windowEnd    time.Time
+	flaggedUntil time.Time
+}
+
+// AbuseCounters keeps each actor's decision activity in process and behaves
+// like the Redis counters.
+type AbuseCounters struct {
+	mu     sync.Mutex
+	config AbuseCountersConfig
+	actors map[domain.UserID]*abuseActivity
+	// now is replaced in tests to move the windows forward.
+	now func() time.Time
+}
+
+func NewAbuseCounters(config AbuseCountersConfig) *AbuseCounters {
+	return &AbuseCounters{
+		config: config,
+		actors: make(map[domain.UserID]*abuseActivity),
+		now:    time.Now,
+	}
+}
+
+func (c *AbuseCounters) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	activity := c.activity(actorID)
+
+	if !now.Before(activity.windowEnd) {
+		activity.decisions, activity.likes = 0, 0
+		activity.windowEnd = now.Add(c.config.Window)
+	}
+	activity.decisions++
+	if decision.Liked() {
+		activity.likes++
+	}
+
+	if now.Before(activity.runEnd) && recipientID > activity.last {
+		activity.run++
+	} else {
+		activity.run = 1
+	}
+	activity.last = recipientID
+	runGap := c.config.RunGap
+	if runGap <= 0 {
+		runGap = c.config.Window
+	}
+	activity.runEnd = now.Add(runGap)
+
+	flagged := now.Before(activity.flaggedUntil)
+	if flagged {
+		activity.flaggedUntil = now.Add(c.config.Window)
+	}
+
+	return domain.DecisionActivity{
+		Decisions:     activity.decisions,
+		Likes:         activity.likes,
+		SequentialRun: activity.run,
+		Flagged:       flagged,
+	}, nil
+}
+
+// MarkFlagged remembers the flag, so a flagged actor isn't evaluated again.
+// The marker lasts until ResetUser or until the actor has made no decision for
+// a whole window.
+func (c *AbuseCounters) MarkFlagged(ctx context.Context, userID domain.UserID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.activity(userID).flaggedUntil = c.now().Add(c.config.Window)
+
+	return nil
+}
+
+// ResetUser drops the user's counts, run and flag marker.
+func (c *AbuseCounters) ResetUser(ctx context.Context, userID domain.UserID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.actors, userID)
+
+	return nil
+}
+
+func (c *AbuseCounters) activity(userID domain.UserID) *abuseActivity {
+	activity, ok := c.actors[userID]
+	if !ok {
+		activity = &abuseActivity{}
+		c.actors[userID] = activity
+	}
+	return activity
+}
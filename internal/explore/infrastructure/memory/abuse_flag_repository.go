@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+)
+
+// AbuseFlagRepository serves abuse flags from an in-memory Database and
+// behaves like the Postgres repository.
+type AbuseFlagRepository struct {
+	db *Database
+}
+
+func NewAbuseFlagRepository(db *Database) *AbuseFlagRepository {
+	return &AbuseFlagRepository{db: db}
+}
+
+// FlagUser shadow-bans the user. A user already flagged keeps their first
+// flag.
+func (r *AbuseFlagRepository) FlagUser(ctx context.Context, flag domain.AbuseFlag) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.flags[flag.UserID]; !ok {
+		r.db.flags[flag.UserID] = flag
+	}
+
+	return nil
+}
+
+// IsUserFlagged reports whether the user is shadow-banned.
+func (r *AbuseFlagRepository) IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	_, ok := r.db.flags[userID]
+
+	return ok, nil
+}
+
+// ListAbuseFlags returns up to limit flags, most recent first.
+func (r *AbuseFlagRepository) ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var flags []domain.AbuseFlag
+	for _, flag := range r.db.flags {
+		flags = append(flags, flag)
+	}
+
+	slices.SortFunc(flags, func(a, b domain.AbuseFlag) int {
+		return cmp.Or(cmp.Compare(b.FlaggedAt, a.FlaggedAt), cmp.Compare(a.UserID, b.UserID))
+	})
+
+	if uint64(len(flags)) > limit {
+		flags = flags[:limit]
+	}
+
+	return flags, nil
+}
+
+// ClearAbuseFlag lifts the user's shadow-ban.
+func (r *AbuseFlagRepository) ClearAbuseFlag(ctx context.Context, userID domain.UserID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.flags[userID]; !ok {
+		return domain.ErrAbuseFlagNotFound
+	}
+	delete(r.db.flags, userID)
+
+	return nil
+}
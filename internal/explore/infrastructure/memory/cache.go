@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss is returned for keys that were never set, have expired or were
+// purged.
+var ErrCacheMiss = errors.New("cache miss")
+
+type CacheConfig struct {
+	TTL time.Duration
+}
+
+type cacheEntry struct {
+	likers    []domain.LikerInfo
+	cursor    *domain.Cursor
+	value     uint64
+	watermark domain.SeenWatermark
+	expiresAt time.Time
+}
+
+// Cache keeps likers pages, counters and seen watermarks in process, with the
+// same keys and TTL semantics as the Redis cache. Entries are grouped by user
+// so PurgeUser doesn't have to scan.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[domain.UserID]map[string]cacheEntry
+	config  CacheConfig
+	now     func() time.Time
+}
+
+func NewCache(config CacheConfig) *Cache {
+	return &Cache{
+		entries: make(map[domain.UserID]map[string]cacheEntry),
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// likersKey includes the seen watermark for unseen-only pages, so moving the
+// watermark forward naturally stops serving the pages built for the old one.
+func (c *Cache) likersKey(query domain.LikersQuery) string {
+	var cursor domain.Cursor
+	if query.Cursor != nil {
+		cursor = *query.Cursor
+	}
+
+	key := fmt.Sprintf("likers:{%s}:%d:%s", query.RecipientID, cursor.Timestamp, query.Filter)
+	if cursor.ActorID != "" {
+		key = fmt.Sprintf("%s:after:%s", key, cursor.ActorID)
+	}
+	if query.SeenUpTo != nil {
+		key = fmt.Sprintf("%s:seen:%d:%s", key, query.SeenUpTo.Timestamp, query.SeenUpTo.ActorID)
+	}
+
+	if query.SuperLikesFirst {
+		key = fmt.Sprintf("%s:super:%d", key, cursor.Decision)
+	}
+
+	if query.IncludeExpired {
+		key += ":expired"
+	}
+
+	return key
+}
+
+func (c *Cache) likersCountKey(query domain.LikersCountQuery) string {
+	key := fmt.Sprintf("count:{%s}", query.RecipientID)
+	if query.SeenUpTo != nil {
+		key = fmt.Sprintf("%s:seen:%d:%s", key, query.SeenUpTo.Timestamp, query.SeenUpTo.ActorID)
+	}
+
+	if query.IncludeExpired {
+		key += ":expired"
+	}
+
+	return key
+}
+
+func (c *Cache) watermarkKey(recipientID domain.UserID) string {
+	return fmt.Sprintf("seen:{%s}", recipientID)
+}
+
+// GetLikers returns a copy of the cached page, so callers may attach profiles
+// without changing what later readers get.
+func (c *Cache) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	entry, err := c.get(query.RecipientID, c.likersKey(query))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.Cursor
+	if entry.cursor != nil {
+		cursor := *entry.cursor
+		next = &cursor
+	}
+
+	return slices.Clone(entry.likers), next, nil
+}
+
+// SetLikers stores a copy of the page without profiles, which are never
+// cached with it.
+func (c *Cache) SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+	stored := make([]domain.LikerInfo, len(likers))
+	for i, liker := range likers {
+		liker.Profile = nil
+		stored[i] = liker
+	}
+
+	entry := cacheEntry{likers: stored}
+	if next != nil {
+		cursor := *next
+		entry.cursor = &cursor
+	}
+
+	c.set(query.RecipientID, c.likersKey(query), entry)
+
+	return nil
+}
+
+func (c *Cache) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	entry, err := c.get(query.RecipientID, c.likersCountKey(query))
+	if err != nil {
+		return 0, err
+	}
+
+	return entry.value, nil
+}
+
+func (c *Cache) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	c.set(query.RecipientID, c.likersCountKey(query), cacheEntry{value: count})
+
+	return nil
+}
+
+func (c *Cache) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	entry, err := c.get(recipientID, c.watermarkKey(recipientID))
+	if err != nil {
+		return domain.SeenWatermark{}, err
+	}
+
+	return entry.watermark, nil
+}
+
+func (c *Cache) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	c.set(recipientID, c.watermarkKey(recipientID), cacheEntry{watermark: seenUpTo})
+
+	return nil
+}
+
+// UpdateSeenWatermark stores a watermark that has just moved. There is a
+// single process, so this is the same write as a fill.
+func (c *Cache) UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return c.SetSeenWatermark(ctx, recipientID, seenUpTo)
+}
+
+// PurgeUser removes every cached listing, counter and watermark of the user.
+func (c *Cache) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, userID)
+
+	return nil
+}
+
+func (c *Cache) get(userID domain.UserID, key string) (cacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[userID][key]
+	if !ok {
+		return cacheEntry{}, ErrCacheMiss
+	}
+
+	if c.expired(entry) {
+		c.delete(userID, key)
+		return cacheEntry{}, ErrCacheMiss
+	}
+
+	return entry, nil
+}
+
+// set stores the entry and drops the user's expired entries, so users who
+// keep being written don't accumulate pages nobody reads any more.
+func (c *Cache) set(userID domain.UserID, key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config.TTL > 0 {
+		entry.expiresAt = c.now().Add(c.config.TTL)
+	}
+
+	entries := c.entries[userID]
+	if entries == nil {
+		entries = make(map[string]cacheEntry)
+		c.entries[userID] = entries
+	}
+
+	for k, e := range entries {
+		if c.expired(e) {
+			delete(entries, k)
+		}
+	}
+	entries[key] = entry
+}
+
+func (c *Cache) delete(userID domain.UserID, key string) {
+	delete(c.entries[userID], key)
+	if len(c.entries[userID]) == 0 {
+		delete(c.entries, userID)
+	}
+}
+
+func (c *Cache) expired(entry cacheEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)
+}
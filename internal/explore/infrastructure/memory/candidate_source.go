@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+)
+
+// CandidateSource serves candidates from an in-memory Database, for tests
+// and local runs without Postgres.
+type CandidateSource struct {
+	db *Database
+}
+
+func NewCandidateSource(db *Database) *CandidateSource {
+	return &CandidateSource{db: db}
+}
+
+// AddUser makes the user an active candidate for everyone else.
+func (s *CandidateSource) AddUser(userID domain.UserID) {
+	s.SetUserStatus(userID, domain.UserStatusActive)
+}
+
+// SetUserStatus hides the user from candidates unless the status is active,
+// adding the user if it is unknown.
+func (s *CandidateSource) SetUserStatus(userID domain.UserID, status domain.UserStatus) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	user := s.db.users[userID]
+	user.ID, user.Status = userID, status
+	s.db.users[userID] = user
+}
+
+// RecordDecision stores the actor's latest decision on the recipient and
+// adds both as active users unless they are known already.
+func (s *CandidateSource) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, id := range []domain.UserID{actorID, recipientID} {
+		if _, ok := s.db.users[id]; !ok {
+			s.db.users[id] = domain.User{ID: id, Status: domain.UserStatusActive}
+		}
+	}
+
+	s.db.putDecision(actorID, recipientID, decisionEntry{decision: decision, timestamp: timestamp})
+
+	return nil
+}
+
+// GetUndecidedLikers returns active users who liked the actor and whom the
+// actor hasn't decided on, super-likes first and then newest first.
+func (s *CandidateSource) GetUndecidedLikers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	type liker struct {
+		id domain.UserID
+		decisionEntry
+	}
+
+	var likers []liker
+	for id := range s.db.received[actorID] {
+		entry, _ := s.db.decision(id, actorID)
+		if !entry.decision.Liked() || s.db.hidden(id) {
+			continue
+		}
+		if _, ok := s.db.decision(actorID, id); ok {
+			continue
+		}
+		likers = append(likers, liker{id: id, decisionEntry: entry})
+	}
+
+	slices.SortFunc(likers, func(a, b liker) int {
+		if a.decision != b.decision {
+			return cmp.Compare(b.decision, a.decision)
+		}
+		if a.timestamp != b.timestamp {
+			return cmp.Compare(b.timestamp, a.timestamp)
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+
+	ids := make([]domain.UserID, 0, min(uint64(len(likers)), limit))
+	for _, l := range likers {
+		if uint64(len(ids)) == limit {
+			break
+		}
+		ids = append(ids, l.id)
+	}
+
+	return ids, nil
+}
+
+// GetUndecidedUsers returns active users other than the actor whom the actor
+// hasn't decided on, ordered by ID.
+func (s *CandidateSource) GetUndecidedUsers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var ids []domain.UserID
+	for id, user := range s.db.users {
+		if id == actorID || user.Status != domain.UserStatusActive {
+			continue
+		}
+		if _, ok := s.db.decision(actorID, id); ok {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+	if uint64(len(ids)) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+func TestCandidateSource(t *testing.T) {
+	ctx := context.Background()
+	source := NewCandidateSource(NewDatabase())
+
+	source.AddUser("actor")
+	source.AddUser("lonely")
+	for _, d := range []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		timestamp        uint64
+	}{
+		{"liker1", "actor", domain.DecisionLike, 100},
+		{"liker2", "actor", domain.DecisionLike, 200},
+		{"liker3", "actor", domain.DecisionSuperLike, 50},
+		{"passer", "actor", domain.DecisionPass, 300},
+		{"matched", "actor", domain.DecisionLike, 400},
+		{"actor", "matched", domain.DecisionLike, 410},
+		{"actor", "skipped", domain.DecisionPass, 420},
+	} {
+		require.NoError(t, source.RecordDecision(ctx, d.actor, d.recipient, d.decision, d.timestamp))
+	}
+
+	likers, err := source.GetUndecidedLikers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker3", "liker2", "liker1"}, likers)
+
+	likers, err = source.GetUndecidedLikers(ctx, "actor", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker3", "liker2"}, likers)
+
+	users, err := source.GetUndecidedUsers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker1", "liker2", "liker3", "lonely", "passer"}, users)
+
+	users, err = source.GetUndecidedUsers(ctx, "actor", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker1", "liker2"}, users)
+
+	// Deciding on a liker removes them from both lists.
+	require.NoError(t, source.RecordDecision(ctx, "actor", "liker2", domain.DecisionPass, 500))
+
+	likers, err = source.GetUndecidedLikers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker3", "liker1"}, likers)
+
+	users, err = source.GetUndecidedUsers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.NotContains(t, users, "liker2")
+
+	// Users who aren't active are hidden from both lists.
+	source.SetUserStatus("liker3", domain.UserStatusBanned)
+	source.SetUserStatus("lonely", domain.UserStatusPaused)
+
+	likers, err = source.GetUndecidedLikers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker1"}, likers)
+
+	users, err = source.GetUndecidedUsers(ctx, "actor", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker1", "passer"}, users)
+}
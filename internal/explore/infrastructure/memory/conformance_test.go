@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"muzz-homework/internal/explore/infrastructure/conformance"
+	"testing"
+	"time"
+)
+
+func TestDecisionRepository_Conformance(t *testing.T) {
+	conformance.TestDecisionRepository(t, func(t *testing.T, likeLifetime time.Duration) conformance.Store {
+		db := NewDatabase()
+		users := NewUserRepository(db)
+
+		return conformance.Store{
+			Repo:  NewDecisionRepository(db, DecisionRepositoryConfig{LikeLifetime: likeLifetime}),
+			Flags: NewAbuseFlagRepository(db),
+			AddDecision: func(t *testing.T, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) {
+				db.RecordDecision(actorID, recipientID, decision, timestamp)
+			},
+			SetUserStatus: func(t *testing.T, userID domain.UserID, status domain.UserStatus) {
+				if err := users.UpsertUser(context.Background(), domain.User{ID: userID, Status: status}); err != nil {
+					t.Fatalf("upserting user: %v", err)
+				}
+			},
+		}
+	})
+}
+
+func TestCache_Conformance(t *testing.T) {
+	conformance.TestCache(t, func(t *testing.T, ttl time.Duration) conformance.CacheHarness {
+		cache := NewCache(CacheConfig{TTL: ttl})
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		return conformance.CacheHarness{
+			Cache:  cache,
+			Elapse: func(d time.Duration) { now = now.Add(d) },
+		}
+	})
+}
+
+func TestAbuseCounters_Conformance(t *testing.T) {
+	conformance.TestAbuseCounters(t, func(t *testing.T, window time.Duration, runGap time.Duration) conformance.AbuseCountersHarness {
+		counters := NewAbuseCounters(AbuseCountersConfig{Window: window, RunGap: runGap})
+		now := time.Now()
+		counters.now = func() time.Time { return now }
+
+		return conformance.AbuseCountersHarness{
+			Counters: counters,
+			Elapse:   func(d time.Duration) { now = now.Add(d) },
+		}
+	})
+}
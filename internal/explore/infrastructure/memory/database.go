@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"muzz-homework/internal/explore/domain"
+	"sync"
+)
+
+type decisionEntry struct {
+	decision  domain.Decision
+	timestamp uint64
+}
+
+// Database holds users, decisions, seen watermarks and abuse flags in memory. It is shared
+// by the in-memory repositories the way *sql.DB is shared by the Postgres
+// ones, so a decision saved through one is seen by the others.
+type Database struct {
+	mu    sync.RWMutex
+	users map[domain.UserID]domain.User
+	// decisions maps an actor to the recipients they decided on.
+	decisions map[domain.UserID]map[domain.UserID]decisionEntry
+	// received maps a recipient to the actors who decided on them.
+	received   map[domain.UserID]map[domain.UserID]struct{}
+	archive    []domain.DecisionRecord
+	watermarks map[domain.UserID]domain.SeenWatermark
+	flags      map[domain.UserID]domain.AbuseFlag
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		users:      make(map[domain.UserID]domain.User),
+		decisions:  make(map[domain.UserID]map[domain.UserID]decisionEntry),
+		received:   make(map[domain.UserID]map[domain.UserID]struct{}),
+		watermarks: make(map[domain.UserID]domain.SeenWatermark),
+		flags:      make(map[domain.UserID]domain.AbuseFlag),
+	}
+}
+
+// RecordDecision stores the actor's decision on the recipient with the given
+// timestamp, replacing any previous one. Unlike InsertDecision it doesn't use
+// the current time, which makes it the way to seed the database.
+func (d *Database) RecordDecision(actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.putDecision(actorID, recipientID, decisionEntry{decision: decision, timestamp: timestamp})
+}
+
+func (d *Database) putDecision(actorID domain.UserID, recipientID domain.UserID, entry decisionEntry) {
+	if d.decisions[actorID] == nil {
+		d.decisions[actorID] = make(map[domain.UserID]decisionEntry)
+	}
+	d.decisions[actorID][recipientID] = entry
+
+	if d.received[recipientID] == nil {
+		d.received[recipientID] = make(map[domain.UserID]struct{})
+	}
+	d.received[recipientID][actorID] = struct{}{}
+}
+
+func (d *Database) deleteDecision(actorID domain.UserID, recipientID domain.UserID) {
+	delete(d.decisions[actorID], recipientID)
+	if len(d.decisions[actorID]) == 0 {
+		delete(d.decisions, actorID)
+	}
+
+	delete(d.received[recipientID], actorID)
+	if len(d.received[recipientID]) == 0 {
+		delete(d.received, recipientID)
+	}
+}
+
+// decision returns the actor's decision on the recipient, if any.
+func (d *Database) decision(actorID domain.UserID, recipientID domain.UserID) (decisionEntry, bool) {
+	entry, ok := d.decisions[actorID][recipientID]
+	return entry, ok
+}
+
+// likedBack reports whether the recipient liked the actor back.
+func (d *Database) likedBack(actorID domain.UserID, recipientID domain.UserID) bool {
+	entry, ok := d.decision(recipientID, actorID)
+	return ok && entry.decision.Liked()
+}
+
+// hidden reports whether the user is known but not active, or is flagged for
+// abuse. Users without a row are listed, as in Postgres.
+func (d *Database) hidden(userID domain.UserID) bool {
+	if _, flagged := d.flags[userID]; flagged {
+		return true
+	}
+
+	user, ok := d.users[userID]
+	return ok && user.Status != domain.UserStatusActive
+}
@@ -0,0 +1,454 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+	"time"
+)
+
+// paginationLimit matches the Postgres repository's page size.
+const paginationLimit = 20
+
+type DecisionRepositoryConfig struct {
+	// LikeLifetime hides likes older than this from likers listings and
+	// counts. Zero keeps likes forever.
+	LikeLifetime time.Duration
+}
+
+// DecisionRepository serves decisions from an in-memory Database and behaves
+// like the Postgres repository: same filters, ordering, page size and
+// mutual detection.
+type DecisionRepository struct {
+	db     *Database
+	config DecisionRepositoryConfig
+}
+
+func NewDecisionRepository(db *Database, config DecisionRepositoryConfig) *DecisionRepository {
+	return &DecisionRepository{
+		db:     db,
+		config: config,
+	}
+}
+
+// InsertDecision upserts the decision and reports whether it completes a
+// mutual like, and the timestamp it was stored with. A super-like is checked against the quota, if given, under the
+// same lock.
+func (r *DecisionRepository) InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if decision == domain.DecisionSuperLike && quota != nil && r.superLikesSince(actorID, recipientID, quota.Since) >= quota.Limit {
+		return false, 0, domain.ErrSuperLikeQuotaExceeded
+	}
+
+	timestamp := uint64(time.Now().Unix())
+	r.db.putDecision(actorID, recipientID, decisionEntry{decision: decision, timestamp: timestamp})
+
+	return decision.Liked() && r.db.likedBack(actorID, recipientID), timestamp, nil
+}
+
+func (r *DecisionRepository) superLikesSince(actorID domain.UserID, excludeRecipientID domain.UserID, since uint64) uint64 {
+	var count uint64
+	for recipientID, entry := range r.db.decisions[actorID] {
+		if recipientID != excludeRecipientID && entry.decision == domain.DecisionSuperLike && entry.timestamp >= since {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *DecisionRepository) GetLikers(ctx context.Context, q domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	if !q.Filter.Valid() {
+		return nil, nil, domain.ErrInvalidFilter
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	cutoff, expires := r.expiryCutoff(q.IncludeExpired)
+
+	var likers []domain.LikerInfo
+	for actorID := range r.db.received[q.RecipientID] {
+		entry, _ := r.db.decision(actorID, q.RecipientID)
+		if !entry.decision.Liked() || r.db.hidden(actorID) {
+			continue
+		}
+		if !r.matchesFilter(q.RecipientID, actorID, q.Filter) {
+			continue
+		}
+		if q.SeenUpTo != nil && q.SeenUpTo.Covers(entry.timestamp, actorID) {
+			continue
+		}
+		if expires && entry.timestamp < cutoff {
+			continue
+		}
+		if q.Cursor != nil && !before(actorID, entry, *q.Cursor, q.SuperLikesFirst) {
+			continue
+		}
+
+		likers = append(likers, domain.LikerInfo{ActorID: actorID, Timestamp: entry.timestamp, Decision: entry.decision})
+	}
+
+	slices.SortFunc(likers, func(a, b domain.LikerInfo) int {
+		if q.SuperLikesFirst && a.Decision != b.Decision {
+			return cmp.Compare(b.Decision, a.Decision)
+		}
+		if a.Timestamp != b.Timestamp {
+			return cmp.Compare(b.Timestamp, a.Timestamp)
+		}
+		return cmp.Compare(b.ActorID, a.ActorID)
+	})
+
+	if len(likers) <= paginationLimit {
+		return likers, nil, nil
+	}
+
+	likers = likers[:paginationLimit]
+	last := likers[len(likers)-1]
+	next := &domain.Cursor{Timestamp: last.Timestamp, ActorID: last.ActorID}
+	if q.SuperLikesFirst {
+		next.Decision = last.Decision
+	}
+
+	return likers, next, nil
+}
+
+// matchesFilter applies the filter to the recipient's own decision about the
+// liker.
+func (r *DecisionRepository) matchesFilter(recipientID domain.UserID, likerID domain.UserID, filter domain.LikersFilter) bool {
+	reverse, decided := r.db.decision(recipientID, likerID)
+
+	switch filter {
+	case domain.LikersFilterPending:
+		return !decided
+	case domain.LikersFilterMatched:
+		return decided && reverse.decision.Liked()
+	case domain.LikersFilterRejected:
+		return decided && !reverse.decision.Liked()
+	default:
+		return !decided || reverse.decision.Liked()
+	}
+}
+
+// before reports whether the like sorts after the cursor's, i.e. belongs to a
+// later page.
+func before(actorID domain.UserID, entry decisionEntry, cursor domain.Cursor, superLikesFirst bool) bool {
+	if superLikesFirst && entry.decision != cursor.Decision {
+		return entry.decision < cursor.Decision
+	}
+
+	if entry.timestamp != cursor.Timestamp {
+		return entry.timestamp < cursor.Timestamp
+	}
+
+	return actorID < cursor.ActorID
+}
+
+func (r *DecisionRepository) GetLikersCount(ctx context.Context, q domain.LikersCountQuery) (uint64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	cutoff, expires := r.expiryCutoff(q.IncludeExpired)
+
+	var count uint64
+	for actorID := range r.db.received[q.RecipientID] {
+		entry, _ := r.db.decision(actorID, q.RecipientID)
+		if !entry.decision.Liked() || r.db.hidden(actorID) {
+			continue
+		}
+		if !r.matchesFilter(q.RecipientID, actorID, domain.LikersFilterAll) {
+			continue
+		}
+		if q.SeenUpTo != nil && q.SeenUpTo.Covers(entry.timestamp, actorID) {
+			continue
+		}
+		if expires && entry.timestamp < cutoff {
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// GetTopRecipients returns up to limit recipients with the most likes since
+// the given time, most liked first.
+func (r *DecisionRepository) GetTopRecipients(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	type recipient struct {
+		id    domain.UserID
+		likes int
+	}
+
+	var recipients []recipient
+	for recipientID, actors := range r.db.received {
+		likes := 0
+		for actorID := range actors {
+			if entry, _ := r.db.decision(actorID, recipientID); entry.decision.Liked() && entry.timestamp >= since {
+				likes++
+			}
+		}
+		if likes > 0 {
+			recipients = append(recipients, recipient{id: recipientID, likes: likes})
+		}
+	}
+
+	slices.SortFunc(recipients, func(a, b recipient) int {
+		if a.likes != b.likes {
+			return cmp.Compare(b.likes, a.likes)
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+
+	ids := make([]domain.UserID, 0, min(uint64(len(recipients)), limit))
+	for _, rec := range recipients[:min(uint64(len(recipients)), limit)] {
+		ids = append(ids, rec.id)
+	}
+
+	return ids, nil
+}
+
+// GetLikerIndex returns every like the recipient received from users who are
+// not hidden, expired ones too, and every decision the recipient made.
+func (r *DecisionRepository) GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	index := domain.LikerIndex{Decisions: make(map[domain.UserID]domain.Decision)}
+	for actorID := range r.db.received[recipientID] {
+		entry, _ := r.db.decision(actorID, recipientID)
+		if entry.decision.Liked() && !r.db.hidden(actorID) {
+			index.Likers = append(index.Likers, domain.LikerInfo{ActorID: actorID, Timestamp: entry.timestamp, Decision: entry.decision})
+		}
+	}
+	for userID, entry := range r.db.decisions[recipientID] {
+		index.Decisions[userID] = entry.decision
+	}
+
+	return index, nil
+}
+
+func (r *DecisionRepository) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.db.watermarks[recipientID], nil
+}
+
+// SetSeenWatermark moves the watermark forward and returns the stored one,
+// which is newer than seenUpTo if it had moved further already.
+func (r *DecisionRepository) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	watermark := r.db.watermarks[recipientID]
+	if watermark.Before(seenUpTo) {
+		watermark = seenUpTo
+		r.db.watermarks[recipientID] = watermark
+	}
+
+	return watermark, nil
+}
+
+// SweepExpiredLikes removes up to batchSize likes older than before,
+// archiving them first when archive is set. Likes that were liked back are
+// kept so that matches survive.
+func (r *DecisionRepository) SweepExpiredLikes(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	type like struct {
+		actorID, recipientID domain.UserID
+		entry                decisionEntry
+	}
+
+	var expired []like
+	for actorID, decided := range r.db.decisions {
+		for recipientID, entry := range decided {
+			if uint64(len(expired)) == batchSize {
+				break
+			}
+			if entry.decision.Liked() && entry.timestamp < before && !r.db.likedBack(actorID, recipientID) {
+				expired = append(expired, like{actorID: actorID, recipientID: recipientID, entry: entry})
+			}
+		}
+	}
+
+	for _, l := range expired {
+		r.db.deleteDecision(l.actorID, l.recipientID)
+		if archive {
+			r.db.archive = append(r.db.archive, domain.DecisionRecord{
+				ActorID:     l.actorID,
+				RecipientID: l.recipientID,
+				Decision:    l.entry.decision,
+				Timestamp:   l.entry.timestamp,
+				Archived:    true,
+			})
+		}
+	}
+
+	return int64(len(expired)), nil
+}
+
+func (r *DecisionRepository) expiryCutoff(includeExpired bool) (uint64, bool) {
+	if includeExpired || r.config.LikeLifetime <= 0 {
+		return 0, false
+	}
+
+	return uint64(time.Now().Add(-r.config.LikeLifetime).Unix()), true
+}
+
+// EraseUserDecisions deletes up to batchSize decisions made and up to
+// batchSize received by the user. It returns how many were deleted and the
+// recipients the user liked, whose counters need recounting.
+func (r *DecisionRepository) EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var deleted int64
+	var affectedRecipients []domain.UserID
+
+	var made []domain.UserID
+	for recipientID := range r.db.decisions[userID] {
+		if uint64(len(made)) == batchSize {
+			break
+		}
+		made = append(made, recipientID)
+	}
+	for _, recipientID := range made {
+		if entry, _ := r.db.decision(userID, recipientID); entry.decision.Liked() {
+			affectedRecipients = append(affectedRecipients, recipientID)
+		}
+		r.db.deleteDecision(userID, recipientID)
+		deleted++
+	}
+
+	var received []domain.UserID
+	for actorID := range r.db.received[userID] {
+		if uint64(len(received)) == batchSize {
+			break
+		}
+		received = append(received, actorID)
+	}
+	for _, actorID := range received {
+		r.db.deleteDecision(actorID, userID)
+		deleted++
+	}
+
+	return deleted, affectedRecipients, nil
+}
+
+// EraseUserMetadata deletes the user's archived decisions, seen watermark and
+// abuse flag, and keeps the user as deleted without a profile.
+func (r *DecisionRepository) EraseUserMetadata(ctx context.Context, userID domain.UserID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.archive = slices.DeleteFunc(r.db.archive, func(record domain.DecisionRecord) bool {
+		return record.ActorID == userID || record.RecipientID == userID
+	})
+	delete(r.db.watermarks, userID)
+	delete(r.db.flags, userID)
+
+	now := uint64(time.Now().Unix())
+	createdAt := now
+	if user, ok := r.db.users[userID]; ok {
+		createdAt = user.CreatedAt
+	}
+	r.db.users[userID] = domain.User{ID: userID, Status: domain.UserStatusDeleted, CreatedAt: createdAt, UpdatedAt: now}
+
+	return nil
+}
+
+// StreamUserDecisions calls fn with every decision made or received by the
+// user, archived ones last. The decisions are copied first so fn runs
+// without holding the database lock.
+func (r *DecisionRepository) StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+	r.db.mu.RLock()
+	var records []domain.DecisionRecord
+	for recipientID, entry := range r.db.decisions[userID] {
+		records = append(records, domain.DecisionRecord{ActorID: userID, RecipientID: recipientID, Decision: entry.decision, Timestamp: entry.timestamp})
+	}
+	for actorID := range r.db.received[userID] {
+		if actorID == userID {
+			continue
+		}
+		entry, _ := r.db.decision(actorID, userID)
+		records = append(records, domain.DecisionRecord{ActorID: actorID, RecipientID: userID, Decision: entry.decision, Timestamp: entry.timestamp})
+	}
+	for _, record := range r.db.archive {
+		if record.ActorID == userID || record.RecipientID == userID {
+			records = append(records, record)
+		}
+	}
+	r.db.mu.RUnlock()
+
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDecisions returns the decisions matching q, newest first.
+func (r *DecisionRepository) ListDecisions(ctx context.Context, q domain.DecisionsQuery) ([]domain.DecisionRecord, error) {
+	if q.ActorID == "" && q.RecipientID == "" {
+		return nil, fmt.Errorf("%w: actor or recipient is required", domain.ErrInvalidInput)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	matches := func(actorID, recipientID domain.UserID) bool {
+		return (q.ActorID == "" || actorID == q.ActorID) && (q.RecipientID == "" || recipientID == q.RecipientID)
+	}
+
+	var records []domain.DecisionRecord
+	for actorID, decided := range r.db.decisions {
+		for recipientID, entry := range decided {
+			if matches(actorID, recipientID) {
+				records = append(records, domain.DecisionRecord{
+					ActorID:     actorID,
+					RecipientID: recipientID,
+					Decision:    entry.decision,
+					Timestamp:   entry.timestamp,
+				})
+			}
+		}
+	}
+
+	if q.IncludeArchived {
+		for _, record := range r.db.archive {
+			if matches(record.ActorID, record.RecipientID) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	slices.SortFunc(records, func(a, b domain.DecisionRecord) int {
+		if a.Timestamp != b.Timestamp {
+			return cmp.Compare(b.Timestamp, a.Timestamp)
+		}
+		if a.Archived != b.Archived {
+			if a.Archived {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(a.ActorID, b.ActorID), cmp.Compare(a.RecipientID, b.RecipientID))
+	})
+
+	if q.Limit > 0 && uint64(len(records)) > q.Limit {
+		records = records[:q.Limit]
+	}
+
+	return records, nil
+}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+func TestDecisionRepository_EraseUserMetadata(t *testing.T) {
+	ctx := context.Background()
+	db := NewDatabase()
+	users := NewUserRepository(db)
+	repo := NewDecisionRepository(db, DecisionRepositoryConfig{})
+
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "erased", Status: domain.UserStatusActive, DisplayName: "Ann", PhotoURL: "https://example.com/ann.jpg", BirthDate: birthDate}))
+
+	require.NoError(t, repo.EraseUserMetadata(ctx, "erased"))
+	require.NoError(t, repo.EraseUserMetadata(ctx, "unprovisioned"))
+
+	profiles, err := users.GetProfiles(ctx, "erased", "unprovisioned")
+	require.NoError(t, err)
+	assert.Empty(t, profiles)
+
+	found, err := users.GetUsers(ctx, "erased", "unprovisioned")
+	require.NoError(t, err)
+	for _, id := range []domain.UserID{"erased", "unprovisioned"} {
+		user := found[id]
+		assert.Equal(t, domain.UserStatusDeleted, user.Status, id)
+		assert.Empty(t, user.DisplayName, id)
+		assert.Empty(t, user.PhotoURL, id)
+		assert.True(t, user.BirthDate.IsZero(), id)
+	}
+}
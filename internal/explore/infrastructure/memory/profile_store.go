@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"sync"
+)
+
+// ProfileStore keeps profiles in memory, for tests and local runs without
+// Postgres.
+type ProfileStore struct {
+	mu       sync.RWMutex
+	profiles map[domain.UserID]domain.Profile
+}
+
+func NewProfileStore() *ProfileStore {
+	return &ProfileStore{profiles: make(map[domain.UserID]domain.Profile)}
+}
+
+// SetProfile stores the profile under profile.UserID, replacing any previous
+// one.
+func (s *ProfileStore) SetProfile(profile domain.Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.profiles[profile.UserID] = profile
+}
+
+func (s *ProfileStore) DeleteProfile(userID domain.UserID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.profiles, userID)
+}
+
+// GetProfiles returns the profiles found among userIDs, keyed by ID.
+func (s *ProfileStore) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	profiles := make(map[domain.UserID]domain.Profile, len(userIDs))
+	for _, id := range userIDs {
+		if profile, ok := s.profiles[id]; ok {
+			profiles[id] = profile
+		}
+	}
+
+	return profiles, nil
+}
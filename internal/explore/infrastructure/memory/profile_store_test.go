@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+func TestProfileStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewProfileStore()
+
+	store.SetProfile(domain.Profile{UserID: "user1", Name: "Ann"})
+	store.SetProfile(domain.Profile{UserID: "user2", Name: "Bob"})
+	store.SetProfile(domain.Profile{UserID: "user1", Name: "Anna", PhotoURL: "https://example.com/anna.jpg"})
+	store.DeleteProfile("user2")
+
+	profiles, err := store.GetProfiles(ctx, "user1", "user2", "user3")
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.UserID]domain.Profile{
+		"user1": {UserID: "user1", Name: "Anna", PhotoURL: "https://example.com/anna.jpg"},
+	}, profiles)
+}
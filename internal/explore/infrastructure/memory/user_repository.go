@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+// UserRepository serves users from an in-memory Database and behaves like
+// the Postgres repository.
+type UserRepository struct {
+	db *Database
+}
+
+func NewUserRepository(db *Database) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// GetUsers returns the users found among userIDs, keyed by ID.
+func (r *UserRepository) GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	users := make(map[domain.UserID]domain.User, len(userIDs))
+	for _, id := range userIDs {
+		if user, ok := r.db.users[id]; ok {
+			users[id] = user
+		}
+	}
+
+	return users, nil
+}
+
+// GetProfiles returns the profiles of the active users among userIDs, keyed
+// by ID.
+func (r *UserRepository) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	profiles := make(map[domain.UserID]domain.Profile, len(userIDs))
+	for _, id := range userIDs {
+		if user, ok := r.db.users[id]; ok && user.Active() {
+			profiles[id] = domain.Profile{
+				UserID:    user.ID,
+				Name:      user.DisplayName,
+				PhotoURL:  user.PhotoURL,
+				BirthDate: user.BirthDate,
+			}
+		}
+	}
+
+	return profiles, nil
+}
+
+// UpsertUser creates the user or updates its status and attributes.
+func (r *UserRepository) UpsertUser(ctx context.Context, user domain.User) error {
+	if !user.Status.Valid() {
+		return fmt.Errorf("%w: unknown user status %q", domain.ErrInvalidInput, user.Status)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := uint64(time.Now().Unix())
+	user.CreatedAt, user.UpdatedAt = now, now
+	if existing, ok := r.db.users[user.ID]; ok && existing.CreatedAt != 0 {
+		user.CreatedAt = existing.CreatedAt
+	}
+	r.db.users[user.ID] = user
+
+	return nil
+}
+
+// SetUserStatus changes the user's status, creating a user who has none yet,
+// as they count as active until then.
+func (r *UserRepository) SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error {
+	if !status.Valid() {
+		return fmt.Errorf("%w: unknown user status %q", domain.ErrInvalidInput, status)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := uint64(time.Now().Unix())
+	user, ok := r.db.users[userID]
+	if !ok {
+		user = domain.User{ID: userID, CreatedAt: now}
+	}
+
+	user.Status = status
+	user.UpdatedAt = now
+	r.db.users[userID] = user
+
+	return nil
+}
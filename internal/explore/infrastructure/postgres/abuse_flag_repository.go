@@ -0,0 +1,106 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"muzz-homework/internal/explore/domain"
+)
+
+type abuseFlagRepository struct {
+	db *sql.DB
+	sq sq.StatementBuilderType
+}
+
+func NewAbuseFlagRepository(db *sql.DB) *abuseFlagRepository {
+	return &abuseFlagRepository{
+		db: db,
+		sq: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// FlagUser shadow-bans the user. A user already flagged keeps their first
+// flag, so the reason shown for review is the one that caught them.
+func (r *abuseFlagRepository) FlagUser(ctx context.Context, flag domain.AbuseFlag) error {
+	_, err := r.sq.Insert("abuse_flags").
+		Columns("user_id", "reason", "details", "flagged_at").
+		Values(flag.UserID, flag.Reason, flag.Details, flag.FlaggedAt).
+		Suffix("ON CONFLICT (user_id) DO NOTHING").
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("inserting abuse flag: %w", err)
+	}
+
+	return nil
+}
+
+// IsUserFlagged reports whether the user is shadow-banned.
+func (r *abuseFlagRepository) IsUserFlagged(ctx context.Context, userID domain.UserID) (bool, error) {
+	var flagged bool
+	err := r.sq.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("abuse_flags").
+		Where(sq.Eq{"user_id": userID}).
+		Suffix(")").
+		RunWith(r.db).
+		QueryRowContext(ctx).
+		Scan(&flagged)
+	if err != nil {
+		return false, fmt.Errorf("checking abuse flag: %w", err)
+	}
+
+	return flagged, nil
+}
+
+// ListAbuseFlags returns up to limit flags, most recent first.
+func (r *abuseFlagRepository) ListAbuseFlags(ctx context.Context, limit uint64) ([]domain.AbuseFlag, error) {
+	rows, err := r.sq.Select("user_id", "reason", "details", "flagged_at").
+		From("abuse_flags").
+		OrderBy("flagged_at DESC", "user_id").
+		Limit(limit).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting abuse flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []domain.AbuseFlag
+	for rows.Next() {
+		var flag domain.AbuseFlag
+		if err := rows.Scan(&flag.UserID, &flag.Reason, &flag.Details, &flag.FlaggedAt); err != nil {
+			return nil, fmt.Errorf("scanning abuse flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over abuse flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// ClearAbuseFlag lifts the user's shadow-ban.
+func (r *abuseFlagRepository) ClearAbuseFlag(ctx context.Context, userID domain.UserID) error {
+	result, err := r.sq.Delete("abuse_flags").
+		Where(sq.Eq{"user_id": userID}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting abuse flag: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting abuse flag: %w", err)
+	}
+
+	if deleted == 0 {
+		return domain.ErrAbuseFlagNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+type CandidateRepositoryConfig struct {
+	// LikeLifetime stops likes older than this from putting their actor
+	// ahead of other candidates. Zero keeps likes forever.
+	LikeLifetime time.Duration
+}
+
+type candidateRepository struct {
+	db     *sql.DB
+	sq     sq.StatementBuilderType
+	config CandidateRepositoryConfig
+}
+
+func NewCandidateRepository(db *sql.DB, config CandidateRepositoryConfig) *candidateRepository {
+	return &candidateRepository{
+		db:     db,
+		sq:     sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		config: config,
+	}
+}
+
+// GetUndecidedLikers returns users who liked the actor, aren't hidden and
+// whom the actor hasn't decided on, super-likes first and then newest first.
+func (r *candidateRepository) GetUndecidedLikers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	query := r.sq.Select("actor_user_id").
+		From("user_decisions").
+		Where(sq.Eq{"recipient_user_id": actorID, "liked_recipient": true}).
+		Where("NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = user_decisions.actor_user_id)", actorID).
+		Where(hiddenActor)
+
+	if r.config.LikeLifetime > 0 {
+		query = query.Where("decision_timestamp >= ?", time.Now().Add(-r.config.LikeLifetime).Unix())
+	}
+
+	rows, err := query.
+		OrderBy("decision DESC", "decision_timestamp DESC").
+		Limit(limit).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting undecided likers: %w", err)
+	}
+
+	return scanUserIDs(rows, "undecided likers")
+}
+
+// GetUndecidedUsers returns active users other than the actor whom the actor
+// hasn't decided on, ordered by ID.
+func (r *candidateRepository) GetUndecidedUsers(ctx context.Context, actorID domain.UserID, limit uint64) ([]domain.UserID, error) {
+	rows, err := r.sq.Select("user_id").
+		From("users").
+		Where(sq.Eq{"status": domain.UserStatusActive}).
+		Where(sq.NotEq{"user_id": actorID}).
+		Where("NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = users.user_id)", actorID).
+		OrderBy("user_id").
+		Limit(limit).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting undecided users: %w", err)
+	}
+
+	return scanUserIDs(rows, "undecided users")
+}
+
+func scanUserIDs(rows *sql.Rows, what string) ([]domain.UserID, error) {
+	defer rows.Close()
+
+	var userIDs []domain.UserID
+	for rows.Next() {
+		var userID domain.UserID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", what, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over %s: %w", what, err)
+	}
+
+	return userIDs, nil
+}
@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"muzz-homework/internal/explore/domain"
@@ -11,57 +12,159 @@ import (
 
 const paginationLimit = 20
 
+type DecisionRepositoryConfig struct {
+	// LikeLifetime hides likes older than this from likers listings and
+	// counts. Zero keeps likes forever.
+	LikeLifetime time.Duration
+}
+
 type decisionRepository struct {
-	db *sql.DB
-	sq sq.StatementBuilderType
+	db     *sql.DB
+	sq     sq.StatementBuilderType
+	config DecisionRepositoryConfig
 }
 
-func NewDecisionRepository(db *sql.DB) *decisionRepository {
+func NewDecisionRepository(db *sql.DB, config DecisionRepositoryConfig) *decisionRepository {
 	return &decisionRepository{
-		db: db,
-		sq: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		db:     db,
+		sq:     sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		config: config,
 	}
 }
 
-func (r *decisionRepository) InsertDecision(ctx context.Context, actorID string, recipientID string, liked bool) (bool, error) {
+// InsertDecision upserts the decision and reports whether it completes a
+// mutual like, and the timestamp it was stored with. Decisions between the
+// same two users take a transaction-scoped advisory lock on the pair first, so
+// two users liking each other at the same time can't both miss the other's
+// like. A super-like is checked against the quota, if given, in the same
+// transaction.
+func (r *decisionRepository) InsertDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, quota *domain.SuperLikeQuota) (bool, uint64, error) {
 	timestamp := uint64(time.Now().Unix())
 
-	_, err := r.sq.Insert("user_decisions").
-		Columns("actor_user_id", "recipient_user_id", "liked_recipient", "decision_timestamp").
-		Values(actorID, recipientID, liked, timestamp).
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("starting decision transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	first, second := actorID, recipientID
+	if second < first {
+		first, second = second, first
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))", first, second); err != nil {
+		return false, 0, fmt.Errorf("locking decision pair: %w", err)
+	}
+
+	if decision == domain.DecisionSuperLike && quota != nil {
+		if err := r.checkSuperLikeQuota(ctx, tx, actorID, recipientID, *quota); err != nil {
+			return false, 0, err
+		}
+	}
+
+	_, err = r.sq.Insert("user_decisions").
+		Columns("actor_user_id", "recipient_user_id", "decision", "decision_timestamp").
+		Values(actorID, recipientID, decision, timestamp).
 		Suffix(`
            ON CONFLICT (actor_user_id, recipient_user_id) 
            DO UPDATE SET 
-               liked_recipient = EXCLUDED.liked_recipient,
+               decision = EXCLUDED.decision,
                decision_timestamp = EXCLUDED.decision_timestamp`).
-		RunWith(r.db).
+		RunWith(tx).
 		ExecContext(ctx)
 
 	if err != nil {
-		return false, fmt.Errorf("inserting decision: %w", err)
+		return false, 0, fmt.Errorf("inserting decision: %w", err)
+	}
+
+	var mutual bool
+	if decision.Liked() {
+		err = r.sq.Select("1").
+			Prefix("SELECT EXISTS (").
+			From("user_decisions").
+			Where(sq.Eq{"actor_user_id": recipientID, "recipient_user_id": actorID, "liked_recipient": true}).
+			Suffix(")").
+			RunWith(tx).
+			QueryRowContext(ctx).
+			Scan(&mutual)
+		if err != nil {
+			return false, 0, fmt.Errorf("checking reverse like: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("committing decision: %w", err)
 	}
 
-	return true, nil
+	return mutual, timestamp, nil
 }
 
-func (r *decisionRepository) GetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-	query := r.sq.Select("actor_user_id", "decision_timestamp").
+// checkSuperLikeQuota counts the actor's super-likes under a
+// transaction-scoped advisory lock on the actor, held until the new one is
+// committed, so concurrent super-likes can't both take the last one left.
+func (r *decisionRepository) checkSuperLikeQuota(ctx context.Context, tx *sql.Tx, actorID domain.UserID, recipientID domain.UserID, quota domain.SuperLikeQuota) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('super_likes:' || $1))", actorID); err != nil {
+		return fmt.Errorf("locking super like quota: %w", err)
+	}
+
+	var used uint64
+	err := r.sq.Select("COUNT(*)").
 		From("user_decisions").
-		Where(sq.Eq{"recipient_user_id": recipientID, "liked_recipient": true})
+		Where(sq.Eq{"actor_user_id": actorID, "decision": domain.DecisionSuperLike}).
+		Where(sq.NotEq{"recipient_user_id": recipientID}).
+		Where("decision_timestamp >= ?", quota.Since).
+		RunWith(tx).
+		QueryRowContext(ctx).
+		Scan(&used)
 
-	if excludeMutual {
-		query = query.Where("NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
-			"ud2.actor_user_id = ? AND "+
-			"ud2.recipient_user_id = user_decisions.actor_user_id AND "+
-			"ud2.liked_recipient = true)", recipientID)
+	if err != nil {
+		return fmt.Errorf("counting super likes: %w", err)
+	}
+
+	if used >= quota.Limit {
+		return domain.ErrSuperLikeQuotaExceeded
+	}
+
+	return nil
+}
+
+func (r *decisionRepository) GetLikers(ctx context.Context, q domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	query := r.sq.Select("actor_user_id", "decision_timestamp", "decision").
+		From("user_decisions").
+		Where(sq.Eq{"recipient_user_id": q.RecipientID, "liked_recipient": true}).
+		Where(hiddenActor)
+
+	filter, err := likersFilter(q.Filter, q.RecipientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	query = query.Where(filter)
+
+	// Actor IDs break ties between likes given in the same second. They are
+	// compared bytewise, as the liker index and the in-memory repository do.
+	if q.Cursor != nil {
+		if q.SuperLikesFirst {
+			query = query.Where(`(decision, decision_timestamp, actor_user_id COLLATE "C") < (?, ?, ?)`,
+				q.Cursor.Decision, q.Cursor.Timestamp, q.Cursor.ActorID)
+		} else {
+			query = query.Where(`(decision_timestamp, actor_user_id COLLATE "C") < (?, ?)`, q.Cursor.Timestamp, q.Cursor.ActorID)
+		}
 	}
 
-	if timestamp != nil {
-		query = query.Where("decision_timestamp < ?", *timestamp)
+	if q.SeenUpTo != nil {
+		query = query.Where(`(decision_timestamp, actor_user_id COLLATE "C") > (?, ?)`, q.SeenUpTo.Timestamp, q.SeenUpTo.ActorID)
 	}
 
-	query = query.OrderBy("decision_timestamp DESC").
-		Limit(paginationLimit + 1)
+	if cutoff, ok := r.expiryCutoff(q.IncludeExpired); ok {
+		query = query.Where("decision_timestamp >= ?", cutoff)
+	}
+
+	if q.SuperLikesFirst {
+		query = query.OrderBy("decision DESC", "decision_timestamp DESC", `actor_user_id COLLATE "C" DESC`)
+	} else {
+		query = query.OrderBy("decision_timestamp DESC", `actor_user_id COLLATE "C" DESC`)
+	}
+
+	query = query.Limit(paginationLimit + 1)
 
 	rows, err := query.RunWith(r.db).QueryContext(ctx)
 	if err != nil {
@@ -70,18 +173,21 @@ func (r *decisionRepository) GetLikers(ctx context.Context, recipientID string,
 	defer rows.Close()
 
 	var likers []domain.LikerInfo
-	var lastTimestamp uint64
+	var lastCursor domain.Cursor
 	var hasMore bool
 
 	for rows.Next() {
 		var liker domain.LikerInfo
-		if err := rows.Scan(&liker.ActorID, &liker.Timestamp); err != nil {
+		if err := rows.Scan(&liker.ActorID, &liker.Timestamp, &liker.Decision); err != nil {
 			return nil, nil, fmt.Errorf("scanning liker: %w", err)
 		}
 
 		if len(likers) < paginationLimit {
 			likers = append(likers, liker)
-			lastTimestamp = liker.Timestamp
+			lastCursor = domain.Cursor{Timestamp: liker.Timestamp, ActorID: liker.ActorID}
+			if q.SuperLikesFirst {
+				lastCursor.Decision = liker.Decision
+			}
 		} else {
 			hasMore = true
 			break
@@ -92,24 +198,42 @@ func (r *decisionRepository) GetLikers(ctx context.Context, recipientID string,
 		return nil, nil, fmt.Errorf("iterating over likers: %w", err)
 	}
 
-	var nextTimestamp *uint64
+	var nextCursor *domain.Cursor
 	if hasMore {
-		nextTimestamp = &lastTimestamp
+		nextCursor = &lastCursor
 	}
 
-	return likers, nextTimestamp, nil
+	return likers, nextCursor, nil
 }
 
-func (r *decisionRepository) GetLikersCount(ctx context.Context, recipientID string) (uint64, error) {
+func (r *decisionRepository) GetLikersCount(ctx context.Context, q domain.LikersCountQuery) (uint64, error) {
 	var count uint64
 
-	err := r.sq.Select("COUNT(*)").
+	query := r.sq.Select("COUNT(*)").
 		From("user_decisions").
 		Where(sq.Eq{
-			"recipient_user_id": recipientID,
+			"recipient_user_id": q.RecipientID,
 			"liked_recipient":   true,
 		}).
-		RunWith(r.db).
+		Where(hiddenActor)
+
+	// Counts match the "all" listing, which hides likers the recipient
+	// passed on.
+	filter, err := likersFilter(domain.LikersFilterAll, q.RecipientID)
+	if err != nil {
+		return 0, err
+	}
+	query = query.Where(filter)
+
+	if q.SeenUpTo != nil {
+		query = query.Where(`(decision_timestamp, actor_user_id COLLATE "C") > (?, ?)`, q.SeenUpTo.Timestamp, q.SeenUpTo.ActorID)
+	}
+
+	if cutoff, ok := r.expiryCutoff(q.IncludeExpired); ok {
+		query = query.Where("decision_timestamp >= ?", cutoff)
+	}
+
+	err = query.RunWith(r.db).
 		QueryRowContext(ctx).
 		Scan(&count)
 
@@ -119,3 +243,407 @@ func (r *decisionRepository) GetLikersCount(ctx context.Context, recipientID str
 
 	return count, nil
 }
+
+// likersFilter returns the condition selecting likers by the recipient's own
+// decision about them. Each is a single probe of the primary key.
+func likersFilter(filter domain.LikersFilter, recipientID domain.UserID) (sq.Sqlizer, error) {
+	switch filter {
+	case domain.LikersFilterAll:
+		return sq.Expr("NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = user_decisions.actor_user_id AND "+
+			"ud2.liked_recipient = false)", recipientID), nil
+	case domain.LikersFilterPending:
+		return sq.Expr("NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = user_decisions.actor_user_id)", recipientID), nil
+	case domain.LikersFilterMatched:
+		return sq.Expr("EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = user_decisions.actor_user_id AND "+
+			"ud2.liked_recipient = true)", recipientID), nil
+	case domain.LikersFilterRejected:
+		return sq.Expr("EXISTS (SELECT 1 FROM user_decisions ud2 WHERE "+
+			"ud2.actor_user_id = ? AND "+
+			"ud2.recipient_user_id = user_decisions.actor_user_id AND "+
+			"ud2.liked_recipient = false)", recipientID), nil
+	default:
+		return nil, domain.ErrInvalidFilter
+	}
+}
+
+// GetTopRecipients returns up to limit recipients with the most likes
+// received since the given time, busiest first.
+func (r *decisionRepository) GetTopRecipients(ctx context.Context, since uint64, limit uint64) ([]domain.UserID, error) {
+	rows, err := r.sq.Select("recipient_user_id").
+		From("user_decisions").
+		Where(sq.Eq{"liked_recipient": true}).
+		Where("decision_timestamp >= ?", since).
+		GroupBy("recipient_user_id").
+		OrderBy("COUNT(*) DESC").
+		Limit(limit).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting top recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []domain.UserID
+	for rows.Next() {
+		var recipientID domain.UserID
+		if err := rows.Scan(&recipientID); err != nil {
+			return nil, fmt.Errorf("scanning top recipient: %w", err)
+		}
+		recipients = append(recipients, recipientID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over top recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// GetLikerIndex loads every like the recipient received from users who are
+// not hidden, expired ones too, and every decision the recipient made.
+func (r *decisionRepository) GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error) {
+	index := domain.LikerIndex{Decisions: make(map[domain.UserID]domain.Decision)}
+
+	rows, err := r.sq.Select("actor_user_id", "decision_timestamp", "decision").
+		From("user_decisions").
+		Where(sq.Eq{"recipient_user_id": recipientID, "liked_recipient": true}).
+		Where(hiddenActor).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return index, fmt.Errorf("selecting likers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var liker domain.LikerInfo
+		if err := rows.Scan(&liker.ActorID, &liker.Timestamp, &liker.Decision); err != nil {
+			return index, fmt.Errorf("scanning liker: %w", err)
+		}
+		index.Likers = append(index.Likers, liker)
+	}
+
+	if err = rows.Err(); err != nil {
+		return index, fmt.Errorf("iterating over likers: %w", err)
+	}
+	rows.Close()
+
+	decisions, err := r.sq.Select("recipient_user_id", "decision").
+		From("user_decisions").
+		Where(sq.Eq{"actor_user_id": recipientID}).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return index, fmt.Errorf("selecting own decisions: %w", err)
+	}
+	defer decisions.Close()
+
+	for decisions.Next() {
+		var userID domain.UserID
+		var decision domain.Decision
+		if err := decisions.Scan(&userID, &decision); err != nil {
+			return index, fmt.Errorf("scanning own decision: %w", err)
+		}
+		index.Decisions[userID] = decision
+	}
+
+	if err = decisions.Err(); err != nil {
+		return index, fmt.Errorf("iterating over own decisions: %w", err)
+	}
+
+	return index, nil
+}
+
+func (r *decisionRepository) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	var seenUpTo domain.SeenWatermark
+
+	err := r.sq.Select("seen_up_to", "seen_actor_user_id").
+		From("liker_seen_watermarks").
+		Where(sq.Eq{"recipient_user_id": recipientID}).
+		RunWith(r.db).
+		QueryRowContext(ctx).
+		Scan(&seenUpTo.Timestamp, &seenUpTo.ActorID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.SeenWatermark{}, nil
+	}
+
+	if err != nil {
+		return domain.SeenWatermark{}, fmt.Errorf("selecting seen watermark: %w", err)
+	}
+
+	return seenUpTo, nil
+}
+
+// SetSeenWatermark moves the watermark forward and returns the stored one,
+// which is newer than seenUpTo if it had moved further already. Watermarks
+// are ordered like likers pages, by timestamp and then bytewise by actor.
+func (r *decisionRepository) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) (domain.SeenWatermark, error) {
+	var watermark domain.SeenWatermark
+
+	err := r.sq.Insert("liker_seen_watermarks").
+		Columns("recipient_user_id", "seen_up_to", "seen_actor_user_id").
+		Values(recipientID, seenUpTo.Timestamp, seenUpTo.ActorID).
+		Suffix(`
+           ON CONFLICT (recipient_user_id)
+           DO UPDATE SET
+               (seen_up_to, seen_actor_user_id) = (
+                   CASE WHEN (EXCLUDED.seen_up_to, EXCLUDED.seen_actor_user_id COLLATE "C")
+                           > (liker_seen_watermarks.seen_up_to, liker_seen_watermarks.seen_actor_user_id COLLATE "C")
+                       THEN EXCLUDED.seen_up_to ELSE liker_seen_watermarks.seen_up_to END,
+                   CASE WHEN (EXCLUDED.seen_up_to, EXCLUDED.seen_actor_user_id COLLATE "C")
+                           > (liker_seen_watermarks.seen_up_to, liker_seen_watermarks.seen_actor_user_id COLLATE "C")
+                       THEN EXCLUDED.seen_actor_user_id ELSE liker_seen_watermarks.seen_actor_user_id END
+               )
+           RETURNING seen_up_to, seen_actor_user_id`).
+		RunWith(r.db).
+		QueryRowContext(ctx).
+		Scan(&watermark.Timestamp, &watermark.ActorID)
+
+	if err != nil {
+		return domain.SeenWatermark{}, fmt.Errorf("upserting seen watermark: %w", err)
+	}
+
+	return watermark, nil
+}
+
+// SweepExpiredLikes removes up to batchSize likes older than before, archiving
+// them first when archive is set. Likes that were liked back are kept so that
+// matches survive. Rows locked by concurrent writers are skipped rather than
+// waited on, so a batch never holds locks for long.
+func (r *decisionRepository) SweepExpiredLikes(ctx context.Context, before uint64, batchSize uint64, archive bool) (int64, error) {
+	deleteExpired := `
+           DELETE FROM user_decisions
+           WHERE ctid IN (
+               SELECT ud.ctid FROM user_decisions ud
+               WHERE ud.liked_recipient = true
+                 AND ud.decision_timestamp < $1
+                 AND NOT EXISTS (SELECT 1 FROM user_decisions ud2 WHERE
+                     ud2.actor_user_id = ud.recipient_user_id AND
+                     ud2.recipient_user_id = ud.actor_user_id AND
+                     ud2.liked_recipient = true)
+               LIMIT $2
+               FOR UPDATE SKIP LOCKED)`
+
+	var result sql.Result
+	var err error
+	if archive {
+		result, err = r.db.ExecContext(ctx, `
+           WITH expired AS (`+deleteExpired+`
+               RETURNING actor_user_id, recipient_user_id, decision, decision_timestamp)
+           INSERT INTO user_decisions_archive (actor_user_id, recipient_user_id, decision, decision_timestamp, archived_at)
+           SELECT actor_user_id, recipient_user_id, decision, decision_timestamp, $3 FROM expired`,
+			before, batchSize, time.Now().Unix())
+	} else {
+		result, err = r.db.ExecContext(ctx, deleteExpired, before, batchSize)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("sweeping expired likes: %w", err)
+	}
+
+	swept, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("reading swept rows: %w", err)
+	}
+
+	return swept, nil
+}
+
+func (r *decisionRepository) expiryCutoff(includeExpired bool) (uint64, bool) {
+	if includeExpired || r.config.LikeLifetime <= 0 {
+		return 0, false
+	}
+
+	return uint64(time.Now().Add(-r.config.LikeLifetime).Unix()), true
+}
+
+// EraseUserDecisions deletes one batch of decisions made or received by the
+// user. It returns how many rows were deleted and the recipients whose likers
+// changed, so their counters can be recounted.
+func (r *decisionRepository) EraseUserDecisions(ctx context.Context, userID domain.UserID, batchSize uint64) (int64, []domain.UserID, error) {
+	rows, err := r.db.QueryContext(ctx, `
+           DELETE FROM user_decisions
+           WHERE ctid IN (
+               (SELECT ctid FROM user_decisions WHERE actor_user_id = $1 LIMIT $2)
+               UNION ALL
+               (SELECT ctid FROM user_decisions WHERE recipient_user_id = $1 LIMIT $2))
+           RETURNING actor_user_id, recipient_user_id, liked_recipient`,
+		userID, batchSize)
+	if err != nil {
+		return 0, nil, fmt.Errorf("erasing user decisions: %w", err)
+	}
+	defer rows.Close()
+
+	var deleted int64
+	var affectedRecipients []domain.UserID
+	for rows.Next() {
+		var actorID, recipientID domain.UserID
+		var liked bool
+		if err := rows.Scan(&actorID, &recipientID, &liked); err != nil {
+			return 0, nil, fmt.Errorf("scanning erased decision: %w", err)
+		}
+
+		deleted++
+		if actorID == userID && liked {
+			affectedRecipients = append(affectedRecipients, recipientID)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("iterating over erased decisions: %w", err)
+	}
+
+	return deleted, affectedRecipients, nil
+}
+
+// EraseUserMetadata deletes the user's archived decisions, seen watermark and
+// abuse flag, and anonymizes the user: the row is kept as deleted, so the
+// user stays rejected and hidden, but their profile is dropped.
+func (r *decisionRepository) EraseUserMetadata(ctx context.Context, userID domain.UserID) error {
+	_, err := r.sq.Delete("user_decisions_archive").
+		Where(sq.Or{sq.Eq{"actor_user_id": userID}, sq.Eq{"recipient_user_id": userID}}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("erasing archived decisions: %w", err)
+	}
+
+	_, err = r.sq.Delete("liker_seen_watermarks").
+		Where(sq.Eq{"recipient_user_id": userID}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("erasing seen watermark: %w", err)
+	}
+
+	_, err = r.sq.Delete("abuse_flags").
+		Where(sq.Eq{"user_id": userID}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("erasing abuse flag: %w", err)
+	}
+
+	now := time.Now().Unix()
+	_, err = r.sq.Insert("users").
+		Columns("user_id", "status", "created_at", "updated_at").
+		Values(userID, domain.UserStatusDeleted, now, now).
+		Suffix(`
+           ON CONFLICT (user_id)
+           DO UPDATE SET
+               status = EXCLUDED.status,
+               display_name = '',
+               photo_url = '',
+               birth_date = NULL,
+               updated_at = EXCLUDED.updated_at`).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("anonymizing user: %w", err)
+	}
+
+	return nil
+}
+
+// ListDecisions returns the decisions matching q, newest first.
+func (r *decisionRepository) ListDecisions(ctx context.Context, q domain.DecisionsQuery) ([]domain.DecisionRecord, error) {
+	if q.ActorID == "" && q.RecipientID == "" {
+		return nil, fmt.Errorf("%w: actor or recipient is required", domain.ErrInvalidInput)
+	}
+
+	where := sq.Eq{}
+	if q.ActorID != "" {
+		where["actor_user_id"] = q.ActorID
+	}
+	if q.RecipientID != "" {
+		where["recipient_user_id"] = q.RecipientID
+	}
+
+	// Both halves use ? placeholders, numbered once the union is assembled.
+	columns := []string{"actor_user_id", "recipient_user_id", "decision", "decision_timestamp"}
+	query, args, err := sq.Select(append(columns, "false AS archived")...).From("user_decisions").Where(where).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("building decisions query: %w", err)
+	}
+
+	if q.IncludeArchived {
+		archived, archivedArgs, err := sq.Select(append(columns, "true")...).From("user_decisions_archive").Where(where).ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("building decisions query: %w", err)
+		}
+		query += " UNION ALL " + archived
+		args = append(args, archivedArgs...)
+	}
+
+	query += " ORDER BY decision_timestamp DESC, archived"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	query, err = sq.Dollar.ReplacePlaceholders(query)
+	if err != nil {
+		return nil, fmt.Errorf("building decisions query: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("selecting decisions: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.DecisionRecord
+	for rows.Next() {
+		var record domain.DecisionRecord
+		if err := rows.Scan(&record.ActorID, &record.RecipientID, &record.Decision, &record.Timestamp, &record.Archived); err != nil {
+			return nil, fmt.Errorf("scanning decision: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over decisions: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *decisionRepository) StreamUserDecisions(ctx context.Context, userID domain.UserID, fn func(domain.DecisionRecord) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+           SELECT actor_user_id, recipient_user_id, decision, decision_timestamp, false
+           FROM user_decisions
+           WHERE actor_user_id = $1 OR recipient_user_id = $1
+           UNION ALL
+           SELECT actor_user_id, recipient_user_id, decision, decision_timestamp, true
+           FROM user_decisions_archive
+           WHERE actor_user_id = $1 OR recipient_user_id = $1`,
+		userID)
+	if err != nil {
+		return fmt.Errorf("selecting user decisions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record domain.DecisionRecord
+		if err := rows.Scan(&record.ActorID, &record.RecipientID, &record.Decision, &record.Timestamp, &record.Archived); err != nil {
+			return fmt.Errorf("scanning user decision: %w", err)
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("iterating over user decisions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+func TestDecisionRepository_InsertDecision(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	insert := func(actor, recipient string, decision domain.Decision) scriptedStatement {
+		return scriptedStatement{
+			query: "INSERT INTO user_decisions",
+			args:  []driver.Value{actor, recipient, int64(decision), anyValue{}},
+		}
+	}
+	reverseLike := func(actor, recipient string, liked bool) scriptedStatement {
+		return scriptedStatement{
+			query:   "SELECT EXISTS ( SELECT 1 FROM user_decisions",
+			args:    []driver.Value{recipient, true, actor},
+			columns: []string{"exists"},
+			rows:    [][]driver.Value{{liked}},
+		}
+	}
+
+	quotaLock := func(actor string) scriptedStatement {
+		return scriptedStatement{query: "hashtext('super_likes:' || $1)", args: []driver.Value{actor}}
+	}
+	superLikesUsed := func(actor, recipient string, used int64) scriptedStatement {
+		return scriptedStatement{
+			query:   "SELECT COUNT(*) FROM user_decisions",
+			args:    []driver.Value{actor, int64(domain.DecisionSuperLike), recipient, int64(100)},
+			columns: []string{"count"},
+			rows:    [][]driver.Value{{used}},
+		}
+	}
+	quota := &domain.SuperLikeQuota{Limit: 3, Since: 100}
+
+	tests := []struct {
+		name      string
+		actor     string
+		recipient string
+		decision  domain.Decision
+		quota     *domain.SuperLikeQuota
+		script    []scriptedStatement
+		want      bool
+		wantErr   string
+	}{
+		{
+			name:      "like completing a match",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				insert("alice", "bob", domain.DecisionLike),
+				reverseLike("alice", "bob", true),
+				{query: "COMMIT"},
+			},
+			want: true,
+		},
+		{
+			name:      "super like without a reverse like",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionSuperLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				insert("alice", "bob", domain.DecisionSuperLike),
+				reverseLike("alice", "bob", false),
+				{query: "COMMIT"},
+			},
+			want: false,
+		},
+		{
+			name:      "pass skips the reverse like check",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionPass,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				insert("alice", "bob", domain.DecisionPass),
+				{query: "COMMIT"},
+			},
+			want: false,
+		},
+		{
+			name:      "pair is locked in the same order from both sides",
+			actor:     "bob",
+			recipient: "alice",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				insert("bob", "alice", domain.DecisionLike),
+				reverseLike("bob", "alice", true),
+				{query: "COMMIT"},
+			},
+			want: true,
+		},
+		{
+			name:      "super like within the quota",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionSuperLike,
+			quota:     quota,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				quotaLock("alice"),
+				superLikesUsed("alice", "bob", 2),
+				insert("alice", "bob", domain.DecisionSuperLike),
+				reverseLike("alice", "bob", false),
+				{query: "COMMIT"},
+			},
+			want: false,
+		},
+		{
+			name:      "super like over the quota rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionSuperLike,
+			quota:     quota,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				quotaLock("alice"),
+				superLikesUsed("alice", "bob", 3),
+				{query: "ROLLBACK"},
+			},
+			wantErr: domain.ErrSuperLikeQuotaExceeded.Error(),
+		},
+		{
+			name:      "like skips the quota",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			quota:     quota,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", args: []driver.Value{"alice", "bob"}},
+				insert("alice", "bob", domain.DecisionLike),
+				reverseLike("alice", "bob", false),
+				{query: "COMMIT"},
+			},
+			want: false,
+		},
+		{
+			name:      "begin error",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN", err: errDB},
+			},
+			wantErr: "starting decision transaction",
+		},
+		{
+			name:      "lock error rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock", err: errDB},
+				{query: "ROLLBACK"},
+			},
+			wantErr: "locking decision pair",
+		},
+		{
+			name:      "quota lock error rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionSuperLike,
+			quota:     quota,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock"},
+				{query: "super_likes:", err: errDB},
+				{query: "ROLLBACK"},
+			},
+			wantErr: "locking super like quota",
+		},
+		{
+			name:      "quota count error rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionSuperLike,
+			quota:     quota,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock"},
+				quotaLock("alice"),
+				{query: "SELECT COUNT(*)", err: errDB},
+				{query: "ROLLBACK"},
+			},
+			wantErr: "counting super likes",
+		},
+		{
+			name:      "insert error rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock"},
+				{query: "INSERT INTO user_decisions", err: errDB},
+				{query: "ROLLBACK"},
+			},
+			wantErr: "inserting decision",
+		},
+		{
+			name:      "reverse like error rolls back",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionLike,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock"},
+				insert("alice", "bob", domain.DecisionLike),
+				{query: "SELECT EXISTS", err: errDB},
+				{query: "ROLLBACK"},
+			},
+			wantErr: "checking reverse like",
+		},
+		{
+			name:      "commit error",
+			actor:     "alice",
+			recipient: "bob",
+			decision:  domain.DecisionPass,
+			script: []scriptedStatement{
+				{query: "BEGIN"},
+				{query: "pg_advisory_xact_lock"},
+				insert("alice", "bob", domain.DecisionPass),
+				{query: "COMMIT", err: errDB},
+			},
+			wantErr: "committing decision",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewDecisionRepository(newScriptedDB(t, tt.script...), DecisionRepositoryConfig{})
+
+			mutual, _, err := repo.InsertDecision(context.Background(), domain.UserID(tt.actor), domain.UserID(tt.recipient), tt.decision, tt.quota)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				if !errors.Is(err, domain.ErrSuperLikeQuotaExceeded) {
+					assert.ErrorIs(t, err, errDB)
+				}
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mutual)
+		})
+	}
+}
+
+func TestDecisionRepository_EraseUserMetadata(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	statements := []scriptedStatement{
+		{query: "DELETE FROM user_decisions_archive", args: []driver.Value{"alice", "alice"}},
+		{query: "DELETE FROM liker_seen_watermarks", args: []driver.Value{"alice"}},
+		{query: "DELETE FROM abuse_flags", args: []driver.Value{"alice"}},
+		{
+			query: "INSERT INTO users (user_id,status,created_at,updated_at) VALUES ($1,$2,$3,$4) " +
+				"ON CONFLICT (user_id) DO UPDATE SET status = EXCLUDED.status, display_name = '', photo_url = '', birth_date = NULL",
+			args: []driver.Value{"alice", string(domain.UserStatusDeleted), anyValue{}, anyValue{}},
+		},
+	}
+
+	t.Run("anonymizes the user", func(t *testing.T) {
+		repo := NewDecisionRepository(newScriptedDB(t, statements...), DecisionRepositoryConfig{})
+
+		require.NoError(t, repo.EraseUserMetadata(context.Background(), "alice"))
+	})
+
+	t.Run("anonymize error", func(t *testing.T) {
+		script := append([]scriptedStatement{}, statements[:3]...)
+		script = append(script, scriptedStatement{query: "INSERT INTO users", err: errDB})
+		repo := NewDecisionRepository(newScriptedDB(t, script...), DecisionRepositoryConfig{})
+
+		err := repo.EraseUserMetadata(context.Background(), "alice")
+		assert.ErrorIs(t, err, errDB)
+		assert.ErrorContains(t, err, "anonymizing user")
+	})
+}
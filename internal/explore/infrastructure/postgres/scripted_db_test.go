@@ -0,0 +1,159 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// anyValue matches any argument of a scripted statement.
+type anyValue struct{}
+
+// scriptedStatement is one statement a test expects the repository to run.
+// BEGIN, COMMIT and ROLLBACK are scripted like any other statement.
+type scriptedStatement struct {
+	// query must be contained in the statement that is run, with runs of
+	// whitespace read as a single space.
+	query string
+	// args are compared with the statement's arguments unless nil.
+	args    []driver.Value
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+// scriptedDB is a database/sql driver that plays back a script of expected
+// statements, so repository methods can be tested without Postgres.
+type scriptedDB struct {
+	t      *testing.T
+	mu     sync.Mutex
+	script []scriptedStatement
+}
+
+// newScriptedDB returns a database that expects exactly the given statements,
+// in order.
+func newScriptedDB(t *testing.T, script ...scriptedStatement) *sql.DB {
+	s := &scriptedDB{t: t, script: script}
+	db := sql.OpenDB(s)
+	t.Cleanup(func() {
+		db.Close()
+		if len(s.script) > 0 {
+			t.Errorf("%d scripted statements were not run, next: %q", len(s.script), s.script[0].query)
+		}
+	})
+	return db
+}
+
+func (s *scriptedDB) next(query string, args []driver.NamedValue) scriptedStatement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.script) == 0 {
+		s.t.Errorf("unexpected statement %q", query)
+		return scriptedStatement{err: errors.New("unexpected statement")}
+	}
+	statement := s.script[0]
+	s.script = s.script[1:]
+
+	// Runs of whitespace match a single space, so multi-line SQL can be
+	// expected on one line.
+	if !strings.Contains(strings.Join(strings.Fields(query), " "), statement.query) {
+		s.t.Errorf("expected statement containing %q, got %q", statement.query, query)
+	}
+	if statement.args != nil {
+		if len(args) != len(statement.args) {
+			s.t.Errorf("statement %q: expected %d args, got %d", statement.query, len(statement.args), len(args))
+			return statement
+		}
+		for i, arg := range args {
+			if _, ok := statement.args[i].(anyValue); !ok && arg.Value != statement.args[i] {
+				s.t.Errorf("statement %q: arg %d is %v, expected %v", statement.query, i+1, arg.Value, statement.args[i])
+			}
+		}
+	}
+	return statement
+}
+
+func (s *scriptedDB) Connect(context.Context) (driver.Conn, error) {
+	return &scriptedConn{db: s}, nil
+}
+
+func (s *scriptedDB) Driver() driver.Driver {
+	return scriptedDriver{}
+}
+
+type scriptedDriver struct{}
+
+func (scriptedDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("scripted driver is opened through sql.OpenDB")
+}
+
+type scriptedConn struct {
+	db *scriptedDB
+}
+
+func (c *scriptedConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not scripted")
+}
+
+func (c *scriptedConn) Close() error {
+	return nil
+}
+
+func (c *scriptedConn) Begin() (driver.Tx, error) {
+	if err := c.db.next("BEGIN", nil).err; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *scriptedConn) Commit() error {
+	return c.db.next("COMMIT", nil).err
+}
+
+func (c *scriptedConn) Rollback() error {
+	return c.db.next("ROLLBACK", nil).err
+}
+
+func (c *scriptedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	statement := c.db.next(query, args)
+	if statement.err != nil {
+		return nil, statement.err
+	}
+	return driver.RowsAffected(len(statement.rows)), nil
+}
+
+func (c *scriptedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	statement := c.db.next(query, args)
+	if statement.err != nil {
+		return nil, statement.err
+	}
+	return &scriptedRows{columns: statement.columns, rows: statement.rows}, nil
+}
+
+type scriptedRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *scriptedRows) Columns() []string {
+	return r.columns
+}
+
+func (r *scriptedRows) Close() error {
+	return nil
+}
+
+func (r *scriptedRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+// hiddenActor excludes rows whose actor is known but not active, or is
+// flagged for abuse. Users without a row are listed, so decisions made before
+// a user was provisioned don't disappear.
+const hiddenActor = "NOT EXISTS (SELECT 1 FROM users u WHERE " +
+	"u.user_id = user_decisions.actor_user_id AND " +
+	"u.status <> 'active') AND " +
+	"NOT EXISTS (SELECT 1 FROM abuse_flags f WHERE " +
+	"f.user_id = user_decisions.actor_user_id)"
+
+type userRepository struct {
+	db *sql.DB
+	sq sq.StatementBuilderType
+}
+
+func NewUserRepository(db *sql.DB) *userRepository {
+	return &userRepository{
+		db: db,
+		sq: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// GetUsers returns the users found among userIDs, keyed by ID.
+func (r *userRepository) GetUsers(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.User, error) {
+	rows, err := r.sq.Select("user_id", "status", "display_name", "photo_url", "birth_date", "created_at", "updated_at").
+		From("users").
+		Where(sq.Eq{"user_id": userIDs}).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make(map[domain.UserID]domain.User, len(userIDs))
+	for rows.Next() {
+		var user domain.User
+		var birthDate sql.NullTime
+		if err := rows.Scan(&user.ID, &user.Status, &user.DisplayName, &user.PhotoURL, &birthDate, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning user: %w", err)
+		}
+		user.BirthDate = birthDate.Time.UTC()
+		users[user.ID] = user
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetProfiles returns the profiles of the active users among userIDs, keyed
+// by ID.
+func (r *userRepository) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	rows, err := r.sq.Select("user_id", "display_name", "photo_url", "birth_date").
+		From("users").
+		Where(sq.Eq{"user_id": userIDs, "status": domain.UserStatusActive}).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("selecting profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := make(map[domain.UserID]domain.Profile, len(userIDs))
+	for rows.Next() {
+		var profile domain.Profile
+		var birthDate sql.NullTime
+		if err := rows.Scan(&profile.UserID, &profile.Name, &profile.PhotoURL, &birthDate); err != nil {
+			return nil, fmt.Errorf("scanning profile: %w", err)
+		}
+		profile.BirthDate = birthDate.Time.UTC()
+		profiles[profile.UserID] = profile
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// UpsertUser creates the user or updates its status and attributes.
+func (r *userRepository) UpsertUser(ctx context.Context, user domain.User) error {
+	if !user.Status.Valid() {
+		return fmt.Errorf("%w: unknown user status %q", domain.ErrInvalidInput, user.Status)
+	}
+
+	now := uint64(time.Now().Unix())
+
+	var birthDate sql.NullTime
+	if !user.BirthDate.IsZero() {
+		birthDate = sql.NullTime{Time: user.BirthDate, Valid: true}
+	}
+
+	_, err := r.sq.Insert("users").
+		Columns("user_id", "status", "display_name", "photo_url", "birth_date", "created_at", "updated_at").
+		Values(user.ID, user.Status, user.DisplayName, user.PhotoURL, birthDate, now, now).
+		Suffix(`
+           ON CONFLICT (user_id)
+           DO UPDATE SET
+               status = EXCLUDED.status,
+               display_name = EXCLUDED.display_name,
+               photo_url = EXCLUDED.photo_url,
+               birth_date = EXCLUDED.birth_date,
+               updated_at = EXCLUDED.updated_at`).
+		RunWith(r.db).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("upserting user: %w", err)
+	}
+
+	return nil
+}
+
+// SetUserStatus changes the user's status, creating a row for a user who has
+// none yet, as they count as active until then.
+func (r *userRepository) SetUserStatus(ctx context.Context, userID domain.UserID, status domain.UserStatus) error {
+	if !status.Valid() {
+		return fmt.Errorf("%w: unknown user status %q", domain.ErrInvalidInput, status)
+	}
+
+	now := uint64(time.Now().Unix())
+
+	_, err := r.sq.Insert("users").
+		Columns("user_id", "status", "created_at", "updated_at").
+		Values(userID, status, now, now).
+		Suffix(`
+           ON CONFLICT (user_id)
+           DO UPDATE SET
+               status = EXCLUDED.status,
+               updated_at = EXCLUDED.updated_at`).
+		RunWith(r.db).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("updating user status: %w", err)
+	}
+
+	return nil
+}
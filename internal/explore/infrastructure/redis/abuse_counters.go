@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+// recordActivityScript counts a decision in the actor's window, which starts
+// with their first decision in it, and extends the run of ascending
+// recipient IDs or starts a new one. The run is kept for RunGap after each
+// decision, so a slower decision starts a new run. It returns the counts, the
+// run and whether the actor is flagged, and keeps the flag marker for another
+// window.
+var recordActivityScript = redis.NewScript(`
+local decisions = redis.call('HINCRBY', KEYS[1], 'decisions', 1)
+local likes = redis.call('HINCRBY', KEYS[1], 'likes', ARGV[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+local last = redis.call('HGET', KEYS[3], 'last')
+local run = 1
+if last and ARGV[3] > last then
+	run = tonumber(redis.call('HGET', KEYS[3], 'run')) + 1
+end
+redis.call('HSET', KEYS[3], 'last', ARGV[3], 'run', run)
+redis.call('PEXPIRE', KEYS[3], ARGV[4])
+local flagged = redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return {decisions, likes, run, flagged}
+`)
+
+type AbuseCountersConfig struct {
+	Prefix string
+	// Window is how long decisions are counted together, from an actor's
+	// first decision in it.
+	Window time.Duration
+	// RunGap is the longest pause between two decisions of a run of
+	// ascending recipient IDs. People browsing the candidates feed, which is
+	// ordered by ID, climb too, only slower than a bot walking it. Zero lets a
+	// run go on while decisions come within a window of each other.
+	RunGap time.Duration
+}
+
+// AbuseCounters keeps each actor's decision counts for the current window,
+// their last recipient and run of ascending recipient IDs while decisions
+// keep coming within RunGap, and a marker once they are flagged. All keys of
+// an actor share the {actorID} hash tag.
+type AbuseCounters struct {
+	redis  redis.UniversalClient
+	config AbuseCountersConfig
+}
+
+func NewAbuseCounters(redis redis.UniversalClient, config AbuseCountersConfig) *AbuseCounters {
+	return &AbuseCounters{
+		redis:  redis,
+		config: config,
+	}
+}
+
+// RecordDecision counts the decision. Recipient IDs are compared as strings,
+// the way the candidates feed orders them.
+func (c *AbuseCounters) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) (domain.DecisionActivity, error) {
+	windowKey, flaggedKey, runKey := c.keys(actorID)
+
+	var liked int
+	if decision.Liked() {
+		liked = 1
+	}
+
+	runGap := c.config.RunGap
+	if runGap <= 0 {
+		runGap = c.config.Window
+	}
+
+	result, err := recordActivityScript.Run(ctx, c.redis, []string{windowKey, flaggedKey, runKey},
+		liked, c.config.Window.Milliseconds(), string(recipientID), runGap.Milliseconds()).Slice()
+	if err != nil {
+		return domain.DecisionActivity{}, fmt.Errorf("recording decision activity: %w", err)
+	}
+	if len(result) != 4 {
+		return domain.DecisionActivity{}, fmt.Errorf("recording decision activity: unexpected reply %v", result)
+	}
+
+	decisions, _ := result[0].(int64)
+	likes, _ := result[1].(int64)
+	run, _ := result[2].(int64)
+	flagged, _ := result[3].(int64)
+
+	return domain.DecisionActivity{
+		Decisions:     uint64(decisions),
+		Likes:         uint64(likes),
+		SequentialRun: uint64(run),
+		Flagged:       flagged == 1,
+	}, nil
+}
+
+// MarkFlagged remembers the flag, so a flagged actor isn't evaluated again.
+// The marker lasts until ResetUser or until the actor has made no decision for
+// a whole window, by when their window has expired too.
+func (c *AbuseCounters) MarkFlagged(ctx context.Context, userID domain.UserID) error {
+	_, flaggedKey, _ := c.keys(userID)
+
+	return c.redis.Set(ctx, flaggedKey, 1, c.config.Window).Err()
+}
+
+// ResetUser drops the user's counts, run and flag marker.
+func (c *AbuseCounters) ResetUser(ctx context.Context, userID domain.UserID) error {
+	windowKey, flaggedKey, runKey := c.keys(userID)
+
+	return c.redis.Del(ctx, windowKey, flaggedKey, runKey).Err()
+}
+
+func (c *AbuseCounters) keys(userID domain.UserID) (string, string, string) {
+	base := fmt.Sprintf("%s:abuse:{%s}", c.config.Prefix, userID)
+	return base + ":window", base + ":flagged", base + ":run"
+}
@@ -0,0 +1,271 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/protobuf/proto"
+	"muzz-homework/internal/explore/domain"
+	pb "muzz-homework/pkg/proto"
+	"strconv"
+)
+
+type CacheEncoding string
+
+const (
+	// EncodingBinary writes versioned protobuf entries.
+	EncodingBinary CacheEncoding = "binary"
+	// EncodingJSON keeps writing the previous JSON entries, for rolling back or
+	// for a rollout where replicas that only read JSON are still serving.
+	EncodingJSON CacheEncoding = "json"
+)
+
+const (
+	codecVersionProto byte = 1
+
+	flagCompressed byte = 1 << 0
+)
+
+var errUnknownCacheFormat = errors.New("unknown cache entry format")
+
+// cacheCodec encodes entries as [version][flags][payload]. Legacy JSON
+// entries start with '{' and pre-JSON counters and watermarks with a digit,
+// neither of which is a valid version byte, so both remain readable.
+type cacheCodec struct {
+	encoding CacheEncoding
+	// compressionThreshold is the payload size in bytes from which binary
+	// entries are s2-compressed. 0 disables compression.
+	compressionThreshold int
+}
+
+func (c cacheCodec) encodeLikers(result likersResult) ([]byte, error) {
+	if c.encoding == EncodingJSON {
+		return json.Marshal(result)
+	}
+
+	msg := &pb.CachedLikers{
+		Likers:      make([]*pb.ListLikedYouResponse_Liker, len(result.Likers)),
+		ComputeUs:   result.ComputeTimeUs,
+		ExpiresAtMs: result.ExpiresAtMs,
+	}
+	for i, liker := range result.Likers {
+		msg.Likers[i] = &pb.ListLikedYouResponse_Liker{
+			ActorId:       string(liker.ActorID),
+			UnixTimestamp: liker.Timestamp,
+			Decision:      decisionToProto(liker.Decision),
+		}
+	}
+	if result.Cursor != nil {
+		msg.Cursor = &pb.CachedCursor{
+			UnixTimestamp: result.Cursor.Timestamp,
+			Decision:      decisionToProto(result.Cursor.Decision),
+			ActorId:       string(result.Cursor.ActorID),
+		}
+	}
+
+	return c.marshal(msg)
+}
+
+func (c cacheCodec) decodeLikers(data []byte) (likersResult, error) {
+	var result likersResult
+	if isLegacyJSON(data) {
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+
+	var msg pb.CachedLikers
+	if err := unmarshal(data, &msg); err != nil {
+		return result, err
+	}
+
+	result.Likers = make([]domain.LikerInfo, len(msg.Likers))
+	for i, liker := range msg.Likers {
+		result.Likers[i] = domain.LikerInfo{
+			ActorID:   domain.UserID(liker.ActorId),
+			Timestamp: liker.UnixTimestamp,
+			Decision:  decisionFromProto(liker.Decision),
+		}
+	}
+	if msg.Cursor != nil {
+		result.Cursor = &domain.Cursor{
+			Timestamp: msg.Cursor.UnixTimestamp,
+			ActorID:   domain.UserID(msg.Cursor.ActorId),
+			Decision:  decisionFromProto(msg.Cursor.Decision),
+		}
+	}
+	result.ComputeTimeUs = msg.ComputeUs
+	result.ExpiresAtMs = msg.ExpiresAtMs
+
+	return result, nil
+}
+
+func (c cacheCodec) encodeCount(result countResult) ([]byte, error) {
+	if c.encoding == EncodingJSON {
+		return json.Marshal(result)
+	}
+
+	return c.marshal(&pb.CachedCount{
+		Count:       result.Count,
+		ComputeUs:   result.ComputeTimeUs,
+		ExpiresAtMs: result.ExpiresAtMs,
+	})
+}
+
+func (c cacheCodec) decodeCount(data []byte) (countResult, error) {
+	var result countResult
+	if isLegacyJSON(data) {
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+
+	if len(data) > 0 && data[0] >= '0' && data[0] <= '9' {
+		count, err := strconv.ParseUint(string(data), 10, 64)
+		result.Count = count
+		return result, err
+	}
+
+	var msg pb.CachedCount
+	if err := unmarshal(data, &msg); err != nil {
+		return result, err
+	}
+
+	result.Count = msg.Count
+	result.ComputeTimeUs = msg.ComputeUs
+	result.ExpiresAtMs = msg.ExpiresAtMs
+
+	return result, nil
+}
+
+func (c cacheCodec) encodeProfile(result profileResult) ([]byte, error) {
+	if c.encoding == EncodingJSON {
+		return json.Marshal(result)
+	}
+
+	return c.marshal(&pb.CachedProfile{
+		Found:     result.Found,
+		Name:      result.Name,
+		PhotoUrl:  result.PhotoURL,
+		BirthDate: result.BirthDate,
+	})
+}
+
+func (c cacheCodec) decodeProfile(data []byte) (profileResult, error) {
+	var result profileResult
+	if isLegacyJSON(data) {
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+
+	var msg pb.CachedProfile
+	if err := unmarshal(data, &msg); err != nil {
+		return result, err
+	}
+
+	result.Found = msg.Found
+	result.Name = msg.Name
+	result.PhotoURL = msg.PhotoUrl
+	result.BirthDate = msg.BirthDate
+
+	return result, nil
+}
+
+func (c cacheCodec) encodeWatermark(watermark domain.SeenWatermark) ([]byte, error) {
+	if c.encoding == EncodingJSON {
+		return json.Marshal(watermark)
+	}
+
+	return c.marshal(&pb.CachedWatermark{
+		UnixTimestamp: watermark.Timestamp,
+		ActorId:       string(watermark.ActorID),
+	})
+}
+
+// decodeWatermark also reads the bare timestamps watermarks were stored as
+// before they held an actor. Those covered their whole second, which is the
+// start of the next one now, as the add_seen_watermark_actor migration has it.
+func (c cacheCodec) decodeWatermark(data []byte) (domain.SeenWatermark, error) {
+	var watermark domain.SeenWatermark
+	if isLegacyJSON(data) {
+		err := json.Unmarshal(data, &watermark)
+		return watermark, err
+	}
+
+	if len(data) > 0 && data[0] >= '0' && data[0] <= '9' {
+		timestamp, err := strconv.ParseUint(string(data), 10, 64)
+		watermark.Timestamp = timestamp + 1
+		return watermark, err
+	}
+
+	var msg pb.CachedWatermark
+	if err := unmarshal(data, &msg); err != nil {
+		return watermark, err
+	}
+
+	watermark.Timestamp = msg.UnixTimestamp
+	watermark.ActorID = domain.UserID(msg.ActorId)
+
+	return watermark, nil
+}
+
+func (c cacheCodec) marshal(msg proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags byte
+	if c.compressionThreshold > 0 && len(payload) >= c.compressionThreshold {
+		payload = s2.Encode(nil, payload)
+		flags |= flagCompressed
+	}
+
+	data := make([]byte, 0, len(payload)+2)
+	data = append(data, codecVersionProto, flags)
+	return append(data, payload...), nil
+}
+
+func unmarshal(data []byte, msg proto.Message) error {
+	if len(data) < 2 || data[0] != codecVersionProto {
+		return errUnknownCacheFormat
+	}
+
+	payload := data[2:]
+	if data[1]&flagCompressed != 0 {
+		decoded, err := s2.Decode(nil, payload)
+		if err != nil {
+			return fmt.Errorf("decompressing cache entry: %w", err)
+		}
+		payload = decoded
+	}
+
+	return proto.Unmarshal(payload, msg)
+}
+
+func isLegacyJSON(data []byte) bool {
+	return len(data) > 0 && data[0] == '{'
+}
+
+func decisionToProto(decision domain.Decision) pb.Decision {
+	switch decision {
+	case domain.DecisionPass:
+		return pb.Decision_DECISION_PASS
+	case domain.DecisionLike:
+		return pb.Decision_DECISION_LIKE
+	case domain.DecisionSuperLike:
+		return pb.Decision_DECISION_SUPER_LIKE
+	default:
+		return pb.Decision_DECISION_UNSPECIFIED
+	}
+}
+
+func decisionFromProto(decision pb.Decision) domain.Decision {
+	switch decision {
+	case pb.Decision_DECISION_PASS:
+		return domain.DecisionPass
+	case pb.Decision_DECISION_SUPER_LIKE:
+		return domain.DecisionSuperLike
+	default:
+		return domain.DecisionLike
+	}
+}
@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+)
+
+func testLikersResult(n int) likersResult {
+	likers := make([]domain.LikerInfo, n)
+	for i := range likers {
+		likers[i] = domain.LikerInfo{
+			ActorID:   domain.UserID(fmt.Sprintf("user-%06d", i)),
+			Timestamp: 1_700_000_000 + uint64(i),
+			Decision:  domain.Decision(i % 3),
+		}
+	}
+
+	return likersResult{
+		Likers:    likers,
+		Cursor:    &domain.Cursor{Timestamp: 1_700_000_000, ActorID: "user-000000", Decision: domain.DecisionSuperLike},
+		entryMeta: entryMeta{ComputeTimeUs: 12_000, ExpiresAtMs: 1_700_000_060_000},
+	}
+}
+
+func TestCacheCodec_Likers(t *testing.T) {
+	tests := []struct {
+		name           string
+		codec          cacheCodec
+		result         likersResult
+		wantCompressed bool
+	}{
+		{
+			name:   "binary",
+			codec:  cacheCodec{encoding: EncodingBinary},
+			result: testLikersResult(10),
+		},
+		{
+			name:   "binary below threshold",
+			codec:  cacheCodec{encoding: EncodingBinary, compressionThreshold: 1 << 20},
+			result: testLikersResult(10),
+		},
+		{
+			name:           "binary compressed",
+			codec:          cacheCodec{encoding: EncodingBinary, compressionThreshold: 64},
+			result:         testLikersResult(100),
+			wantCompressed: true,
+		},
+		{
+			name:   "binary last page",
+			codec:  cacheCodec{encoding: EncodingBinary},
+			result: likersResult{Likers: []domain.LikerInfo{}},
+		},
+		{
+			name:   "json",
+			codec:  cacheCodec{encoding: EncodingJSON},
+			result: testLikersResult(10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.codec.encodeLikers(tt.result)
+			require.NoError(t, err)
+
+			if tt.codec.encoding == EncodingBinary {
+				assert.Equal(t, codecVersionProto, data[0])
+				assert.Equal(t, tt.wantCompressed, data[1]&flagCompressed != 0)
+			}
+
+			// Readers decode whatever encoding the writer was configured with.
+			got, err := cacheCodec{}.decodeLikers(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.result, got)
+		})
+	}
+}
+
+func TestCacheCodec_Count(t *testing.T) {
+	result := countResult{Count: 42, entryMeta: entryMeta{ComputeTimeUs: 300, ExpiresAtMs: 1_700_000_060_000}}
+
+	for _, codec := range []cacheCodec{{encoding: EncodingBinary}, {encoding: EncodingJSON}} {
+		t.Run(string(codec.encoding), func(t *testing.T) {
+			data, err := codec.encodeCount(result)
+			require.NoError(t, err)
+
+			got, err := cacheCodec{}.decodeCount(data)
+			require.NoError(t, err)
+			assert.Equal(t, result, got)
+		})
+	}
+}
+
+func TestCacheCodec_Watermark(t *testing.T) {
+	watermark := domain.SeenWatermark{Timestamp: 1_700_000_000, ActorID: "6f1c2a8e-3b4d-4c5e-8f90-1a2b3c4d5e01"}
+
+	for _, codec := range []cacheCodec{{encoding: EncodingBinary}, {encoding: EncodingJSON}} {
+		t.Run(string(codec.encoding), func(t *testing.T) {
+			data, err := codec.encodeWatermark(watermark)
+			require.NoError(t, err)
+
+			got, err := cacheCodec{}.decodeWatermark(data)
+			require.NoError(t, err)
+			assert.Equal(t, watermark, got)
+		})
+	}
+}
+
+func TestCacheCodec_LegacyEntries(t *testing.T) {
+	legacy := testLikersResult(3)
+	data, err := json.Marshal(legacy)
+	require.NoError(t, err)
+
+	likers, err := cacheCodec{}.decodeLikers(data)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, likers)
+
+	count, err := cacheCodec{}.decodeCount([]byte(`{"count":7,"compute_us":2000}`))
+	require.NoError(t, err)
+	assert.Equal(t, countResult{Count: 7, entryMeta: entryMeta{ComputeTimeUs: 2000}}, count)
+
+	count, err = cacheCodec{}.decodeCount([]byte("9"))
+	require.NoError(t, err)
+	assert.Equal(t, countResult{Count: 9}, count)
+
+	// Bare timestamps covered their whole second.
+	watermark, err := cacheCodec{}.decodeWatermark([]byte("1700000000"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeenWatermark{Timestamp: 1_700_000_001}, watermark)
+}
+
+func TestCacheCodec_UnknownVersion(t *testing.T) {
+	_, err := cacheCodec{}.decodeLikers([]byte{0x7f, 0, 1, 2})
+	assert.ErrorIs(t, err, errUnknownCacheFormat)
+
+	_, err = cacheCodec{}.decodeCount(nil)
+	assert.ErrorIs(t, err, errUnknownCacheFormat)
+}
+
+func BenchmarkCacheCodec(b *testing.B) {
+	codecs := []cacheCodec{
+		{encoding: EncodingJSON},
+		{encoding: EncodingBinary},
+		{encoding: EncodingBinary, compressionThreshold: 1024},
+	}
+
+	for _, size := range []int{20, 100} {
+		result := testLikersResult(size)
+
+		for _, codec := range codecs {
+			name := fmt.Sprintf("%s/threshold=%d/likers=%d", codec.encoding, codec.compressionThreshold, size)
+
+			b.Run("encode/"+name, func(b *testing.B) {
+				var data []byte
+				for i := 0; i < b.N; i++ {
+					data, _ = codec.encodeLikers(result)
+				}
+				b.ReportMetric(float64(len(data)), "bytes/entry")
+			})
+
+			data, err := codec.encodeLikers(result)
+			require.NoError(b, err)
+
+			b.Run("decode/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := codec.decodeLikers(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
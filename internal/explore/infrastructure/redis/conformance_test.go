@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/infrastructure/conformance"
+	"testing"
+	"time"
+)
+
+func TestRedisCache_Conformance(t *testing.T) {
+	conformance.TestCache(t, func(t *testing.T, ttl time.Duration) conformance.CacheHarness {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+
+		return conformance.CacheHarness{
+			Cache:  NewRedisCache(client, RedisConfig{Prefix: "test", TTL: ttl}),
+			Elapse: server.FastForward,
+		}
+	})
+}
+
+func TestAbuseCounters_Conformance(t *testing.T) {
+	conformance.TestAbuseCounters(t, func(t *testing.T, window time.Duration, runGap time.Duration) conformance.AbuseCountersHarness {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+
+		return conformance.AbuseCountersHarness{
+			Counters: NewAbuseCounters(client, AbuseCountersConfig{Prefix: "test", Window: window, RunGap: runGap}),
+			Elapse:   server.FastForward,
+		}
+	})
+}
@@ -2,83 +2,300 @@ package infrastructure
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"math"
+	"math/rand/v2"
 	"muzz-homework/internal/explore/domain"
+	"strings"
 	"time"
 )
 
+var errEarlyRefresh = errors.New("cache entry picked for early refresh")
+
 type RedisConfig struct {
 	Prefix string
 	TTL    time.Duration
+	// XFetchBeta scales how eagerly entries are refreshed before they expire.
+	// 1 is the usual choice, higher refreshes earlier and 0 disables it.
+	XFetchBeta float64
+	Metrics    *CacheMetrics
+	// Encoding selects how listings and counters are written. Both encodings
+	// are always readable. Defaults to EncodingBinary.
+	Encoding CacheEncoding
+	// CompressionThreshold is the size in bytes from which binary entries are
+	// compressed. 0 disables compression.
+	CompressionThreshold int
+}
+
+// entryMeta is stored with listings and counters for XFetch early refresh.
+// Compute time is in microseconds since most queries take under a
+// millisecond.
+type entryMeta struct {
+	ComputeTimeUs int64 `json:"compute_us,omitempty"`
+	ExpiresAtMs   int64 `json:"expires_at_ms,omitempty"`
 }
 
 type likersResult struct {
-	Likers    []domain.LikerInfo `json:"likers"`
-	Timestamp *uint64            `json:"timestamp"`
+	Likers []domain.LikerInfo `json:"likers"`
+	Cursor *domain.Cursor     `json:"cursor"`
+	entryMeta
+}
+
+type countResult struct {
+	Count uint64 `json:"count"`
+	entryMeta
 }
 
 type RedisCache struct {
-	redis  *redis.Client
+	redis  redis.UniversalClient
 	config RedisConfig
+	codec  cacheCodec
 }
 
-func NewRedisCache(redis *redis.Client, config RedisConfig) *RedisCache {
+func NewRedisCache(redis redis.UniversalClient, config RedisConfig) *RedisCache {
+	if config.Encoding == "" {
+		config.Encoding = EncodingBinary
+	}
+
 	return &RedisCache{
 		redis:  redis,
 		config: config,
+		codec: cacheCodec{
+			encoding:             config.Encoding,
+			compressionThreshold: config.CompressionThreshold,
+		},
 	}
 }
 
-func (r *RedisCache) GetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool) ([]domain.LikerInfo, *uint64, error) {
-	timestampValue := uint64(0)
-	if timestamp != nil {
-		timestampValue = *timestamp
-	}
-
-	key := fmt.Sprintf("%s:likers:%s:%d:%t", r.config.Prefix, recipientID, timestampValue, excludeMutual)
+func (r *RedisCache) GetLikers(ctx context.Context, query domain.LikersQuery) (likers []domain.LikerInfo, next *domain.Cursor, err error) {
+	defer func() { r.config.Metrics.lookupErr(tierRedis, entryLikers, err) }()
 
-	data, err := r.redis.Get(ctx, key).Bytes()
+	data, err := r.redis.Get(ctx, r.LikersKey(query)).Bytes()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var result likersResult
-	if err := json.Unmarshal(data, &result); err != nil {
+	result, err := r.codec.decodeLikers(data)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	return result.Likers, result.Timestamp, nil
-}
-
-func (r *RedisCache) SetLikers(ctx context.Context, recipientID string, timestamp *uint64, excludeMutual bool, likers []domain.LikerInfo, nextTS *uint64) error {
-	timestampValue := uint64(0)
-	if timestamp != nil {
-		timestampValue = *timestamp
+	if r.refreshEarly(result.entryMeta) {
+		return nil, nil, errEarlyRefresh
 	}
 
-	key := fmt.Sprintf("%s:likers:%s:%d:%t", r.config.Prefix, recipientID, timestampValue, excludeMutual)
+	return result.Likers, result.Cursor, nil
+}
 
+func (r *RedisCache) SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
 	result := likersResult{
 		Likers:    likers,
-		Timestamp: nextTS,
+		Cursor:    next,
+		entryMeta: r.newEntryMeta(computeTime),
+	}
+
+	data, err := r.codec.encodeLikers(result)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, r.LikersKey(query), data, r.config.TTL).Err()
+}
+
+func (r *RedisCache) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (count uint64, err error) {
+	defer func() { r.config.Metrics.lookupErr(tierRedis, entryCount, err) }()
+
+	data, err := r.redis.Get(ctx, r.LikersCountKey(query)).Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.codec.decodeCount(data)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.refreshEarly(result.entryMeta) {
+		return 0, errEarlyRefresh
+	}
+
+	return result.Count, nil
+}
+
+func (r *RedisCache) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	data, err := r.codec.encodeCount(countResult{
+		Count:     count,
+		entryMeta: r.newEntryMeta(computeTime),
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, r.LikersCountKey(query), data, r.config.TTL).Err()
+}
+
+func (r *RedisCache) newEntryMeta(computeTime time.Duration) entryMeta {
+	return entryMeta{
+		ComputeTimeUs: computeTime.Microseconds(),
+		ExpiresAtMs:   time.Now().Add(r.config.TTL).UnixMilli(),
+	}
+}
+
+func (r *RedisCache) refreshEarly(meta entryMeta) bool {
+	return xfetchExpired(meta, time.Now(), r.config.XFetchBeta, rand.Float64())
+}
+
+// xfetchExpired implements probabilistic early expiration (XFetch, Vattani
+// et al.). Every read treats the entry as expired slightly ahead of time, with
+// a probability that grows as the real expiry nears and with how long the
+// value took to compute. A single reader then refreshes a hot key shortly
+// before it expires instead of every reader missing at once. random is
+// uniform in [0, 1).
+func xfetchExpired(meta entryMeta, now time.Time, beta float64, random float64) bool {
+	if beta <= 0 || meta.ExpiresAtMs == 0 || meta.ComputeTimeUs <= 0 {
+		return false
+	}
+
+	early := -float64(meta.ComputeTimeUs) / 1000 * beta * math.Log(1-random)
+	return float64(now.UnixMilli())+early >= float64(meta.ExpiresAtMs)
+}
+
+func (r *RedisCache) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (watermark domain.SeenWatermark, err error) {
+	defer func() { r.config.Metrics.lookupErr(tierRedis, entryWatermark, err) }()
+
+	data, err := r.redis.Get(ctx, r.watermarkKey(recipientID)).Bytes()
+	if err != nil {
+		return domain.SeenWatermark{}, err
 	}
 
-	data, err := json.Marshal(result)
+	return r.codec.decodeWatermark(data)
+}
+
+func (r *RedisCache) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	data, err := r.codec.encodeWatermark(seenUpTo)
 	if err != nil {
 		return err
 	}
 
-	return r.redis.Set(ctx, key, data, r.config.TTL).Err()
+	return r.redis.Set(ctx, r.watermarkKey(recipientID), data, r.config.TTL).Err()
+}
+
+// UpdateSeenWatermark stores a watermark that has just moved. Redis is shared
+// by every replica, so this is the same write as a fill.
+func (r *RedisCache) UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return r.SetSeenWatermark(ctx, recipientID, seenUpTo)
+}
+
+// PurgeUser removes every cached listing, counter, watermark, profile and
+// liker index of the user. The keys share the user's hash tag, so on a
+// cluster they all live on one node and are removed with a single command.
+func (r *RedisCache) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	keys, err := r.userKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Unlink(ctx, keys...).Err()
+}
+
+// userKeys returns the keys the user may have. Keys without a variable part
+// are listed whether they exist or not.
+func (r *RedisCache) userKeys(ctx context.Context, userID domain.UserID) ([]string, error) {
+	id := escapePattern(string(userID))
+	patterns := []string{
+		fmt.Sprintf("%s:likers:{%s}:*", r.config.Prefix, id),
+		fmt.Sprintf("%s:count:{%s}:*", r.config.Prefix, id),
+	}
+
+	indexKey, indexMetaKey, indexRejectedKey := r.indexKeys(userID)
+	keys := []string{
+		fmt.Sprintf("%s:count:{%s}", r.config.Prefix, userID),
+		r.watermarkKey(userID),
+		r.profileKey(userID),
+		indexKey,
+		indexMetaKey,
+		indexRejectedKey,
+	}
+
+	// SCAN only walks the node it is sent to.
+	var scanner redis.Cmdable = r.redis
+	if cluster, ok := r.redis.(*redis.ClusterClient); ok {
+		node, err := cluster.MasterForKey(ctx, r.watermarkKey(userID))
+		if err != nil {
+			return nil, err
+		}
+		scanner = node
+	}
+
+	for _, pattern := range patterns {
+		iter := scanner.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// LikersKey includes the seen watermark for unseen-only pages, so moving the
+// watermark forward naturally stops serving the pages built for the old one.
+// Every key of a user carries the {userID} hash tag.
+func (r *RedisCache) LikersKey(query domain.LikersQuery) string {
+	var cursor domain.Cursor
+	if query.Cursor != nil {
+		cursor = *query.Cursor
+	}
+
+	key := fmt.Sprintf("%s:likers:{%s}:%d:%s", r.config.Prefix, query.RecipientID, cursor.Timestamp, query.Filter)
+	if cursor.ActorID != "" {
+		key = fmt.Sprintf("%s:after:%s", key, cursor.ActorID)
+	}
+	if query.SeenUpTo != nil {
+		key = fmt.Sprintf("%s:seen:%d:%s", key, query.SeenUpTo.Timestamp, query.SeenUpTo.ActorID)
+	}
+
+	if query.SuperLikesFirst {
+		key = fmt.Sprintf("%s:super:%d", key, cursor.Decision)
+	}
+
+	if query.IncludeExpired {
+		key += ":expired"
+	}
+
+	return key
+}
+
+func (r *RedisCache) LikersCountKey(query domain.LikersCountQuery) string {
+	key := fmt.Sprintf("%s:count:{%s}", r.config.Prefix, query.RecipientID)
+	if query.SeenUpTo != nil {
+		key = fmt.Sprintf("%s:seen:%d:%s", key, query.SeenUpTo.Timestamp, query.SeenUpTo.ActorID)
+	}
+
+	if query.IncludeExpired {
+		key += ":expired"
+	}
+
+	return key
+}
+
+func (r *RedisCache) watermarkKey(recipientID domain.UserID) string {
+	return fmt.Sprintf("%s:seen:{%s}", r.config.Prefix, recipientID)
+}
+
+func (r *RedisCache) indexKeys(recipientID domain.UserID) (string, string, string) {
+	likersKey := fmt.Sprintf("%s:index:{%s}", r.config.Prefix, recipientID)
+	return likersKey, likersKey + ":meta", likersKey + ":rejected"
 }
 
-func (r *RedisCache) GetLikersCount(ctx context.Context, recipientID string) (uint64, error) {
-	key := fmt.Sprintf("%s:count:%s", r.config.Prefix, recipientID)
-	return r.redis.Get(ctx, key).Uint64()
+func (r *RedisCache) profileKey(userID domain.UserID) string {
+	return fmt.Sprintf("%s:profile:{%s}", r.config.Prefix, userID)
 }
 
-func (r *RedisCache) SetLikersCount(ctx context.Context, recipientID string, count uint64) error {
-	key := fmt.Sprintf("%s:count:%s", r.config.Prefix, recipientID)
-	return r.redis.Set(ctx, key, count, r.config.TTL).Err()
+func escapePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`).Replace(value)
 }
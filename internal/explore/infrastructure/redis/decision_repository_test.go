@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXFetchExpired(t *testing.T) {
+	now := time.UnixMilli(1_000_000)
+
+	tests := []struct {
+		name   string
+		meta   entryMeta
+		beta   float64
+		random float64
+		want   bool
+	}{
+		{
+			name:   "disabled",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() + 10},
+			beta:   0,
+			random: 0.99,
+			want:   false,
+		},
+		{
+			name:   "entry without metadata",
+			meta:   entryMeta{},
+			beta:   1,
+			random: 0.99,
+			want:   false,
+		},
+		{
+			name:   "far from expiry",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() + 60_000},
+			beta:   1,
+			random: 0.99,
+			want:   false,
+		},
+		{
+			name:   "near expiry with unlucky draw",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() + 200},
+			beta:   1,
+			random: 0.99,
+			want:   true,
+		},
+		{
+			name:   "near expiry with lucky draw",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() + 200},
+			beta:   1,
+			random: 0.1,
+			want:   false,
+		},
+		{
+			name:   "sub-millisecond compute time",
+			meta:   entryMeta{ComputeTimeUs: 400, ExpiresAtMs: now.UnixMilli() + 1},
+			beta:   1,
+			random: 0.99,
+			want:   true,
+		},
+		{
+			name:   "higher beta refreshes earlier",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() + 200},
+			beta:   20,
+			random: 0.1,
+			want:   true,
+		},
+		{
+			name:   "already expired",
+			meta:   entryMeta{ComputeTimeUs: 100_000, ExpiresAtMs: now.UnixMilli() - 1},
+			beta:   1,
+			random: 0,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, xfetchExpired(tt.meta, now, tt.beta, tt.random))
+		})
+	}
+}
+
+func TestRedisCache_KeysShareHashTag(t *testing.T) {
+	cache := NewRedisCache(nil, RedisConfig{Prefix: "test"})
+	index := NewLikerIndex(cache, nil, LikerIndexConfig{})
+	seen := domain.SeenWatermark{Timestamp: 10, ActorID: "user2"}
+
+	likersIndexKey, metaKey, rejectedKey := index.keys("user1")
+	keys := []string{
+		cache.LikersKey(domain.LikersQuery{RecipientID: "user1", Filter: domain.LikersFilterAll, SeenUpTo: &seen, SuperLikesFirst: true}),
+		cache.LikersCountKey(domain.LikersCountQuery{RecipientID: "user1", SeenUpTo: &seen, IncludeExpired: true}),
+		cache.watermarkKey("user1"),
+		likersIndexKey,
+		metaKey,
+		rejectedKey,
+	}
+
+	for _, key := range keys {
+		_, rest, _ := strings.Cut(key, "{")
+		tag, _, _ := strings.Cut(rest, "}")
+		assert.Equal(t, "user1", tag, key)
+	}
+}
+
+func TestRedisCache_PurgeUserOnCluster(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{server.Addr()}})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	cache := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+
+	for _, id := range []domain.UserID{"user1", "user2"} {
+		require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: id, Filter: domain.LikersFilterAll}, nil, nil, 0))
+		require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: id}, 1, 0))
+		require.NoError(t, cache.SetSeenWatermark(ctx, id, domain.SeenWatermark{Timestamp: 1, ActorID: "user3"}))
+	}
+
+	require.NoError(t, cache.PurgeUser(ctx, "user1"))
+
+	assert.ElementsMatch(t, []string{"test:count:{user2}", "test:likers:{user2}:0:all", "test:seen:{user2}"}, server.Keys())
+}
@@ -0,0 +1,160 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/domain"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry describes one cached key, for admin tooling.
+type CacheEntry struct {
+	Key  string
+	Type string
+	// TTL is negative for keys without an expiry.
+	TTL time.Duration
+	// Summary is a short decoded description of the value.
+	Summary string
+}
+
+// InspectUser describes every key cached for the user, sorted by key.
+func (r *RedisCache) InspectUser(ctx context.Context, userID domain.UserID) ([]CacheEntry, error) {
+	keys, err := r.userKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.redis.Pipeline()
+	types := make([]*redis.StatusCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		types[i] = pipe.Type(ctx, key)
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("inspecting cache keys: %w", err)
+	}
+
+	var entries []CacheEntry
+	for i, key := range keys {
+		if types[i].Val() == "none" {
+			continue
+		}
+
+		entry := CacheEntry{Key: key, Type: types[i].Val(), TTL: ttls[i].Val()}
+		if entry.Summary, err = r.summarize(ctx, entry); err != nil {
+			return nil, fmt.Errorf("inspecting %s: %w", key, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	slices.SortFunc(entries, func(a, b CacheEntry) int { return strings.Compare(a.Key, b.Key) })
+
+	return entries, nil
+}
+
+func (r *RedisCache) summarize(ctx context.Context, entry CacheEntry) (string, error) {
+	switch entry.Type {
+	case "zset":
+		n, err := r.redis.ZCard(ctx, entry.Key).Result()
+		return fmt.Sprintf("%d likers", n), err
+	case "hash":
+		n, err := r.redis.HLen(ctx, entry.Key).Result()
+		return fmt.Sprintf("%d fields", n), err
+	case "string":
+		return r.summarizeValue(ctx, entry.Key)
+	default:
+		return "", nil
+	}
+}
+
+func (r *RedisCache) summarizeValue(ctx context.Context, key string) (string, error) {
+	data, err := r.redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return "expired", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	prefix := r.config.Prefix + ":"
+	switch {
+	case strings.HasPrefix(key, prefix+"likers:"):
+		result, err := r.codec.decodeLikers(data)
+		if err != nil {
+			return "undecodable: " + err.Error(), nil
+		}
+		return fmt.Sprintf("%d likers, last page: %t", len(result.Likers), result.Cursor == nil), nil
+	case strings.HasPrefix(key, prefix+"count:"):
+		result, err := r.codec.decodeCount(data)
+		if err != nil {
+			return "undecodable: " + err.Error(), nil
+		}
+		return fmt.Sprintf("count %d", result.Count), nil
+	case strings.HasPrefix(key, prefix+"profile:"):
+		result, err := r.codec.decodeProfile(data)
+		if err != nil {
+			return "undecodable: " + err.Error(), nil
+		}
+		if !result.Found {
+			return "no profile", nil
+		}
+		return fmt.Sprintf("profile %q", result.Name), nil
+	case strings.HasPrefix(key, prefix+"seen:"):
+		watermark, err := r.codec.decodeWatermark(data)
+		if err != nil {
+			return "undecodable: " + err.Error(), nil
+		}
+		return fmt.Sprintf("watermark %d %s", watermark.Timestamp, watermark.ActorID), nil
+	default:
+		return "", nil
+	}
+}
+
+// CountedRecipients returns every user with a cached likers counter. On a
+// cluster, every master is scanned.
+func (r *RedisCache) CountedRecipients(ctx context.Context) ([]domain.UserID, error) {
+	pattern := escapePattern(r.config.Prefix) + ":count:{*"
+	prefix := r.config.Prefix + ":count:{"
+
+	var mu sync.Mutex
+	seen := make(map[domain.UserID]struct{})
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.Scan(ctx, 0, pattern, 1000).Iterator()
+		for iter.Next(ctx) {
+			id, _, ok := strings.Cut(strings.TrimPrefix(iter.Val(), prefix), "}")
+			if !ok {
+				continue
+			}
+			mu.Lock()
+			seen[domain.UserID(id)] = struct{}{}
+			mu.Unlock()
+		}
+		return iter.Err()
+	}
+
+	var err error
+	if cluster, ok := r.redis.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	} else {
+		err = scan(ctx, r.redis)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning counters: %w", err)
+	}
+
+	recipients := make([]domain.UserID, 0, len(seen))
+	for id := range seen {
+		recipients = append(recipients, id)
+	}
+	slices.Sort(recipients)
+
+	return recipients, nil
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+func TestRedisCache_InspectAndPurgeUser(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	cache := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	seen := domain.SeenWatermark{Timestamp: 5, ActorID: "user3"}
+
+	for _, id := range []domain.UserID{"user1", "user2"} {
+		require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: id, Filter: domain.LikersFilterAll},
+			[]domain.LikerInfo{{ActorID: "user3", Timestamp: 10}}, nil, 0))
+		require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: id}, 1, 0))
+	}
+	require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1", SeenUpTo: &seen}, 0, 0))
+	require.NoError(t, cache.SetSeenWatermark(ctx, "user1", seen))
+	_, err := NewProfileCache(cache, &mockProfileSource{}, time.Minute).GetProfiles(ctx, "user1")
+	require.NoError(t, err)
+	require.NoError(t, client.HSet(ctx, "test:index:{user1}:meta", indexBuiltField, 1).Err())
+
+	entries, err := cache.InspectUser(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, []CacheEntry{
+		{Key: "test:count:{user1}", Type: "string", TTL: time.Minute, Summary: "count 1"},
+		{Key: "test:count:{user1}:seen:5:user3", Type: "string", TTL: time.Minute, Summary: "count 0"},
+		{Key: "test:index:{user1}:meta", Type: "hash", TTL: -1, Summary: "1 fields"},
+		{Key: "test:likers:{user1}:0:all", Type: "string", TTL: time.Minute, Summary: "1 likers, last page: true"},
+		{Key: "test:profile:{user1}", Type: "string", TTL: time.Minute, Summary: "no profile"},
+		{Key: "test:seen:{user1}", Type: "string", TTL: time.Minute, Summary: "watermark 5 user3"},
+	}, entries)
+
+	recipients, err := cache.CountedRecipients(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"user1", "user2"}, recipients)
+
+	require.NoError(t, cache.PurgeUser(ctx, "user1"))
+	entries, err = cache.InspectUser(ctx, "user1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.ElementsMatch(t, []string{"test:count:{user2}", "test:likers:{user2}:0:all"}, server.Keys())
+}
@@ -0,0 +1,499 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"muzz-homework/internal/explore/domain"
+	"muzz-homework/pkg/ctxutil"
+	"strconv"
+	"time"
+)
+
+const (
+	indexBuiltField      = "built"
+	defaultIndexPageSize = 20
+	// indexChunkSize is how many likers are read per round trip while filling
+	// a page, as filters can skip some of them.
+	indexChunkSize = 100
+	// indexBuildAttempts bounds how often a build is retried when decisions
+	// keep landing while it reads Postgres.
+	indexBuildAttempts = 3
+	// indexBuildMarkerTTL cleans up the marker of a build that died midway.
+	indexBuildMarkerTTL = 30 * time.Second
+)
+
+// markBuildScript flags the recipient's index as being built, unless it
+// already is built, so decisions recorded meanwhile touch the meta key.
+var markBuildScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], 'built') == 1 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'building', 1)
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return 1
+`)
+
+// recordLikerScript adds or removes the actor in the recipient's index, and
+// in its rejected set if the recipient passed on them. Only an index that was
+// fully built is touched, so a partial one is never created; one being built
+// only has its marker bumped, which makes the build start over.
+var recordLikerScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[2], 'built') == 0 then
+	if redis.call('HEXISTS', KEYS[2], 'building') == 1 then
+		redis.call('HINCRBY', KEYS[2], 'building', 1)
+	end
+	return 0
+end
+if ARGV[2] == '0' then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+	redis.call('ZREM', KEYS[3], ARGV[1])
+	redis.call('HDEL', KEYS[2], 'liker:' .. ARGV[1])
+	return 1
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+redis.call('HSET', KEYS[2], 'liker:' .. ARGV[1], ARGV[2])
+if redis.call('HGET', KEYS[2], 'own:' .. ARGV[1]) == '0' then
+	redis.call('ZADD', KEYS[3], ARGV[3], ARGV[1])
+end
+local ttl = redis.call('PTTL', KEYS[2])
+if ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+	redis.call('PEXPIRE', KEYS[3], ttl)
+end
+return 1
+`)
+
+// recordOwnScript stores the actor's own decision in the actor's index, and
+// moves the other user in or out of its rejected set if they are a liker.
+var recordOwnScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], 'built') == 0 then
+	if redis.call('HEXISTS', KEYS[1], 'building') == 1 then
+		redis.call('HINCRBY', KEYS[1], 'building', 1)
+	end
+	return 0
+end
+redis.call('HSET', KEYS[1], 'own:' .. ARGV[1], ARGV[2])
+if ARGV[2] ~= '0' then
+	redis.call('ZREM', KEYS[3], ARGV[1])
+	return 1
+end
+local score = redis.call('ZSCORE', KEYS[2], ARGV[1])
+if score then
+	redis.call('ZADD', KEYS[3], score, ARGV[1])
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl > 0 then
+		redis.call('PEXPIRE', KEYS[3], ttl)
+	end
+end
+return 1
+`)
+
+type likerIndexSource interface {
+	GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error)
+}
+
+type LikerIndexConfig struct {
+	// TTL starts when an index is built. Incremental updates don't extend it,
+	// so every index is periodically rebuilt from Postgres.
+	TTL time.Duration
+	// LikeLifetime hides likes older than this, as the repository does.
+	LikeLifetime time.Duration
+	PageSize     int
+}
+
+// LikerIndex keeps each recipient's likers in a sorted set scored by like
+// timestamp, next to a hash holding every liker's decision and the
+// recipient's own decisions, and a second sorted set of the likers the
+// recipient passed on. Pages for any cursor, filter or watermark and all
+// counts are read from those keys, which DecisionCreator updates in place
+// instead of invalidating. Listings with super-likes first are ordered
+// by decision, so they keep using the per-page cache.
+type LikerIndex struct {
+	pages  *RedisCache
+	source likerIndexSource
+	config LikerIndexConfig
+	builds singleflight.Group
+}
+
+func NewLikerIndex(pages *RedisCache, source likerIndexSource, config LikerIndexConfig) *LikerIndex {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultIndexPageSize
+	}
+
+	return &LikerIndex{
+		pages:  pages,
+		source: source,
+		config: config,
+	}
+}
+
+func (x *LikerIndex) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	if query.SuperLikesFirst {
+		return x.pages.GetLikers(ctx, query)
+	}
+
+	if err := x.ensure(ctx, query.RecipientID, entryLikers); err != nil {
+		return nil, nil, err
+	}
+
+	likersKey, metaKey, _ := x.keys(query.RecipientID)
+	min, max := indexScoreRange(query.Cursor, query.SeenUpTo, x.expiryCutoff(query.IncludeExpired))
+
+	var likers []domain.LikerInfo
+	var hasMore bool
+
+	for offset := int64(0); !hasMore; offset += indexChunkSize {
+		members, err := x.pages.redis.ZRevRangeByScoreWithScores(ctx, likersKey, &redis.ZRangeBy{
+			Min:    min,
+			Max:    max,
+			Offset: offset,
+			Count:  indexChunkSize,
+		}).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(members) == 0 {
+			break
+		}
+
+		fields := make([]string, 0, 2*len(members))
+		for _, member := range members {
+			actorID := member.Member.(string)
+			fields = append(fields, "liker:"+actorID, "own:"+actorID)
+		}
+
+		values, err := x.pages.redis.HMGet(ctx, metaKey, fields...).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i, member := range members {
+			// Likers sharing the cursor's second come first, as the range
+			// includes it; those up to the cursor's actor were already listed.
+			if query.Cursor != nil && uint64(member.Score) == query.Cursor.Timestamp && member.Member.(string) >= string(query.Cursor.ActorID) {
+				continue
+			}
+			// Likers sharing the watermark's second are in range too; those
+			// up to its actor were seen.
+			if query.SeenUpTo != nil && query.SeenUpTo.Covers(uint64(member.Score), domain.UserID(member.Member.(string))) {
+				continue
+			}
+
+			own, decided := parseIndexDecision(values[2*i+1])
+			if !matchesFilter(query.Filter, own, decided) {
+				continue
+			}
+
+			if len(likers) == x.config.PageSize {
+				hasMore = true
+				break
+			}
+
+			decision, ok := parseIndexDecision(values[2*i])
+			if !ok {
+				decision = domain.DecisionLike
+			}
+
+			likers = append(likers, domain.LikerInfo{
+				ActorID:   domain.UserID(member.Member.(string)),
+				Timestamp: uint64(member.Score),
+				Decision:  decision,
+			})
+		}
+
+		if len(members) < indexChunkSize {
+			break
+		}
+	}
+
+	var next *domain.Cursor
+	if hasMore {
+		last := likers[len(likers)-1]
+		next = &domain.Cursor{Timestamp: last.Timestamp, ActorID: last.ActorID}
+	}
+
+	return likers, next, nil
+}
+
+// SetLikers only stores super-likes-first pages; everything else is served
+// from the index, which is maintained incrementally.
+func (x *LikerIndex) SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+	if query.SuperLikesFirst {
+		return x.pages.SetLikers(ctx, query, likers, next, computeTime)
+	}
+
+	return nil
+}
+
+func (x *LikerIndex) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	if err := x.ensure(ctx, query.RecipientID, entryCount); err != nil {
+		return 0, err
+	}
+
+	// Counts match the "all" listing, which hides likers the recipient
+	// passed on.
+	likersKey, _, rejectedKey := x.keys(query.RecipientID)
+	cutoff := x.expiryCutoff(query.IncludeExpired)
+	min, max := indexScoreRange(nil, query.SeenUpTo, cutoff)
+
+	// The range includes the watermark's second, whose likers up to the
+	// watermark's actor were seen and are taken off again.
+	watermarkSecond := query.SeenUpTo != nil && query.SeenUpTo.Timestamp >= cutoff
+
+	var likers, rejected *redis.IntCmd
+	var secondLikers, secondRejected *redis.StringSliceCmd
+	_, err := x.pages.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		likers = pipe.ZCount(ctx, likersKey, min, max)
+		rejected = pipe.ZCount(ctx, rejectedKey, min, max)
+		if watermarkSecond {
+			second := &redis.ZRangeBy{Min: min, Max: min}
+			secondLikers = pipe.ZRangeByScore(ctx, likersKey, second)
+			secondRejected = pipe.ZRangeByScore(ctx, rejectedKey, second)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := likers.Val() - rejected.Val()
+	if watermarkSecond {
+		count -= countSeen(secondLikers.Val(), *query.SeenUpTo) - countSeen(secondRejected.Val(), *query.SeenUpTo)
+	}
+
+	return uint64(count), nil
+}
+
+// countSeen counts the members of the watermark's second that it covers.
+func countSeen(members []string, watermark domain.SeenWatermark) int64 {
+	var seen int64
+	for _, member := range members {
+		if watermark.Covers(watermark.Timestamp, domain.UserID(member)) {
+			seen++
+		}
+	}
+
+	return seen
+}
+
+func (x *LikerIndex) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	return nil
+}
+
+func (x *LikerIndex) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	return x.pages.GetSeenWatermark(ctx, recipientID)
+}
+
+func (x *LikerIndex) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return x.pages.SetSeenWatermark(ctx, recipientID, seenUpTo)
+}
+
+func (x *LikerIndex) UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	return x.pages.UpdateSeenWatermark(ctx, recipientID, seenUpTo)
+}
+
+func (x *LikerIndex) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	return x.pages.PurgeUser(ctx, userID)
+}
+
+// RecordDecision applies a saved decision to the recipient's index, where the
+// actor is a liker, and to the actor's index, where it is their own decision.
+// Indexes that aren't built are left alone.
+func (x *LikerIndex) RecordDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) error {
+	likersKey, metaKey, rejectedKey := x.keys(recipientID)
+	err := recordLikerScript.Run(ctx, x.pages.redis, []string{likersKey, metaKey, rejectedKey}, string(actorID), int(decision), timestamp).Err()
+	if err != nil {
+		return fmt.Errorf("updating liker index: %w", err)
+	}
+
+	return x.RecordOwnDecision(ctx, actorID, recipientID, decision)
+}
+
+// RecordOwnDecision applies a saved decision to the actor's index only. It is
+// used for shadow-banned actors, who are kept out of their recipients'
+// indexes but still filter their own likers by their decisions.
+func (x *LikerIndex) RecordOwnDecision(ctx context.Context, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision) error {
+	likersKey, metaKey, rejectedKey := x.keys(actorID)
+	err := recordOwnScript.Run(ctx, x.pages.redis, []string{metaKey, likersKey, rejectedKey}, string(recipientID), int(decision)).Err()
+	if err != nil {
+		return fmt.Errorf("updating liker index: %w", err)
+	}
+
+	return nil
+}
+
+// ensure builds the recipient's index from Postgres unless it is cached.
+// Concurrent builds of the same index are coalesced.
+func (x *LikerIndex) ensure(ctx context.Context, recipientID domain.UserID, entry string) error {
+	_, metaKey, _ := x.keys(recipientID)
+	built, err := x.pages.redis.HExists(ctx, metaKey, indexBuiltField).Result()
+	if err != nil {
+		x.pages.config.Metrics.lookup(tierRedis, entry, resultError)
+		return err
+	}
+
+	if built {
+		x.pages.config.Metrics.lookup(tierRedis, entry, resultHit)
+		return nil
+	}
+
+	x.pages.config.Metrics.lookup(tierRedis, entry, resultMiss)
+	results := x.builds.DoChan(string(recipientID), func() (any, error) {
+		buildCtx, cancel := ctxutil.Detach(ctx)
+		defer cancel()
+
+		return nil, x.build(buildCtx, recipientID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-results:
+		return result.Err
+	}
+}
+
+// build loads the index from Postgres and writes it in a transaction watching
+// the meta key. A decision saved after the read bumps the build marker before
+// the write, which fails the transaction, so the build starts over instead of
+// writing an index that misses it.
+func (x *LikerIndex) build(ctx context.Context, recipientID domain.UserID) error {
+	_, metaKey, _ := x.keys(recipientID)
+
+	for range indexBuildAttempts {
+		marked, err := markBuildScript.Run(ctx, x.pages.redis, []string{metaKey}, indexBuildMarkerTTL.Milliseconds()).Int()
+		if err != nil {
+			return fmt.Errorf("marking liker index build: %w", err)
+		}
+		if marked == 0 {
+			return nil
+		}
+
+		err = x.pages.redis.Watch(ctx, func(tx *redis.Tx) error {
+			return x.write(ctx, tx, recipientID)
+		}, metaKey)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("building liker index: %w", redis.TxFailedErr)
+}
+
+func (x *LikerIndex) write(ctx context.Context, tx *redis.Tx, recipientID domain.UserID) error {
+	index, err := x.source.GetLikerIndex(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+
+	members := make([]redis.Z, len(index.Likers))
+	var rejected []redis.Z
+	meta := map[string]any{indexBuiltField: 1}
+	for i, liker := range index.Likers {
+		members[i] = redis.Z{Score: float64(liker.Timestamp), Member: string(liker.ActorID)}
+		meta["liker:"+string(liker.ActorID)] = int(liker.Decision)
+
+		if own, decided := index.Decisions[liker.ActorID]; decided && !own.Liked() {
+			rejected = append(rejected, members[i])
+		}
+	}
+	for userID, decision := range index.Decisions {
+		meta["own:"+string(userID)] = int(decision)
+	}
+
+	likersKey, metaKey, rejectedKey := x.keys(recipientID)
+	_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, likersKey, metaKey, rejectedKey)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, likersKey, members...)
+		}
+		if len(rejected) > 0 {
+			pipe.ZAdd(ctx, rejectedKey, rejected...)
+		}
+		pipe.HSet(ctx, metaKey, meta)
+
+		if x.config.TTL > 0 {
+			pipe.Expire(ctx, likersKey, x.config.TTL)
+			pipe.Expire(ctx, metaKey, x.config.TTL)
+			pipe.Expire(ctx, rejectedKey, x.config.TTL)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		return fmt.Errorf("building liker index: %w", err)
+	}
+
+	return err
+}
+
+func (x *LikerIndex) keys(recipientID domain.UserID) (string, string, string) {
+	return x.pages.indexKeys(recipientID)
+}
+
+func (x *LikerIndex) expiryCutoff(includeExpired bool) uint64 {
+	if includeExpired || x.config.LikeLifetime <= 0 {
+		return 0
+	}
+
+	return uint64(time.Now().Add(-x.config.LikeLifetime).Unix())
+}
+
+// indexScoreRange returns the ZRANGEBYSCORE bounds matching the repository's
+// conditions: not newer than the cursor, newer than the seen watermark and not
+// older than the expiry cutoff. Likers in the cursor's and the watermark's own
+// seconds are included, since ZREVRANGEBYSCORE orders them by actor ID,
+// descending, and only the ones up to the cursor's or the watermark's actor
+// are to be skipped.
+func indexScoreRange(cursor *domain.Cursor, seenUpTo *domain.SeenWatermark, cutoff uint64) (string, string) {
+	max := "+inf"
+	if cursor != nil {
+		max = strconv.FormatUint(cursor.Timestamp, 10)
+	}
+
+	lower := cutoff
+	if seenUpTo != nil && seenUpTo.Timestamp > lower {
+		lower = seenUpTo.Timestamp
+	}
+
+	min := "-inf"
+	if lower > 0 {
+		min = strconv.FormatUint(lower, 10)
+	}
+
+	return min, max
+}
+
+// matchesFilter applies a likers filter given the recipient's own decision
+// about the liker, if they made one.
+func matchesFilter(filter domain.LikersFilter, own domain.Decision, decided bool) bool {
+	switch filter {
+	case domain.LikersFilterAll:
+		return !decided || own.Liked()
+	case domain.LikersFilterPending:
+		return !decided
+	case domain.LikersFilterMatched:
+		return decided && own.Liked()
+	case domain.LikersFilterRejected:
+		return decided && !own.Liked()
+	default:
+		return false
+	}
+}
+
+func parseIndexDecision(value any) (domain.Decision, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+
+	decision, err := strconv.ParseInt(s, 10, 16)
+	if err != nil {
+		return 0, false
+	}
+
+	return domain.Decision(decision), true
+}
@@ -0,0 +1,363 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+type mockLikerIndexSource struct {
+	index domain.LikerIndex
+}
+
+func (m *mockLikerIndexSource) GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error) {
+	return m.index, nil
+}
+
+func TestLikerIndex_PagesWithinSecond(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	// 12 likes in two seconds, paged by 5, so pages break inside a second.
+	source := &mockLikerIndexSource{index: domain.LikerIndex{Decisions: map[domain.UserID]domain.Decision{}}}
+	var want []domain.UserID
+	for second := range 2 {
+		for i := 5; i >= 0; i-- {
+			likerID := domain.UserID(fmt.Sprintf("liker%d-%d", second, i))
+			source.index.Likers = append(source.index.Likers, domain.LikerInfo{ActorID: likerID, Timestamp: uint64(200 - second), Decision: domain.DecisionLike})
+			want = append(want, likerID)
+		}
+	}
+
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute, PageSize: 5})
+
+	var got []domain.UserID
+	var cursors []domain.Cursor
+	query := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll}
+	for {
+		likers, next, err := index.GetLikers(context.Background(), query)
+		require.NoError(t, err)
+		for _, liker := range likers {
+			got = append(got, liker.ActorID)
+		}
+
+		if next == nil {
+			break
+		}
+		require.Less(t, len(cursors), 5, "pagination doesn't end")
+		cursors = append(cursors, *next)
+		query.Cursor = next
+	}
+
+	assert.Equal(t, want, got)
+	assert.Equal(t, []domain.Cursor{{Timestamp: 200, ActorID: "liker0-1"}, {Timestamp: 199, ActorID: "liker1-2"}}, cursors)
+
+	// A cursor without an actor, from a token issued before actors broke
+	// ties, resumes after the whole second.
+	likers, _, err := index.GetLikers(context.Background(), domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, Cursor: &domain.Cursor{Timestamp: 200}})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserID("liker1-5"), likers[0].ActorID)
+}
+
+// racingLikerIndexSource records a decision the first time it is read, as if
+// it was saved right after the read, without it showing in that read.
+type racingLikerIndexSource struct {
+	index  domain.LikerIndex
+	record func()
+	reads  int
+}
+
+func (s *racingLikerIndexSource) GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error) {
+	s.reads++
+	index := s.index
+	if s.reads == 1 {
+		s.record()
+	} else {
+		index.Likers = append(index.Likers, domain.LikerInfo{ActorID: "late", Timestamp: 200, Decision: domain.DecisionLike})
+	}
+
+	return index, nil
+}
+
+func TestLikerIndex_BuildRetriesAfterConcurrentDecision(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	source := &racingLikerIndexSource{index: domain.LikerIndex{
+		Likers:    []domain.LikerInfo{{ActorID: "early", Timestamp: 100, Decision: domain.DecisionLike}},
+		Decisions: map[domain.UserID]domain.Decision{},
+	}}
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute})
+	source.record = func() {
+		require.NoError(t, index.RecordDecision(ctx, "late", "recipient", domain.DecisionLike, 200))
+	}
+
+	count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count, "decision saved during the build is kept")
+	assert.Equal(t, 2, source.reads)
+
+	assert.Empty(t, server.HGet("test:index:{recipient}:meta", "building"), "build marker is cleared")
+}
+
+type blockingLikerIndexSource struct {
+	index   domain.LikerIndex
+	started chan struct{}
+	release chan struct{}
+	t       *testing.T
+}
+
+func (s *blockingLikerIndexSource) GetLikerIndex(ctx context.Context, recipientID domain.UserID) (domain.LikerIndex, error) {
+	close(s.started)
+	<-s.release
+	assert.NoError(s.t, ctx.Err())
+
+	return s.index, nil
+}
+
+func TestLikerIndex_BuildSurvivesCancelledCaller(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	source := &blockingLikerIndexSource{
+		index: domain.LikerIndex{
+			Likers:    []domain.LikerInfo{{ActorID: "liker", Timestamp: 100, Decision: domain.DecisionLike}},
+			Decisions: map[domain.UserID]domain.Decision{},
+		},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+		t:       t,
+	}
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute})
+	query := domain.LikersCountQuery{RecipientID: "recipient"}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := index.GetLikersCount(leaderCtx, query)
+		leaderErr <- err
+	}()
+	<-source.started
+
+	followerCount := make(chan uint64, 1)
+	go func() {
+		count, err := index.GetLikersCount(context.Background(), query)
+		assert.NoError(t, err)
+		followerCount <- count
+	}()
+
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	assert.Equal(t, uint64(1), <-followerCount)
+}
+
+func TestLikerIndex_CountHidesRejected(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	source := &mockLikerIndexSource{index: domain.LikerIndex{
+		Likers: []domain.LikerInfo{
+			{ActorID: "pending", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "matched", Timestamp: 110, Decision: domain.DecisionLike},
+			{ActorID: "rejected", Timestamp: 120, Decision: domain.DecisionSuperLike},
+		},
+		Decisions: map[domain.UserID]domain.Decision{"matched": domain.DecisionLike, "rejected": domain.DecisionPass, "stranger": domain.DecisionPass},
+	}}
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute})
+
+	assertCount := func(want uint64, msg string) {
+		t.Helper()
+		count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+		require.NoError(t, err)
+		assert.Equal(t, want, count, msg)
+	}
+
+	assertCount(2, "built with a rejected liker")
+
+	require.NoError(t, index.RecordDecision(ctx, "recipient", "pending", domain.DecisionPass, 200))
+	assertCount(1, "recipient passed on a liker")
+
+	require.NoError(t, index.RecordDecision(ctx, "recipient", "rejected", domain.DecisionLike, 210))
+	assertCount(2, "recipient liked back a rejected liker")
+
+	require.NoError(t, index.RecordDecision(ctx, "stranger", "recipient", domain.DecisionLike, 220))
+	assertCount(2, "liker the recipient already passed on")
+
+	require.NoError(t, index.RecordDecision(ctx, "stranger", "recipient", domain.DecisionPass, 230))
+	assertCount(2, "rejected liker took their like back")
+
+	seen := domain.SeenWatermark{Timestamp: 105}
+	count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient", SeenUpTo: &seen})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count, "matched and rejected-then-liked are unseen")
+
+	ttl := server.TTL("test:index:{recipient}:rejected")
+	assert.Positive(t, ttl, "rejected set expires with the index")
+}
+
+func TestLikerIndex_WatermarkWithinSecond(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	source := &mockLikerIndexSource{index: domain.LikerIndex{
+		Likers: []domain.LikerInfo{
+			{ActorID: "a", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "b", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "c", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "d", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "e", Timestamp: 110, Decision: domain.DecisionLike},
+		},
+		Decisions: map[domain.UserID]domain.Decision{"a": domain.DecisionPass, "d": domain.DecisionPass},
+	}}
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute})
+
+	// Seen up to b, so c is unseen although it was given in the same second,
+	// and the rejected a and d are hidden on either side of the watermark.
+	seen := domain.SeenWatermark{Timestamp: 100, ActorID: "b"}
+	likers, _, err := index.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &seen})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LikerInfo{
+		{ActorID: "e", Timestamp: 110, Decision: domain.DecisionLike},
+		{ActorID: "c", Timestamp: 100, Decision: domain.DecisionLike},
+	}, likers)
+
+	count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient", SeenUpTo: &seen})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// A watermark before the expiry cutoff leaves the cutoff's second whole.
+	index.config.LikeLifetime = time.Since(time.Unix(105, 0))
+	count, err = index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient", SeenUpTo: &seen})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestLikerIndex_RecordOwnDecision(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	source := &mockLikerIndexSource{index: domain.LikerIndex{
+		Likers: []domain.LikerInfo{
+			{ActorID: "pending", Timestamp: 100, Decision: domain.DecisionLike},
+			{ActorID: "other", Timestamp: 110, Decision: domain.DecisionLike},
+		},
+		Decisions: map[domain.UserID]domain.Decision{},
+	}}
+	pages := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute})
+	index := NewLikerIndex(pages, source, LikerIndexConfig{TTL: time.Minute})
+
+	assertCount := func(want uint64, msg string) {
+		t.Helper()
+		count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+		require.NoError(t, err)
+		assert.Equal(t, want, count, msg)
+	}
+
+	assertCount(2, "built with two pending likers")
+
+	require.NoError(t, index.RecordOwnDecision(ctx, "recipient", "pending", domain.DecisionPass))
+	assertCount(1, "own pass still hides the liker")
+
+	require.NoError(t, index.RecordOwnDecision(ctx, "flagged", "recipient", domain.DecisionLike))
+	assertCount(1, "actor is not added to the recipient's likers")
+}
+
+func TestIndexScoreRange(t *testing.T) {
+	seen := domain.SeenWatermark{Timestamp: 150, ActorID: "liker"}
+
+	tests := []struct {
+		name     string
+		cursor   *domain.Cursor
+		seenUpTo *domain.SeenWatermark
+		cutoff   uint64
+		wantMin  string
+		wantMax  string
+	}{
+		{
+			name:    "unbounded",
+			wantMin: "-inf",
+			wantMax: "+inf",
+		},
+		{
+			name:    "cursor second is inclusive",
+			cursor:  &domain.Cursor{Timestamp: 200},
+			wantMin: "-inf",
+			wantMax: "200",
+		},
+		{
+			name:     "watermark second is inclusive",
+			seenUpTo: &seen,
+			wantMin:  "150",
+			wantMax:  "+inf",
+		},
+		{
+			name:    "expiry cutoff is inclusive",
+			cutoff:  100,
+			wantMin: "100",
+			wantMax: "+inf",
+		},
+		{
+			name:     "later of watermark and cutoff wins",
+			cursor:   &domain.Cursor{Timestamp: 200},
+			seenUpTo: &seen,
+			cutoff:   170,
+			wantMin:  "170",
+			wantMax:  "200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max := indexScoreRange(tt.cursor, tt.seenUpTo, tt.cutoff)
+			assert.Equal(t, tt.wantMin, min)
+			assert.Equal(t, tt.wantMax, max)
+		})
+	}
+}
+
+func TestMatchesFilter(t *testing.T) {
+	tests := []struct {
+		filter    domain.LikersFilter
+		undecided bool
+		liked     bool
+		passed    bool
+	}{
+		{filter: domain.LikersFilterAll, undecided: true, liked: true, passed: false},
+		{filter: domain.LikersFilterPending, undecided: true, liked: false, passed: false},
+		{filter: domain.LikersFilterMatched, undecided: false, liked: true, passed: false},
+		{filter: domain.LikersFilterRejected, undecided: false, liked: false, passed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.filter), func(t *testing.T) {
+			assert.Equal(t, tt.undecided, matchesFilter(tt.filter, 0, false))
+			assert.Equal(t, tt.liked, matchesFilter(tt.filter, domain.DecisionLike, true))
+			assert.Equal(t, tt.liked, matchesFilter(tt.filter, domain.DecisionSuperLike, true))
+			assert.Equal(t, tt.passed, matchesFilter(tt.filter, domain.DecisionPass, true))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Lock is a Redis key taken by one replica at a time until it expires. It is
+// never released early, so periodic jobs holding it for their interval run
+// once per interval across the fleet.
+type Lock struct {
+	redis redis.UniversalClient
+	key   string
+}
+
+func NewLock(redis redis.UniversalClient, key string) *Lock {
+	return &Lock{
+		redis: redis,
+		key:   key,
+	}
+}
+
+// TryAcquire takes the lock for ttl, reporting false while it is held.
+func (l *Lock) TryAcquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	acquired, err := l.redis.SetNX(ctx, l.key, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("acquiring lock %s: %w", l.key, err)
+	}
+
+	return acquired, nil
+}
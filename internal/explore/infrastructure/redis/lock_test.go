@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLock_TryAcquire(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	first := NewLock(client, "test:lock:job")
+	second := NewLock(client, "test:lock:job")
+
+	acquired, err := first.TryAcquire(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "free lock")
+
+	acquired, err = second.TryAcquire(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "held by another replica")
+
+	server.FastForward(time.Minute)
+	acquired, err = second.TryAcquire(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "expired lock")
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	tierLocal = "local"
+	tierRedis = "redis"
+
+	entryLikers    = "likers"
+	entryCount     = "count"
+	entryWatermark = "watermark"
+	entryProfile   = "profile"
+
+	resultHit          = "hit"
+	resultMiss         = "miss"
+	resultEarlyRefresh = "early_refresh"
+	resultError        = "error"
+)
+
+// CacheMetrics counts cache lookups per tier. A nil *CacheMetrics records
+// nothing.
+type CacheMetrics struct {
+	requests      *prometheus.CounterVec
+	invalidations *prometheus.CounterVec
+}
+
+func NewCacheMetrics(registerer prometheus.Registerer) *CacheMetrics {
+	m := &CacheMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "explore_cache_requests_total",
+			Help: "Cache lookups by tier, entry type and result.",
+		}, []string{"tier", "entry", "result"}),
+		invalidations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "explore_cache_invalidations_total",
+			Help: "Local cache invalidations by source.",
+		}, []string{"source"}),
+	}
+
+	registerer.MustRegister(m.requests, m.invalidations)
+
+	return m
+}
+
+func (m *CacheMetrics) lookup(tier string, entry string, result string) {
+	if m == nil {
+		return
+	}
+
+	m.requests.WithLabelValues(tier, entry, result).Inc()
+}
+
+// lookupErr records a lookup from the error it returned.
+func (m *CacheMetrics) lookupErr(tier string, entry string, err error) {
+	switch {
+	case err == nil:
+		m.lookup(tier, entry, resultHit)
+	case errors.Is(err, redis.Nil):
+		m.lookup(tier, entry, resultMiss)
+	case errors.Is(err, errEarlyRefresh):
+		m.lookup(tier, entry, resultEarlyRefresh)
+	default:
+		m.lookup(tier, entry, resultError)
+	}
+}
+
+func (m *CacheMetrics) invalidated(source string) {
+	if m == nil {
+		return
+	}
+
+	m.invalidations.WithLabelValues(source).Inc()
+}
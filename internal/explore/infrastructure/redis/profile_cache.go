@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/domain"
+	"time"
+)
+
+const birthDateLayout = time.DateOnly
+
+type profileSource interface {
+	GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error)
+}
+
+// profileResult is a cached profile. Users without a profile are cached too,
+// with Found unset, so they don't hit the source on every listing.
+type profileResult struct {
+	Found     bool   `json:"found"`
+	Name      string `json:"name,omitempty"`
+	PhotoURL  string `json:"photo_url,omitempty"`
+	BirthDate string `json:"birth_date,omitempty"`
+}
+
+// ProfileCache caches each user's profile under its own key, so a listing
+// only loads the profiles of likers it hasn't seen recently, in one batch.
+type ProfileCache struct {
+	pages  *RedisCache
+	source profileSource
+	ttl    time.Duration
+}
+
+func NewProfileCache(pages *RedisCache, source profileSource, ttl time.Duration) *ProfileCache {
+	return &ProfileCache{
+		pages:  pages,
+		source: source,
+		ttl:    ttl,
+	}
+}
+
+// GetProfiles returns the profiles found among userIDs, keyed by ID. Redis
+// errors fall back to the source.
+func (c *ProfileCache) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	profiles := make(map[domain.UserID]domain.Profile, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+
+	missing := c.getCached(ctx, userIDs, profiles)
+	if len(missing) == 0 {
+		return profiles, nil
+	}
+
+	loaded, err := c.source.GetProfiles(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := c.pages.redis.Pipeline()
+	for _, id := range missing {
+		result := profileResult{}
+		if profile, ok := loaded[id]; ok {
+			profiles[id] = profile
+			result = newProfileResult(profile)
+		}
+
+		data, err := c.pages.codec.encodeProfile(result)
+		if err != nil {
+			continue
+		}
+		pipe.Set(ctx, c.pages.profileKey(id), data, c.ttl)
+	}
+
+	// Profiles are served from the source either way, a failed write only
+	// means loading them again next time.
+	_, _ = pipe.Exec(ctx)
+
+	return profiles, nil
+}
+
+// getCached adds the cached profiles to profiles and returns the users that
+// need loading.
+func (c *ProfileCache) getCached(ctx context.Context, userIDs []domain.UserID, profiles map[domain.UserID]domain.Profile) []domain.UserID {
+	metrics := c.pages.config.Metrics
+
+	pipe := c.pages.redis.Pipeline()
+	cmds := make([]*redis.StringCmd, len(userIDs))
+	for i, id := range userIDs {
+		cmds[i] = pipe.Get(ctx, c.pages.profileKey(id))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		metrics.lookup(tierRedis, entryProfile, resultError)
+		return userIDs
+	}
+
+	var missing []domain.UserID
+	for i, id := range userIDs {
+		data, err := cmds[i].Bytes()
+		if err != nil {
+			metrics.lookupErr(tierRedis, entryProfile, err)
+			missing = append(missing, id)
+			continue
+		}
+
+		result, err := c.pages.codec.decodeProfile(data)
+		if err != nil {
+			metrics.lookup(tierRedis, entryProfile, resultError)
+			missing = append(missing, id)
+			continue
+		}
+
+		metrics.lookup(tierRedis, entryProfile, resultHit)
+		if result.Found {
+			profiles[id] = result.profile(id)
+		}
+	}
+
+	return missing
+}
+
+func newProfileResult(profile domain.Profile) profileResult {
+	result := profileResult{
+		Found:    true,
+		Name:     profile.Name,
+		PhotoURL: profile.PhotoURL,
+	}
+	if !profile.BirthDate.IsZero() {
+		result.BirthDate = profile.BirthDate.Format(birthDateLayout)
+	}
+
+	return result
+}
+
+func (r profileResult) profile(userID domain.UserID) domain.Profile {
+	profile := domain.Profile{
+		UserID:   userID,
+		Name:     r.Name,
+		PhotoURL: r.PhotoURL,
+	}
+	if birthDate, err := time.Parse(birthDateLayout, r.BirthDate); err == nil {
+		profile.BirthDate = birthDate
+	}
+
+	return profile
+}
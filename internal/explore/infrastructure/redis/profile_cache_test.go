@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"testing"
+	"time"
+)
+
+type mockProfileSource struct {
+	profiles map[domain.UserID]domain.Profile
+	err      error
+	requests [][]domain.UserID
+}
+
+func (m *mockProfileSource) GetProfiles(ctx context.Context, userIDs ...domain.UserID) (map[domain.UserID]domain.Profile, error) {
+	m.requests = append(m.requests, userIDs)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	profiles := make(map[domain.UserID]domain.Profile)
+	for _, id := range userIDs {
+		if profile, ok := m.profiles[id]; ok {
+			profiles[id] = profile
+		}
+	}
+
+	return profiles, nil
+}
+
+func TestProfileCache_GetProfiles(t *testing.T) {
+	ann := domain.Profile{UserID: "user1", Name: "Ann", PhotoURL: "https://example.com/ann.jpg", BirthDate: time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC)}
+	bob := domain.Profile{UserID: "user2", Name: "Bob"}
+
+	for _, encoding := range []CacheEncoding{EncodingBinary, EncodingJSON} {
+		t.Run(string(encoding), func(t *testing.T) {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { client.Close() })
+
+			ctx := context.Background()
+			source := &mockProfileSource{profiles: map[domain.UserID]domain.Profile{"user1": ann, "user2": bob}}
+			cache := NewProfileCache(NewRedisCache(client, RedisConfig{Prefix: "test", Encoding: encoding}), source, time.Minute)
+
+			want := map[domain.UserID]domain.Profile{"user1": ann}
+			profiles, err := cache.GetProfiles(ctx, "user1", "user3")
+			require.NoError(t, err)
+			assert.Equal(t, want, profiles)
+
+			// Both the profile and the missing one are served from Redis now.
+			profiles, err = cache.GetProfiles(ctx, "user1", "user2", "user3")
+			require.NoError(t, err)
+			assert.Equal(t, map[domain.UserID]domain.Profile{"user1": ann, "user2": bob}, profiles)
+			assert.Equal(t, [][]domain.UserID{{"user1", "user3"}, {"user2"}}, source.requests)
+
+			assert.Equal(t, time.Minute, server.TTL("test:profile:{user3}"))
+
+			require.NoError(t, cache.pages.PurgeUser(ctx, "user1"))
+			assert.False(t, server.Exists("test:profile:{user1}"))
+		})
+	}
+}
+
+func TestProfileCache_FallsBackToSource(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	ann := domain.Profile{UserID: "user1", Name: "Ann"}
+	source := &mockProfileSource{profiles: map[domain.UserID]domain.Profile{"user1": ann}}
+	cache := NewProfileCache(NewRedisCache(client, RedisConfig{Prefix: "test"}), source, time.Minute)
+
+	require.NoError(t, server.Set("test:profile:{user1}", "garbage"))
+	server.SetError("LOADING")
+	profiles, err := cache.GetProfiles(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.UserID]domain.Profile{"user1": ann}, profiles)
+
+	server.SetError("")
+	source.err = errors.New("db error")
+	profiles, err = cache.GetProfiles(ctx, "user1")
+	assert.EqualError(t, err, "db error")
+	assert.Nil(t, profiles)
+}
+
+func TestCacheCodec_Profile(t *testing.T) {
+	result := profileResult{Found: true, Name: "Ann", PhotoURL: "https://example.com/ann.jpg", BirthDate: "1990-03-15"}
+
+	for _, codec := range []cacheCodec{{encoding: EncodingBinary}, {encoding: EncodingJSON}} {
+		data, err := codec.encodeProfile(result)
+		require.NoError(t, err)
+
+		got, err := cacheCodec{}.decodeProfile(data)
+		require.NoError(t, err)
+		assert.Equal(t, result, got)
+	}
+}
@@ -0,0 +1,337 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/domain"
+	"sync"
+	"time"
+)
+
+var errLocalMiss = errors.New("local cache miss")
+
+type LocalCacheConfig struct {
+	// Size bounds the number of entries kept in process.
+	Size int
+	// TTL bounds how stale a local entry can get when an invalidation is lost,
+	// so it should stay short.
+	TTL     time.Duration
+	Metrics *CacheMetrics
+}
+
+type localEntry struct {
+	userID    domain.UserID
+	likers    []domain.LikerInfo
+	cursor    *domain.Cursor
+	value     uint64
+	watermark domain.SeenWatermark
+	// seq tells this entry apart from earlier ones under the same key, so
+	// evicting one of those doesn't unindex it.
+	seq uint64
+}
+
+// invalidation is published when cached data changes, so other replicas drop
+// their local copies of the keys, or of everything cached for UserID. Fills
+// after a miss store what the repository already holds and publish nothing.
+type invalidation struct {
+	Origin string        `json:"origin"`
+	Keys   []string      `json:"keys,omitempty"`
+	UserID domain.UserID `json:"user_id,omitempty"`
+}
+
+// TieredCache keeps recently read entries in a size-bounded in-process LRU in
+// front of RedisCache. Writes go to both tiers, and changes are broadcast over
+// Redis pub/sub so every replica evicts its local copy.
+type TieredCache struct {
+	remote   *RedisCache
+	local    *expirable.LRU[string, localEntry]
+	config   LocalCacheConfig
+	channel  string
+	instance string
+
+	// userKeys indexes the local keys of each user, with the seq of the
+	// entry each holds, so a purge doesn't scan the whole LRU. epoch moves
+	// with every local invalidation, so a fill that raced one is dropped
+	// rather than left behind unindexed or stale. mu is never held while
+	// calling into the LRU, whose eviction callback takes it.
+	mu       sync.Mutex
+	userKeys map[domain.UserID]map[string]uint64
+	epoch    uint64
+	seq      uint64
+}
+
+func NewTieredCache(remote *RedisCache, config LocalCacheConfig) *TieredCache {
+	instance := make([]byte, 8)
+	rand.Read(instance)
+
+	c := &TieredCache{
+		remote:   remote,
+		config:   config,
+		channel:  remote.config.Prefix + ":invalidations",
+		instance: hex.EncodeToString(instance),
+		userKeys: make(map[domain.UserID]map[string]uint64),
+	}
+	c.local = expirable.NewLRU[string, localEntry](config.Size, c.unindexLocal, config.TTL)
+
+	return c
+}
+
+func (c *TieredCache) GetLikers(ctx context.Context, query domain.LikersQuery) ([]domain.LikerInfo, *domain.Cursor, error) {
+	key := c.remote.LikersKey(query)
+	if entry, err := c.getLocal(key, entryLikers); err == nil {
+		return entry.likers, entry.cursor, nil
+	}
+
+	epoch := c.localEpoch()
+	likers, next, err := c.remote.GetLikers(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.addLocal(key, localEntry{userID: query.RecipientID, likers: likers, cursor: next}, epoch)
+
+	return likers, next, nil
+}
+
+func (c *TieredCache) SetLikers(ctx context.Context, query domain.LikersQuery, likers []domain.LikerInfo, next *domain.Cursor, computeTime time.Duration) error {
+	key := c.remote.LikersKey(query)
+	epoch := c.localEpoch()
+	if err := c.remote.SetLikers(ctx, query, likers, next, computeTime); err != nil {
+		c.local.Remove(key)
+		return err
+	}
+
+	c.addLocal(key, localEntry{userID: query.RecipientID, likers: likers, cursor: next}, epoch)
+
+	return nil
+}
+
+func (c *TieredCache) GetLikersCount(ctx context.Context, query domain.LikersCountQuery) (uint64, error) {
+	key := c.remote.LikersCountKey(query)
+	if entry, err := c.getLocal(key, entryCount); err == nil {
+		return entry.value, nil
+	}
+
+	epoch := c.localEpoch()
+	count, err := c.remote.GetLikersCount(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	c.addLocal(key, localEntry{userID: query.RecipientID, value: count}, epoch)
+
+	return count, nil
+}
+
+func (c *TieredCache) SetLikersCount(ctx context.Context, query domain.LikersCountQuery, count uint64, computeTime time.Duration) error {
+	key := c.remote.LikersCountKey(query)
+	epoch := c.localEpoch()
+	if err := c.remote.SetLikersCount(ctx, query, count, computeTime); err != nil {
+		c.local.Remove(key)
+		return err
+	}
+
+	c.addLocal(key, localEntry{userID: query.RecipientID, value: count}, epoch)
+
+	return nil
+}
+
+func (c *TieredCache) GetSeenWatermark(ctx context.Context, recipientID domain.UserID) (domain.SeenWatermark, error) {
+	key := c.remote.watermarkKey(recipientID)
+	if entry, err := c.getLocal(key, entryWatermark); err == nil {
+		return entry.watermark, nil
+	}
+
+	epoch := c.localEpoch()
+	watermark, err := c.remote.GetSeenWatermark(ctx, recipientID)
+	if err != nil {
+		return domain.SeenWatermark{}, err
+	}
+
+	c.addLocal(key, localEntry{userID: recipientID, watermark: watermark}, epoch)
+
+	return watermark, nil
+}
+
+func (c *TieredCache) SetSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	key := c.remote.watermarkKey(recipientID)
+	epoch := c.localEpoch()
+	if err := c.remote.SetSeenWatermark(ctx, recipientID, seenUpTo); err != nil {
+		c.local.Remove(key)
+		return err
+	}
+
+	c.addLocal(key, localEntry{userID: recipientID, watermark: seenUpTo}, epoch)
+
+	return nil
+}
+
+// UpdateSeenWatermark stores a watermark that has just moved and tells the
+// other replicas to drop the old one.
+func (c *TieredCache) UpdateSeenWatermark(ctx context.Context, recipientID domain.UserID, seenUpTo domain.SeenWatermark) error {
+	if err := c.SetSeenWatermark(ctx, recipientID, seenUpTo); err != nil {
+		return err
+	}
+
+	return c.publish(ctx, invalidation{Keys: []string{c.remote.watermarkKey(recipientID)}})
+}
+
+func (c *TieredCache) PurgeUser(ctx context.Context, userID domain.UserID) error {
+	c.purgeLocalUser(userID)
+	c.config.Metrics.invalidated("purge")
+
+	if err := c.remote.PurgeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return c.publish(ctx, invalidation{UserID: userID})
+}
+
+// Run applies invalidations published by other replicas until ctx is done.
+func (c *TieredCache) Run(ctx context.Context) error {
+	sub := c.remote.redis.Subscribe(ctx, c.channel)
+	defer sub.Close()
+
+	// Wait for the subscription to be confirmed so no invalidation published
+	// after Run starts is missed.
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			c.apply(msg)
+		}
+	}
+}
+
+func (c *TieredCache) apply(msg *redis.Message) {
+	var inv invalidation
+	if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil || inv.Origin == c.instance {
+		return
+	}
+
+	if len(inv.Keys) > 0 {
+		c.removeLocal(inv.Keys)
+	}
+
+	if inv.UserID != "" {
+		c.purgeLocalUser(inv.UserID)
+	}
+
+	c.config.Metrics.invalidated("remote")
+}
+
+func (c *TieredCache) getLocal(key string, entry string) (localEntry, error) {
+	value, ok := c.local.Get(key)
+	if !ok {
+		c.config.Metrics.lookup(tierLocal, entry, resultMiss)
+		return localEntry{}, errLocalMiss
+	}
+
+	c.config.Metrics.lookup(tierLocal, entry, resultHit)
+	return value, nil
+}
+
+// localEpoch is taken before reading what a fill will store.
+func (c *TieredCache) localEpoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.epoch
+}
+
+// addLocal indexes the key before adding it, so an entry evicted right away
+// is unindexed by the callback. An entry that raced an invalidation since
+// epoch was taken is removed again, as it may be stale or its key may have
+// been unindexed by the purge of the entry it replaced.
+func (c *TieredCache) addLocal(key string, entry localEntry, epoch uint64) {
+	c.mu.Lock()
+	c.seq++
+	entry.seq = c.seq
+	keys, ok := c.userKeys[entry.userID]
+	if !ok {
+		keys = make(map[string]uint64)
+		c.userKeys[entry.userID] = keys
+	}
+	keys[key] = entry.seq
+	c.mu.Unlock()
+
+	c.local.Add(key, entry)
+
+	c.mu.Lock()
+	raced := c.epoch != epoch
+	c.mu.Unlock()
+
+	if raced {
+		c.local.Remove(key)
+	}
+}
+
+// unindexLocal is the LRU's eviction callback, run whenever an entry is
+// removed, evicted or expires. An entry replaced since it was indexed leaves
+// its successor indexed.
+func (c *TieredCache) unindexLocal(key string, entry localEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := c.userKeys[entry.userID]
+	if seq, ok := keys[key]; !ok || seq != entry.seq {
+		return
+	}
+
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(c.userKeys, entry.userID)
+	}
+}
+
+func (c *TieredCache) removeLocal(keys []string) {
+	c.mu.Lock()
+	c.epoch++
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.local.Remove(key)
+	}
+}
+
+func (c *TieredCache) purgeLocalUser(userID domain.UserID) {
+	c.mu.Lock()
+	c.epoch++
+	keys := make([]string, 0, len(c.userKeys[userID]))
+	for key := range c.userKeys[userID] {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.local.Remove(key)
+	}
+}
+
+func (c *TieredCache) publish(ctx context.Context, inv invalidation) error {
+	inv.Origin = c.instance
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+
+	return c.remote.redis.Publish(ctx, c.channel, data).Err()
+}
@@ -0,0 +1,188 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newOfflineTieredCache points the Redis tier at an address nothing listens
+// on, so only the local tier can serve reads.
+func newOfflineTieredCache(t *testing.T, metrics *CacheMetrics) *TieredCache {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { client.Close() })
+
+	remote := NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute, Metrics: metrics})
+	return NewTieredCache(remote, LocalCacheConfig{Size: 2, TTL: time.Minute, Metrics: metrics})
+}
+
+func TestTieredCache_LocalTier(t *testing.T) {
+	metrics := NewCacheMetrics(prometheus.NewRegistry())
+	cache := newOfflineTieredCache(t, metrics)
+	ctx := context.Background()
+
+	query := domain.LikersCountQuery{RecipientID: "user1"}
+	cache.addLocal(cache.remote.LikersCountKey(query), localEntry{userID: "user1", value: 7}, cache.localEpoch())
+
+	count, err := cache.GetLikersCount(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), count)
+
+	_, err = cache.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user2"})
+	assert.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(tierLocal, entryCount, resultHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(tierLocal, entryCount, resultMiss)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(tierRedis, entryCount, resultError)))
+}
+
+func TestTieredCache_SizeBound(t *testing.T) {
+	cache := newOfflineTieredCache(t, nil)
+
+	for _, id := range []domain.UserID{"user1", "user2", "user3"} {
+		cache.addLocal(cache.remote.watermarkKey(id), localEntry{userID: id, value: 1}, cache.localEpoch())
+	}
+
+	assert.Equal(t, 2, cache.local.Len())
+	_, ok := cache.local.Peek(cache.remote.watermarkKey("user1"))
+	assert.False(t, ok)
+	assert.NotContains(t, cache.userKeys, domain.UserID("user1"), "evicted keys are unindexed")
+}
+
+func TestTieredCache_FillRacingPurge(t *testing.T) {
+	cache := newOfflineTieredCache(t, nil)
+	key := cache.remote.LikersCountKey(domain.LikersCountQuery{RecipientID: "user1"})
+
+	// The fill read its value before the purge and stores it after.
+	epoch := cache.localEpoch()
+	cache.purgeLocalUser("user1")
+	cache.addLocal(key, localEntry{userID: "user1", value: 7}, epoch)
+
+	assert.Zero(t, cache.local.Len())
+	assert.Empty(t, cache.userKeys)
+}
+
+// TestTieredCache_ConcurrentFillAndPurge fills, evicts and purges the same
+// keys at once; run it with -race. Every entry left must stay indexed, so
+// a last purge drops all of them.
+func TestTieredCache_ConcurrentFillAndPurge(t *testing.T) {
+	cache := newOfflineTieredCache(t, nil)
+	users := []domain.UserID{"user1", "user2"}
+	var keys []string
+	for _, userID := range users {
+		keys = append(keys, cache.remote.LikersCountKey(domain.LikersCountQuery{RecipientID: userID}), cache.remote.watermarkKey(userID))
+	}
+
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				key := keys[(worker+i)%len(keys)]
+				userID := users[(worker+i)%len(keys)/2]
+				if worker%4 == 0 {
+					cache.purgeLocalUser(userID)
+					continue
+				}
+				cache.addLocal(key, localEntry{userID: userID, value: uint64(i)}, cache.localEpoch())
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, key := range cache.local.Keys() {
+		entry, ok := cache.local.Peek(key)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, entry.seq, cache.userKeys[entry.userID][key], "%s is indexed", key)
+	}
+
+	for _, userID := range users {
+		cache.purgeLocalUser(userID)
+	}
+	assert.Zero(t, cache.local.Len())
+	assert.Empty(t, cache.userKeys)
+}
+
+func TestTieredCache_ApplyInvalidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		inv      invalidation
+		wantKept []string
+	}{
+		{
+			name:     "keys from another replica",
+			inv:      invalidation{Origin: "other", Keys: []string{"test:seen:{user1}"}},
+			wantKept: []string{"test:count:{user1}", "test:seen:{user2}"},
+		},
+		{
+			name:     "user purge from another replica",
+			inv:      invalidation{Origin: "other", UserID: "user1"},
+			wantKept: []string{"test:seen:{user2}"},
+		},
+		{
+			name:     "own invalidation is ignored",
+			inv:      invalidation{Keys: []string{"test:seen:{user1}"}},
+			wantKept: []string{"test:count:{user1}", "test:seen:{user1}", "test:seen:{user2}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newOfflineTieredCache(t, nil)
+			cache.local.Resize(10)
+			cache.addLocal("test:seen:{user1}", localEntry{userID: "user1", value: 1}, cache.localEpoch())
+			cache.addLocal("test:count:{user1}", localEntry{userID: "user1", value: 2}, cache.localEpoch())
+			cache.addLocal("test:seen:{user2}", localEntry{userID: "user2", value: 3}, cache.localEpoch())
+
+			if tt.inv.Origin == "" {
+				tt.inv.Origin = cache.instance
+			}
+			payload, err := json.Marshal(tt.inv)
+			require.NoError(t, err)
+
+			cache.apply(&redis.Message{Channel: cache.channel, Payload: string(payload)})
+
+			assert.ElementsMatch(t, tt.wantKept, cache.local.Keys())
+		})
+	}
+}
+
+func TestTieredCache_PublishesOnlyChanges(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cache := NewTieredCache(NewRedisCache(client, RedisConfig{Prefix: "test", TTL: time.Minute}), LocalCacheConfig{Size: 10, TTL: time.Minute})
+
+	sub := client.Subscribe(ctx, cache.channel)
+	t.Cleanup(func() { sub.Close() })
+	_, err := sub.Receive(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: "user1", Filter: domain.LikersFilterAll}, nil, nil, 0))
+	require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "user1"}, 3, 0))
+	require.NoError(t, cache.SetSeenWatermark(ctx, "user1", domain.SeenWatermark{Timestamp: 100, ActorID: "user2"}))
+	require.NoError(t, cache.UpdateSeenWatermark(ctx, "user1", domain.SeenWatermark{Timestamp: 120, ActorID: "user2"}))
+
+	// Fills publish nothing, so the first message is the moved watermark.
+	msg, err := sub.ReceiveMessage(ctx)
+	require.NoError(t, err)
+	var inv invalidation
+	require.NoError(t, json.Unmarshal([]byte(msg.Payload), &inv))
+	assert.Equal(t, []string{cache.remote.watermarkKey("user1")}, inv.Keys)
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+// TestAbuseDetection checks that an actor liking too many users is flagged
+// through the Redis counters, that their likes stay stored but drop out of
+// listings, counts and the liker indexes built before the flag, and that
+// clearing the flag brings them back.
+func TestAbuseDetection(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	flags := infraPostgres.NewAbuseFlagRepository(db)
+	pages := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+	index := infraRedis.NewLikerIndex(pages, repo, infraRedis.LikerIndexConfig{TTL: time.Minute})
+	detector := application.NewAbuseDetector(
+		infraRedis.NewAbuseCounters(client, infraRedis.AbuseCountersConfig{Prefix: prefix, Window: time.Hour}),
+		flags, repo, index, application.AbuseDetectorConfig{MaxLikes: 5}, discardLogger{})
+	creator := application.NewDecisionCreator(repo, infraPostgres.NewUserRepository(db), index, detector, application.DecisionCreatorConfig{SuperLikeDailyLimit: 5, AllowUnknownUsers: true}, discardLogger{})
+
+	const bot = "0b7e9c4a-5d21-4f0e-9a63-2c8d1e7f4b90"
+	victims := make([]domain.UserID, 8)
+	for i := range victims {
+		victims[i] = domain.UserID(fmt.Sprintf("6f1c2a8e-3b4d-4c5e-8f90-%012x", i))
+	}
+	indexCount := func(recipientID domain.UserID) uint64 {
+		t.Helper()
+		count, err := index.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: recipientID})
+		require.NoError(t, err)
+		return count
+	}
+
+	for i, victim := range victims {
+		_, err := creator.SaveDecision(ctx, bot, victim, domain.DecisionLike)
+		require.NoError(t, err)
+		if i == 0 {
+			require.Equal(t, uint64(1), indexCount(victim), "the index is built while the bot isn't flagged yet")
+		}
+	}
+	_, err := creator.SaveDecision(ctx, "person", victims[0], domain.DecisionLike)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), indexCount(victims[0]), "flagging drops the bot from indexes built before")
+
+	listed, err := detector.ListFlags(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, domain.UserID(bot), listed[0].UserID)
+	assert.Equal(t, domain.AbuseReasonVelocity, listed[0].Reason)
+
+	assert.Equal(t, len(victims), countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = $1", bot),
+		"decisions of a flagged actor are still stored")
+
+	likers, _, err := repo.GetLikers(ctx, domain.LikersQuery{RecipientID: victims[0], Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"person"}, actorIDs(likers))
+
+	count, err := repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: victims[7]})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	require.NoError(t, detector.ClearFlag(ctx, bot, "oncall", "false positive"))
+	assert.ErrorIs(t, detector.ClearFlag(ctx, bot, "oncall", "again"), domain.ErrAbuseFlagNotFound)
+
+	count, err = repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: victims[7]})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, uint64(2), indexCount(victims[0]), "clearing the flag brings the bot back into indexes")
+
+	// The counters were reset with the flag, so the next decision starts a
+	// new window rather than flagging the actor again.
+	_, err = creator.SaveDecision(ctx, bot, "person", domain.DecisionLike)
+	require.NoError(t, err)
+	listed, err = detector.ListFlags(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+}
+
+// TestAbuseDetection_SequentialIDs checks that an actor walking the users in
+// ID order is flagged through the Redis counters, even when passing on them,
+// and that someone browsing the same users at human speed is not.
+func TestAbuseDetection_SequentialIDs(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	detector := application.NewAbuseDetector(
+		infraRedis.NewAbuseCounters(client, infraRedis.AbuseCountersConfig{Prefix: prefix, Window: time.Hour, RunGap: 200 * time.Millisecond}),
+		infraPostgres.NewAbuseFlagRepository(db), infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{}),
+		infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute}),
+		application.AbuseDetectorConfig{MaxSequentialRun: 5}, discardLogger{})
+
+	const bot = "0b7e9c4a-5d21-4f0e-9a63-2c8d1e7f4b90"
+	for i := range 6 {
+		flagged, err := detector.CheckDecision(ctx, bot, domain.UserID(fmt.Sprintf("6f1c2a8e-3b4d-4c5e-8f90-%012x", i)), domain.DecisionPass)
+		require.NoError(t, err)
+		assert.Equal(t, i == 5, flagged, "decision %d", i)
+	}
+
+	const person = "3c2f8e1d-9a4b-4d7e-8c6f-5b1a0e9d2c47"
+	for i := range 8 {
+		flagged, err := detector.CheckDecision(ctx, person, domain.UserID(fmt.Sprintf("6f1c2a8e-3b4d-4c5e-8f90-%012x", i)), domain.DecisionPass)
+		require.NoError(t, err)
+		assert.False(t, flagged, "decision %d", i)
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	listed, err := detector.ListFlags(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, domain.AbuseReasonSequentialIDs, listed[0].Reason)
+}
@@ -0,0 +1,48 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+	"time"
+)
+
+func TestDecisionRepository_GetTopRecipients(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	now := time.Now().Unix()
+	decisions := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		age              int64
+	}{
+		{"user1", "busy", domain.DecisionLike, 10},
+		{"user2", "busy", domain.DecisionSuperLike, 10},
+		{"user3", "busy", domain.DecisionLike, 10},
+		{"user1", "medium", domain.DecisionLike, 10},
+		{"user2", "medium", domain.DecisionLike, 10},
+		{"user1", "passed", domain.DecisionPass, 10},
+		{"user2", "passed", domain.DecisionPass, 10},
+		{"user3", "passed", domain.DecisionPass, 10},
+		{"user1", "stale", domain.DecisionLike, 7200},
+		{"user2", "stale", domain.DecisionLike, 7200},
+		{"user3", "stale", domain.DecisionLike, 7200},
+		{"user4", "quiet", domain.DecisionLike, 10},
+	}
+	for _, d := range decisions {
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+			d.actor, d.recipient, d.decision, now-d.age)
+		require.NoError(t, err)
+	}
+
+	recipients, err := repo.GetTopRecipients(ctx, uint64(now-3600), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"busy", "medium"}, recipients)
+}
@@ -0,0 +1,32 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+	"time"
+)
+
+func TestDecisionRepository_Cancellation(t *testing.T) {
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "user1", Filter: domain.LikersFilterAll})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// A statement already running on the server is cancelled too.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = db.ExecContext(ctx, "SELECT pg_sleep(5)")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraMemory "muzz-homework/internal/explore/infrastructure/memory"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+	"time"
+)
+
+// TestCandidateSources_Agree checks that the Postgres and in-memory candidate
+// sources return the same candidates for the same decisions.
+func TestCandidateSources_Agree(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	memory := infraMemory.NewCandidateSource(infraMemory.NewDatabase())
+
+	addUsers(t, db, "actor", "liker1", "liker2", "liker3", "passer", "matched", "skipped", "other", "stranger")
+
+	now := time.Now().Unix()
+	decisions := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		age              int64
+	}{
+		{"liker1", "actor", domain.DecisionLike, 30},
+		{"liker2", "actor", domain.DecisionLike, 20},
+		{"liker3", "actor", domain.DecisionSuperLike, 40},
+		{"passer", "actor", domain.DecisionPass, 10},
+		{"matched", "actor", domain.DecisionLike, 50},
+		{"actor", "matched", domain.DecisionLike, 5},
+		{"actor", "skipped", domain.DecisionPass, 5},
+		{"other", "stranger", domain.DecisionLike, 5},
+	}
+	for _, d := range decisions {
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+			d.actor, d.recipient, d.decision, now-d.age)
+		require.NoError(t, err)
+		require.NoError(t, memory.RecordDecision(ctx, d.actor, d.recipient, d.decision, uint64(now-d.age)))
+	}
+
+	postgres := application.NewCandidateProvider(infraPostgres.NewCandidateRepository(db, infraPostgres.CandidateRepositoryConfig{}))
+	inMemory := application.NewCandidateProvider(memory)
+
+	for _, pageSize := range []uint64{2, 4, 20} {
+		want, err := postgres.GetCandidates(ctx, "actor", pageSize)
+		require.NoError(t, err)
+
+		got, err := inMemory.GetCandidates(ctx, "actor", pageSize)
+		require.NoError(t, err)
+
+		assert.Equal(t, want, got, "page size %d", pageSize)
+	}
+
+	candidates, err := postgres.GetCandidates(ctx, "actor", 20)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"liker3", "liker2", "liker1", "other", "passer", "stranger"}, candidates)
+}
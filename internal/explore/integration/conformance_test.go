@@ -0,0 +1,59 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"muzz-homework/internal/explore/domain"
+	"muzz-homework/internal/explore/infrastructure/conformance"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+func TestDecisionRepository_Conformance(t *testing.T) {
+	conformance.TestDecisionRepository(t, func(t *testing.T, likeLifetime time.Duration) conformance.Store {
+		db := newTestDB(t)
+		users := infraPostgres.NewUserRepository(db)
+
+		return conformance.Store{
+			Repo:  infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{LikeLifetime: likeLifetime}),
+			Flags: infraPostgres.NewAbuseFlagRepository(db),
+			AddDecision: func(t *testing.T, actorID domain.UserID, recipientID domain.UserID, decision domain.Decision, timestamp uint64) {
+				_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+					actorID, recipientID, decision, timestamp)
+				if err != nil {
+					t.Fatalf("inserting decision: %v", err)
+				}
+			},
+			SetUserStatus: func(t *testing.T, userID domain.UserID, status domain.UserStatus) {
+				if err := users.UpsertUser(context.Background(), domain.User{ID: userID, Status: status}); err != nil {
+					t.Fatalf("upserting user: %v", err)
+				}
+			},
+		}
+	})
+}
+
+func TestRedisCache_Conformance(t *testing.T) {
+	conformance.TestCache(t, func(t *testing.T, ttl time.Duration) conformance.CacheHarness {
+		client, prefix := newTestRedis(t)
+
+		return conformance.CacheHarness{
+			Cache:  infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: ttl}),
+			Elapse: time.Sleep,
+		}
+	})
+}
+
+func TestAbuseCounters_Conformance(t *testing.T) {
+	conformance.TestAbuseCounters(t, func(t *testing.T, window time.Duration, runGap time.Duration) conformance.AbuseCountersHarness {
+		client, prefix := newTestRedis(t)
+
+		return conformance.AbuseCountersHarness{
+			Counters: infraRedis.NewAbuseCounters(client, infraRedis.AbuseCountersConfig{Prefix: prefix, Window: window, RunGap: runGap}),
+			Elapse:   time.Sleep,
+		}
+	})
+}
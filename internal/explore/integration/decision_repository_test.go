@@ -0,0 +1,277 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"sync"
+	"testing"
+	"time"
+)
+
+// likersPageSize mirrors the repository's page size, which is not configurable.
+const likersPageSize = 20
+
+func TestDecisionRepository_Pagination(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	tests := []struct {
+		name      string
+		likers    int
+		pageSizes []int
+	}{
+		{name: "no likers", likers: 0, pageSizes: []int{0}},
+		{name: "one liker", likers: 1, pageSizes: []int{1}},
+		{name: "exactly one page", likers: likersPageSize, pageSizes: []int{likersPageSize}},
+		{name: "one past a page", likers: likersPageSize + 1, pageSizes: []int{likersPageSize, 1}},
+		{name: "exactly two pages", likers: 2 * likersPageSize, pageSizes: []int{likersPageSize, likersPageSize}},
+		{name: "one past two pages", likers: 2*likersPageSize + 1, pageSizes: []int{likersPageSize, likersPageSize, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipient := domain.UserID(fmt.Sprintf("recipient-%d", tt.likers))
+
+			// Seven likes per second, so pages also break between likes
+			// given in the same second.
+			var expected []domain.UserID
+			for i := tt.likers - 1; i >= 0; i-- {
+				actor := fmt.Sprintf("%s-liker%02d", recipient, i)
+				_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+					actor, recipient, domain.DecisionLike, 1000+i/7)
+				require.NoError(t, err)
+				expected = append(expected, domain.UserID(actor))
+			}
+
+			var pageSizes []int
+			var listed []domain.UserID
+			query := domain.LikersQuery{RecipientID: recipient, Filter: domain.LikersFilterAll}
+			for {
+				likers, next, err := repo.GetLikers(ctx, query)
+				require.NoError(t, err)
+
+				pageSizes = append(pageSizes, len(likers))
+				listed = append(listed, actorIDs(likers)...)
+				if next == nil {
+					break
+				}
+				require.Len(t, likers, likersPageSize, "only full pages have a cursor")
+				query.Cursor = next
+			}
+
+			assert.Equal(t, tt.pageSizes, pageSizes)
+			assert.Equal(t, expected, listed)
+		})
+	}
+
+	t.Run("super-likes first", func(t *testing.T) {
+		var superLikes, likes []domain.UserID
+		for i := 2*likersPageSize + 4; i >= 0; i-- {
+			actor := fmt.Sprintf("mixed-liker%02d", i)
+			decision := domain.DecisionLike
+			if i%3 == 0 {
+				decision = domain.DecisionSuperLike
+				superLikes = append(superLikes, domain.UserID(actor))
+			} else {
+				likes = append(likes, domain.UserID(actor))
+			}
+
+			_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, 'mixed', $2, $3)`,
+				actor, decision, 1000+i/4)
+			require.NoError(t, err)
+		}
+
+		var listed []domain.UserID
+		query := domain.LikersQuery{RecipientID: "mixed", Filter: domain.LikersFilterAll, SuperLikesFirst: true}
+		for {
+			likers, next, err := repo.GetLikers(ctx, query)
+			require.NoError(t, err)
+
+			listed = append(listed, actorIDs(likers)...)
+			if next == nil {
+				break
+			}
+			query.Cursor = next
+		}
+
+		assert.Equal(t, append(superLikes, likes...), listed)
+	})
+}
+
+// TestDecisionRepository_Filters checks that each filter includes or excludes
+// likers by the recipient's own decision about them, mutual likes included.
+func TestDecisionRepository_Filters(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	decisions := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		timestamp        uint64
+	}{
+		{"matched", "recipient", domain.DecisionLike, 400},
+		{"recipient", "matched", domain.DecisionSuperLike, 450},
+		{"rejected", "recipient", domain.DecisionSuperLike, 300},
+		{"recipient", "rejected", domain.DecisionPass, 350},
+		{"pending", "recipient", domain.DecisionLike, 200},
+		{"passer", "recipient", domain.DecisionPass, 100},
+		{"recipient", "unrequited", domain.DecisionLike, 500},
+	}
+	for _, d := range decisions {
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+			d.actor, d.recipient, d.decision, d.timestamp)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		filter   domain.LikersFilter
+		expected []domain.UserID
+	}{
+		{domain.LikersFilterAll, []domain.UserID{"matched", "pending"}},
+		{domain.LikersFilterPending, []domain.UserID{"pending"}},
+		{domain.LikersFilterMatched, []domain.UserID{"matched"}},
+		{domain.LikersFilterRejected, []domain.UserID{"rejected"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.filter), func(t *testing.T) {
+			likers, next, err := repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: tt.filter})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actorIDs(likers))
+			assert.Nil(t, next)
+		})
+	}
+
+	_, _, err := repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: "unknown"})
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+
+	count, err := repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count, "counts match the all filter")
+}
+
+func TestDecisionRepository_InsertDecision(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	steps := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		mutual           bool
+	}{
+		{"alice", "bob", domain.DecisionLike, false},
+		{"bob", "alice", domain.DecisionPass, false},
+		{"bob", "alice", domain.DecisionLike, true},
+		{"alice", "bob", domain.DecisionSuperLike, true},
+		{"alice", "bob", domain.DecisionPass, false},
+		{"bob", "alice", domain.DecisionSuperLike, false},
+	}
+
+	for i, step := range steps {
+		mutual, _, err := repo.InsertDecision(ctx, step.actor, step.recipient, step.decision, nil)
+		require.NoError(t, err)
+		assert.Equal(t, step.mutual, mutual, "step %d", i)
+	}
+
+	// Upserts replace the decision in place rather than adding rows.
+	assert.Equal(t, 2, countRows(t, db, "SELECT COUNT(*) FROM user_decisions"))
+	assert.Equal(t, 1, countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = 'alice' AND decision = $1 AND NOT liked_recipient", domain.DecisionPass))
+	assert.Equal(t, 1, countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = 'bob' AND decision = $1 AND liked_recipient", domain.DecisionSuperLike))
+
+	t.Run("refreshes the timestamp", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE user_decisions SET decision_timestamp = 1 WHERE actor_user_id = 'bob'`)
+		require.NoError(t, err)
+
+		before := time.Now().Unix()
+		_, _, err = repo.InsertDecision(ctx, "bob", "alice", domain.DecisionLike, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = 'bob' AND decision_timestamp >= $1", before))
+	})
+}
+
+func TestDecisionRepository_ConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	// run calls fn from n goroutines released together.
+	run := func(n int, fn func(i int)) {
+		var start, done sync.WaitGroup
+		start.Add(1)
+		for i := 0; i < n; i++ {
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				start.Wait()
+				fn(i)
+			}()
+		}
+		start.Done()
+		done.Wait()
+	}
+
+	t.Run("simultaneous mutual likes", func(t *testing.T) {
+		const pairs = 25
+
+		// Exactly one side of each pair sees the other's like; without the
+		// pair lock both could miss it and the match would go unreported.
+		mutual := make([]bool, 2*pairs)
+		errs := make([]error, 2*pairs)
+		run(2*pairs, func(i int) {
+			a, b := domain.UserID(fmt.Sprintf("pair%02d-a", i/2)), domain.UserID(fmt.Sprintf("pair%02d-b", i/2))
+			if i%2 == 1 {
+				a, b = b, a
+			}
+			mutual[i], _, errs[i] = repo.InsertDecision(ctx, a, b, domain.DecisionLike, nil)
+		})
+
+		for i := 0; i < pairs; i++ {
+			require.NoError(t, errs[2*i])
+			require.NoError(t, errs[2*i+1])
+			assert.True(t, mutual[2*i] != mutual[2*i+1], "pair %d: mutual %v and %v", i, mutual[2*i], mutual[2*i+1])
+		}
+	})
+
+	t.Run("same pair", func(t *testing.T) {
+		errs := make([]error, 20)
+		run(len(errs), func(i int) {
+			decision := domain.DecisionLike
+			if i%2 == 0 {
+				decision = domain.DecisionPass
+			}
+			_, _, errs[i] = repo.InsertDecision(ctx, "fickle", "target", decision, nil)
+		})
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = 'fickle'"))
+	})
+
+	t.Run("many likers", func(t *testing.T) {
+		const likers = 50
+
+		errs := make([]error, likers)
+		run(likers, func(i int) {
+			_, _, errs[i] = repo.InsertDecision(ctx, domain.UserID(fmt.Sprintf("fan%02d", i)), "popular", domain.DecisionLike, nil)
+		})
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+
+		count, err := repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "popular"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(likers), count)
+	})
+}
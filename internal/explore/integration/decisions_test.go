@@ -0,0 +1,99 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+)
+
+func TestDecisionRepository_ListDecisions(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	live := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+		timestamp        uint64
+	}{
+		{"user1", "user2", domain.DecisionLike, 300},
+		{"user1", "user3", domain.DecisionPass, 200},
+		{"user2", "user1", domain.DecisionSuperLike, 250},
+		{"user3", "user2", domain.DecisionLike, 150},
+	}
+	for _, d := range live {
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, $3, $4)`,
+			d.actor, d.recipient, d.decision, d.timestamp)
+		require.NoError(t, err)
+	}
+
+	_, err := db.Exec(`INSERT INTO user_decisions_archive (actor_user_id, recipient_user_id, decision, decision_timestamp, archived_at) VALUES ($1, $2, $3, $4, $5)`,
+		"user1", "user4", domain.DecisionLike, 100, 400)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    domain.DecisionsQuery
+		expected []domain.DecisionRecord
+	}{
+		{
+			name:  "by actor",
+			query: domain.DecisionsQuery{ActorID: "user1"},
+			expected: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike, Timestamp: 300},
+				{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionPass, Timestamp: 200},
+			},
+		},
+		{
+			name:  "by actor including archived",
+			query: domain.DecisionsQuery{ActorID: "user1", IncludeArchived: true},
+			expected: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike, Timestamp: 300},
+				{ActorID: "user1", RecipientID: "user3", Decision: domain.DecisionPass, Timestamp: 200},
+				{ActorID: "user1", RecipientID: "user4", Decision: domain.DecisionLike, Timestamp: 100, Archived: true},
+			},
+		},
+		{
+			name:  "by recipient",
+			query: domain.DecisionsQuery{RecipientID: "user2"},
+			expected: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike, Timestamp: 300},
+				{ActorID: "user3", RecipientID: "user2", Decision: domain.DecisionLike, Timestamp: 150},
+			},
+		},
+		{
+			name:  "by pair",
+			query: domain.DecisionsQuery{ActorID: "user2", RecipientID: "user1", IncludeArchived: true},
+			expected: []domain.DecisionRecord{
+				{ActorID: "user2", RecipientID: "user1", Decision: domain.DecisionSuperLike, Timestamp: 250},
+			},
+		},
+		{
+			name:  "limited",
+			query: domain.DecisionsQuery{ActorID: "user1", IncludeArchived: true, Limit: 1},
+			expected: []domain.DecisionRecord{
+				{ActorID: "user1", RecipientID: "user2", Decision: domain.DecisionLike, Timestamp: 300},
+			},
+		},
+		{
+			name:  "unknown user",
+			query: domain.DecisionsQuery{ActorID: "user9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := repo.ListDecisions(ctx, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+
+	_, err = repo.ListDecisions(ctx, domain.DecisionsQuery{})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
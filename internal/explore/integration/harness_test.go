@@ -0,0 +1,143 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/lib/pq"
+	goredis "github.com/redis/go-redis/v9"
+	"muzz-homework/internal/explore/domain"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+const migrationsDir = "../../../migrations"
+
+// newTestDB creates a throwaway schema on the Postgres server configured via
+// INTEGRATION_POSTGRES_DSN, runs every migration in it and drops it when the
+// test finishes.
+func newTestDB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("INTEGRATION_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("INTEGRATION_POSTGRES_DSN is not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("opening postgres: %v", err)
+	}
+
+	schema := fmt.Sprintf("it_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	db, err := sql.Open("postgres", withSearchPath(t, dsn, schema))
+	if err != nil {
+		t.Fatalf("opening postgres: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		t.Fatalf("listing migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	// Each file runs as one simple query, as migrate does, so the
+	// single-statement migrations run outside a transaction block.
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading migration %s: %v", file, err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			t.Fatalf("running migration %s: %v", file, err)
+		}
+	}
+
+	return db
+}
+
+func withSearchPath(t testing.TB, dsn string, schema string) string {
+	t.Helper()
+
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("parsing INTEGRATION_POSTGRES_DSN: %v", err)
+	}
+
+	query := parsed.Query()
+	query.Set("search_path", schema)
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
+
+// newTestRedis connects to INTEGRATION_REDIS_ADDR and returns a key prefix
+// unique to the test. Every key under the prefix is removed afterwards.
+func newTestRedis(t *testing.T) (*goredis.Client, string) {
+	t.Helper()
+
+	addr := os.Getenv("INTEGRATION_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("INTEGRATION_REDIS_ADDR is not set")
+	}
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("connecting to redis: %v", err)
+	}
+
+	prefix := fmt.Sprintf("it-%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		ctx := context.Background()
+		iter := client.Scan(ctx, 0, prefix+":*", 100).Iterator()
+		for iter.Next(ctx) {
+			client.Del(ctx, iter.Val())
+		}
+		client.Close()
+	})
+
+	return client, prefix
+}
+
+func countRows(t *testing.T, db *sql.DB, query string, args ...any) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow(query, args...).Scan(&count); err != nil {
+		t.Fatalf("counting rows: %v", err)
+	}
+	return count
+}
+
+// addUsers provisions active users, which the candidates feed lists.
+func addUsers(t *testing.T, db *sql.DB, userIDs ...domain.UserID) {
+	t.Helper()
+
+	for _, id := range userIDs {
+		if _, err := db.Exec(`INSERT INTO users (user_id, created_at, updated_at) VALUES ($1, 0, 0)`, id); err != nil {
+			t.Fatalf("inserting user: %v", err)
+		}
+	}
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Info(msg string, args ...any) {}
+
+func (discardLogger) Error(msg string, args ...any) {}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+// TestLikerIndex_MatchesRepository checks that pages and counts served by the
+// index match Postgres, both after a build and after incremental updates.
+func TestLikerIndex_MatchesRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	pages := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+	index := infraRedis.NewLikerIndex(pages, repo, infraRedis.LikerIndexConfig{TTL: time.Minute})
+	creator := application.NewDecisionCreator(repo, infraPostgres.NewUserRepository(db), index, nil, application.DecisionCreatorConfig{SuperLikeDailyLimit: 5, AllowUnknownUsers: true}, discardLogger{})
+
+	// Three likes per second, so pages also break between likes given in the
+	// same second.
+	now := time.Now().Unix()
+	for i := 0; i < 45; i++ {
+		decision := domain.DecisionLike
+		if i%7 == 0 {
+			decision = domain.DecisionSuperLike
+		}
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, 'recipient', $2, $3)`,
+			fmt.Sprintf("liker%02d", i), decision, now-int64(1000-i/3))
+		require.NoError(t, err)
+	}
+	for i, decision := range []domain.Decision{domain.DecisionLike, domain.DecisionPass, domain.DecisionSuperLike, domain.DecisionPass} {
+		_, err := db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ('recipient', $1, $2, $3)`,
+			fmt.Sprintf("liker%02d", i*5), decision, now-2000)
+		require.NoError(t, err)
+	}
+
+	// exact is off once decisions are saved through the creator, as the
+	// repository and the index each take the current time and may land on
+	// different seconds.
+	assertMatches := func(t *testing.T, exact bool) {
+		t.Helper()
+
+		filters := []domain.LikersFilter{domain.LikersFilterAll, domain.LikersFilterPending, domain.LikersFilterMatched, domain.LikersFilterRejected}
+		for _, filter := range filters {
+			query := domain.LikersQuery{RecipientID: "recipient", Filter: filter}
+			for {
+				want, wantNext, err := repo.GetLikers(ctx, query)
+				require.NoError(t, err)
+
+				got, gotNext, err := index.GetLikers(ctx, query)
+				require.NoError(t, err)
+
+				if exact {
+					assert.Equal(t, want, got, "filter %s", filter)
+				} else {
+					assert.Equal(t, actorIDs(want), actorIDs(got), "filter %s", filter)
+				}
+				assert.Equal(t, wantNext, gotNext, "filter %s", filter)
+
+				if wantNext == nil || gotNext == nil {
+					break
+				}
+				query.Cursor = wantNext
+			}
+		}
+
+		// Seen up to the middle of a second holding a rejected liker.
+		seen := domain.SeenWatermark{Timestamp: uint64(now - 995), ActorID: "liker16"}
+		for _, query := range []domain.LikersCountQuery{{RecipientID: "recipient"}, {RecipientID: "recipient", SeenUpTo: &seen}} {
+			want, err := repo.GetLikersCount(ctx, query)
+			require.NoError(t, err)
+
+			got, err := index.GetLikersCount(ctx, query)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	}
+
+	assertMatches(t, true)
+
+	// The index is built now, so these are applied to it in place.
+	for _, d := range []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+	}{
+		{"newcomer", "recipient", domain.DecisionLike},
+		{"liker01", "recipient", domain.DecisionPass},
+		{"liker02", "recipient", domain.DecisionPass},
+		{"recipient", "liker03", domain.DecisionPass},
+		{"recipient", "liker06", domain.DecisionLike},
+	} {
+		_, err := creator.SaveDecision(ctx, d.actor, d.recipient, d.decision)
+		require.NoError(t, err)
+	}
+
+	assertMatches(t, false)
+
+	require.NoError(t, index.PurgeUser(ctx, "recipient"))
+	keys, err := client.Keys(ctx, prefix+":index:{recipient}*").Result()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func actorIDs(likers []domain.LikerInfo) []domain.UserID {
+	ids := make([]domain.UserID, len(likers))
+	for i, liker := range likers {
+		ids[i] = liker.ActorID
+	}
+	return ids
+}
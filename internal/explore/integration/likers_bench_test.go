@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+)
+
+const benchLikers = 20000
+
+// BenchmarkDecisionRepository_GetLikers measures listing the likers of a
+// popular recipient, with mutual likes included (all) and excluded
+// (pending), on the first page and deep into the listing.
+func BenchmarkDecisionRepository_GetLikers(b *testing.B) {
+	ctx := context.Background()
+	db := newTestDB(b)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+
+	// The hot recipient likes back a third of its likers and passes on a
+	// tenth; everyone else gets a handful of likes so the index isn't
+	// dominated by one recipient.
+	seed := []string{
+		`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp)
+		 SELECT 'liker-' || i, 'hot', 1, 1000000 + i FROM generate_series(1, $1) AS i`,
+		`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp)
+		 SELECT 'hot', 'liker-' || i, CASE WHEN i % 3 = 0 THEN 1 ELSE 0 END, 2000000 + i
+		 FROM generate_series(1, $1) AS i WHERE i % 3 = 0 OR i % 10 = 1`,
+		`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp)
+		 SELECT 'liker-' || i, 'other-' || (i % 1000), 1, 1000000 + i FROM generate_series(1, $1) AS i`,
+	}
+	for _, query := range seed {
+		if _, err := db.ExecContext(ctx, query, benchLikers); err != nil {
+			b.Fatalf("seeding decisions: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, "ANALYZE user_decisions"); err != nil {
+		b.Fatalf("analyzing decisions: %v", err)
+	}
+
+	deep := &domain.Cursor{Timestamp: 1000000 + benchLikers/10}
+
+	benchmarks := []struct {
+		name   string
+		filter domain.LikersFilter
+		cursor *domain.Cursor
+	}{
+		{"with mutual/first page", domain.LikersFilterAll, nil},
+		{"with mutual/deep page", domain.LikersFilterAll, deep},
+		{"excluding mutual/first page", domain.LikersFilterPending, nil},
+		{"excluding mutual/deep page", domain.LikersFilterPending, deep},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			query := domain.LikersQuery{RecipientID: "hot", Filter: bm.filter, Cursor: bm.cursor}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				likers, _, err := repo.GetLikers(ctx, query)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(likers) == 0 {
+					b.Fatal("no likers")
+				}
+			}
+		})
+	}
+}
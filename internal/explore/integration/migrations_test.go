@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMigration_LowercaseUserIDs runs the ID normalization again over rows
+// written with uppercase IDs, some of which collide with lowercase ones.
+func TestMigration_LowercaseUserIDs(t *testing.T) {
+	db := newTestDB(t)
+
+	const (
+		upperA = "AAAAAAAA-0000-4000-8000-000000000001"
+		mixedA = "Aaaaaaaa-0000-4000-8000-000000000001"
+		lowerA = "aaaaaaaa-0000-4000-8000-000000000001"
+		upperB = "BBBBBBBB-0000-4000-8000-000000000002"
+		lowerB = "bbbbbbbb-0000-4000-8000-000000000002"
+		upperC = "CCCCCCCC-0000-4000-8000-000000000003"
+		lowerC = "cccccccc-0000-4000-8000-000000000003"
+	)
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, 0, 100)`, []any{upperA, upperB}},
+		{`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, 1, 200)`, []any{lowerA, lowerB}},
+		{`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, 2, 300)`, []any{upperC, lowerA}},
+		{`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, 0, 500)`, []any{upperA, upperC}},
+		{`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ($1, $2, 1, 400)`, []any{mixedA, upperC}},
+		{`INSERT INTO user_decisions_archive (actor_user_id, recipient_user_id, decision, decision_timestamp, archived_at) VALUES ($1, $2, 1, 50, 60)`, []any{upperB, upperC}},
+		{`INSERT INTO liker_seen_watermarks (recipient_user_id, seen_up_to) VALUES ($1, 150), ($2, 120)`, []any{upperA, lowerA}},
+		{`INSERT INTO users (user_id, status, created_at, updated_at) VALUES ($1, 'banned', 0, 20), ($2, 'active', 0, 10), ($3, 'active', 0, 0)`, []any{upperA, lowerA, upperC}},
+		{`INSERT INTO abuse_flags (user_id, reason, flagged_at) VALUES ($1, 'velocity', 10)`, []any{upperC}},
+	}
+	for _, statement := range statements {
+		_, err := db.Exec(statement.query, statement.args...)
+		require.NoError(t, err)
+	}
+
+	migration, err := os.ReadFile(filepath.Join(migrationsDir, "00016_lowercase_user_ids.up.sql"))
+	require.NoError(t, err)
+	_, err = db.Exec(string(migration))
+	require.NoError(t, err)
+
+	assert.Zero(t, countRows(t, db, `SELECT COUNT(*) FROM user_decisions WHERE actor_user_id <> lower(actor_user_id) OR recipient_user_id <> lower(recipient_user_id)`))
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = $1 AND recipient_user_id = $2 AND decision = 1`, lowerA, lowerB),
+		"newer of the colliding decisions is kept")
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = $1 AND recipient_user_id = $2`, lowerC, lowerA))
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = $1 AND recipient_user_id = $2 AND decision_timestamp = 500`, lowerA, lowerC),
+		"newer of two uppercase variants without a lowercase row is kept")
+	assert.Equal(t, 3, countRows(t, db, `SELECT COUNT(*) FROM user_decisions`))
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM user_decisions_archive WHERE actor_user_id = $1 AND recipient_user_id = $2`, lowerB, lowerC))
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM liker_seen_watermarks WHERE recipient_user_id = $1 AND seen_up_to = 150`, lowerA),
+		"higher of the colliding watermarks is kept")
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM users WHERE user_id = $1 AND status = 'banned'`, lowerA),
+		"most recently updated of the colliding users is kept")
+	assert.Equal(t, 2, countRows(t, db, `SELECT COUNT(*) FROM users`))
+	assert.Equal(t, 1, countRows(t, db, `SELECT COUNT(*) FROM abuse_flags WHERE user_id = $1`, lowerC))
+}
@@ -0,0 +1,56 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+// TestProfileCache checks that profiles are read from the users table, that
+// non-active users have none, and that cached profiles are served until
+// purged.
+func TestProfileCache(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+	users := infraPostgres.NewUserRepository(db)
+
+	birthDate := time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "ann", Status: domain.UserStatusActive, DisplayName: "Ann", PhotoURL: "https://example.com/ann.jpg", BirthDate: birthDate}))
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "bob", Status: domain.UserStatusActive, DisplayName: "Bob"}))
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "eve", Status: domain.UserStatusBanned, DisplayName: "Eve"}))
+
+	pages := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+	profiles := infraRedis.NewProfileCache(pages, users, time.Minute)
+
+	want := map[domain.UserID]domain.Profile{
+		"ann": {UserID: "ann", Name: "Ann", PhotoURL: "https://example.com/ann.jpg", BirthDate: birthDate},
+		"bob": {UserID: "bob", Name: "Bob"},
+	}
+
+	found, err := users.GetProfiles(ctx, "ann", "bob", "eve", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, want, found)
+
+	found, err = profiles.GetProfiles(ctx, "ann", "bob", "eve", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, want, found)
+
+	// Served from Redis until the entry expires or the user is purged.
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "bob", Status: domain.UserStatusActive, DisplayName: "Robert"}))
+	found, err = profiles.GetProfiles(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", found["bob"].Name)
+
+	require.NoError(t, pages.PurgeUser(ctx, "bob"))
+	found, err = profiles.GetProfiles(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, "Robert", found["bob"].Name)
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedisCache_KeysAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	client, prefix := newTestRedis(t)
+
+	const ttl = time.Second
+	cache := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: ttl})
+
+	seenUpTo := domain.SeenWatermark{Timestamp: 100, ActorID: "liker"}
+	likersQuery := domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterPending, SeenUpTo: &seenUpTo}
+	countQuery := domain.LikersCountQuery{RecipientID: "recipient"}
+	likers := []domain.LikerInfo{{ActorID: "liker", Timestamp: 150, Decision: domain.DecisionLike}}
+
+	require.NoError(t, cache.SetLikers(ctx, likersQuery, likers, &domain.Cursor{Timestamp: 150}, 0))
+	require.NoError(t, cache.SetLikersCount(ctx, countQuery, 1, 0))
+	require.NoError(t, cache.SetSeenWatermark(ctx, "recipient", seenUpTo))
+
+	// Every key is under the prefix and carries the recipient's hash tag.
+	keys, err := client.Keys(ctx, prefix+":*").Result()
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+	for _, key := range keys {
+		assert.Contains(t, key, "{recipient}")
+
+		remaining, err := client.PTTL(ctx, key).Result()
+		require.NoError(t, err)
+		assert.True(t, remaining > 0 && remaining <= ttl, "%s expires in %s", key, remaining)
+	}
+	assert.True(t, strings.HasPrefix(cache.LikersKey(likersQuery), prefix+":likers:{recipient}:"))
+
+	cachedLikers, next, err := cache.GetLikers(ctx, likersQuery)
+	require.NoError(t, err)
+	assert.Equal(t, likers, cachedLikers)
+	assert.Equal(t, &domain.Cursor{Timestamp: 150}, next)
+
+	// Queries differing only in the watermark or filter use other keys.
+	_, _, err = cache.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterPending})
+	assert.ErrorIs(t, err, goredis.Nil)
+	_, _, err = cache.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll, SeenUpTo: &seenUpTo})
+	assert.ErrorIs(t, err, goredis.Nil)
+
+	time.Sleep(ttl + 100*time.Millisecond)
+
+	_, _, err = cache.GetLikers(ctx, likersQuery)
+	assert.ErrorIs(t, err, goredis.Nil)
+	_, err = cache.GetLikersCount(ctx, countQuery)
+	assert.ErrorIs(t, err, goredis.Nil)
+	_, err = cache.GetSeenWatermark(ctx, "recipient")
+	assert.ErrorIs(t, err, goredis.Nil)
+}
+
+// TestDecisionProvider_CacheExpiry checks the read path end to end: listings
+// and counts are served from Redis until their entries expire, then reloaded
+// from Postgres.
+func TestDecisionProvider_CacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	const ttl = time.Second
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	cache := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: ttl})
+	tokens, err := domain.NewTokenCodec([]domain.SigningKey{{ID: "test", Secret: []byte("secret")}}, time.Hour)
+	require.NoError(t, err)
+	provider := application.NewDecisionProvider(repo, cache, infraPostgres.NewUserRepository(db), tokens)
+
+	_, _, err = repo.InsertDecision(ctx, "first", "recipient", domain.DecisionLike, nil)
+	require.NoError(t, err)
+
+	likers, _, err := provider.ListLikedYou(ctx, "recipient", "", domain.ListLikersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"first"}, actorIDs(likers))
+
+	count, err := provider.CountLikedYou(ctx, "recipient", domain.CountLikersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count.Total)
+
+	// A new like is not visible while the cached entries live...
+	_, err = db.Exec(`INSERT INTO user_decisions (actor_user_id, recipient_user_id, decision, decision_timestamp) VALUES ('second', 'recipient', $1, $2)`,
+		domain.DecisionLike, time.Now().Unix()+1)
+	require.NoError(t, err)
+
+	likers, _, err = provider.ListLikedYou(ctx, "recipient", "", domain.ListLikersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"first"}, actorIDs(likers))
+
+	// ...and shows up once they expire.
+	time.Sleep(ttl + 100*time.Millisecond)
+
+	likers, _, err = provider.ListLikedYou(ctx, "recipient", "", domain.ListLikersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"second", "first"}, actorIDs(likers))
+
+	count, err = provider.CountLikedYou(ctx, "recipient", domain.CountLikersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count.Total)
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/domain"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"testing"
+	"time"
+)
+
+func TestTieredCache_CrossReplicaInvalidation(t *testing.T) {
+	client, prefix := newTestRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remote := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+	newReplica := func() *infraRedis.TieredCache {
+		cache := infraRedis.NewTieredCache(remote, infraRedis.LocalCacheConfig{Size: 100, TTL: time.Minute})
+		go cache.Run(ctx)
+		return cache
+	}
+	replicaA, replicaB := newReplica(), newReplica()
+
+	// Give both subscriptions time to be confirmed.
+	time.Sleep(100 * time.Millisecond)
+
+	query := domain.LikersCountQuery{RecipientID: "user1"}
+	require.NoError(t, replicaA.SetLikersCount(ctx, query, 1, time.Millisecond))
+
+	count, err := replicaB.GetLikersCount(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// replicaB now serves the count from its local tier until replicaA's
+	// write invalidates it.
+	require.NoError(t, replicaA.SetLikersCount(ctx, query, 2, time.Millisecond))
+	assert.Eventually(t, func() bool {
+		count, err := replicaB.GetLikersCount(ctx, query)
+		return err == nil && count == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, replicaA.PurgeUser(ctx, "user1"))
+	assert.Eventually(t, func() bool {
+		_, err := replicaB.GetLikersCount(ctx, query)
+		return err != nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,119 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	infraRedis "muzz-homework/internal/explore/infrastructure/redis"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserDataManager_EraseUser(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	cache := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+
+	decisions := []struct {
+		actor, recipient domain.UserID
+		decision         domain.Decision
+	}{
+		{"erased", "user2", domain.DecisionLike},
+		{"erased", "user3", domain.DecisionSuperLike},
+		{"erased", "user4", domain.DecisionPass},
+		{"user2", "erased", domain.DecisionLike},
+		{"user5", "erased", domain.DecisionPass},
+		{"user5", "user2", domain.DecisionLike},
+	}
+	for _, d := range decisions {
+		_, _, err := repo.InsertDecision(ctx, d.actor, d.recipient, d.decision, nil)
+		require.NoError(t, err)
+	}
+
+	_, err := repo.SetSeenWatermark(ctx, "erased", domain.SeenWatermark{Timestamp: 1, ActorID: "user2"})
+	require.NoError(t, err)
+
+	users := infraPostgres.NewUserRepository(db)
+	profiles := infraRedis.NewProfileCache(cache, users, time.Minute)
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "erased", Status: domain.UserStatusActive, DisplayName: "Ann",
+		PhotoURL: "https://example.com/ann.jpg", BirthDate: time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC)}))
+	found, err := profiles.GetProfiles(ctx, "erased")
+	require.NoError(t, err)
+	require.Contains(t, found, domain.UserID("erased"))
+
+	user2Count := domain.LikersCountQuery{RecipientID: "user2"}
+	require.NoError(t, cache.SetLikersCount(ctx, user2Count, 2, 0))
+	require.NoError(t, cache.SetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "erased"}, 1, 0))
+	require.NoError(t, cache.SetLikers(ctx, domain.LikersQuery{RecipientID: "erased", Filter: domain.LikersFilterAll}, nil, nil, 0))
+
+	// A flag marker left behind would shadow-ban the ID again.
+	counters := infraRedis.NewAbuseCounters(client, infraRedis.AbuseCountersConfig{Prefix: prefix, Window: time.Hour})
+	_, err = counters.RecordDecision(ctx, "erased", "user2", domain.DecisionLike)
+	require.NoError(t, err)
+	require.NoError(t, counters.MarkFlagged(ctx, "erased"))
+
+	manager := application.NewUserDataManager(repo, cache, counters, discardLogger{}, 2)
+	result, err := manager.EraseUser(ctx, "erased", "oncall", "account deleted")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), result.DecisionsDeleted)
+	assert.Equal(t, uint64(2), result.CountersRecounted)
+
+	assert.Equal(t, 0, countRows(t, db, "SELECT COUNT(*) FROM user_decisions WHERE actor_user_id = $1 OR recipient_user_id = $1", "erased"))
+	assert.Equal(t, 0, countRows(t, db, "SELECT COUNT(*) FROM liker_seen_watermarks WHERE recipient_user_id = $1", "erased"))
+	assert.Equal(t, 1, countRows(t, db, "SELECT COUNT(*) FROM user_decisions"))
+
+	recounted, err := cache.GetLikersCount(ctx, user2Count)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), recounted)
+
+	keys, err := client.Keys(ctx, prefix+":*erased*").Result()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// The profile is dropped, in Postgres and in the cache, and the user is
+	// kept as deleted.
+	found, err = profiles.GetProfiles(ctx, "erased")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	assert.Equal(t, 0, countRows(t, db, "SELECT COUNT(*) FROM users WHERE user_id = $1 AND (display_name <> '' OR photo_url <> '' OR birth_date IS NOT NULL)", "erased"))
+	erased, err := users.GetUsers(ctx, "erased")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusDeleted, erased["erased"].Status)
+}
+
+func TestUserDataManager_ExportUserData(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client, prefix := newTestRedis(t)
+
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	cache := infraRedis.NewRedisCache(client, infraRedis.RedisConfig{Prefix: prefix, TTL: time.Minute})
+
+	_, _, err := repo.InsertDecision(ctx, "exported", "user2", domain.DecisionLike, nil)
+	require.NoError(t, err)
+	_, _, err = repo.InsertDecision(ctx, "user3", "exported", domain.DecisionPass, nil)
+	require.NoError(t, err)
+	_, _, err = repo.InsertDecision(ctx, "user3", "user2", domain.DecisionLike, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	manager := application.NewUserDataManager(repo, cache, nil, discardLogger{}, 100)
+	require.NoError(t, manager.ExportUserData(ctx, "exported", "oncall", &buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Contains(t, line, `"exported"`)
+	}
+}
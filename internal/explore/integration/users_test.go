@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraPostgres "muzz-homework/internal/explore/infrastructure/postgres"
+	"testing"
+)
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	users := infraPostgres.NewUserRepository(db)
+
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "user1", Status: domain.UserStatusActive, DisplayName: "Ann"}))
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "user2", Status: domain.UserStatusPaused}))
+	require.NoError(t, users.UpsertUser(ctx, domain.User{ID: "user1", Status: domain.UserStatusActive, DisplayName: "Anna"}))
+	require.NoError(t, users.SetUserStatus(ctx, "user2", domain.UserStatusBanned))
+
+	found, err := users.GetUsers(ctx, "user1", "user2", "missing")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "Anna", found["user1"].DisplayName)
+	assert.NotZero(t, found["user1"].CreatedAt)
+	assert.Equal(t, domain.UserStatusBanned, found["user2"].Status)
+
+	require.NoError(t, users.SetUserStatus(ctx, "user3", domain.UserStatusBanned))
+	found, err = users.GetUsers(ctx, "user3")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusBanned, found["user3"].Status)
+
+	assert.ErrorIs(t, users.UpsertUser(ctx, domain.User{ID: "user3", Status: "frozen"}), domain.ErrInvalidInput)
+}
+
+// TestHiddenUsers checks that decisions involving non-active users or users
+// without a row are rejected, and that likes of non-active users are hidden
+// from listings and counts.
+func TestHiddenUsers(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	users := infraPostgres.NewUserRepository(db)
+	repo := infraPostgres.NewDecisionRepository(db, infraPostgres.DecisionRepositoryConfig{})
+	creator := application.NewDecisionCreator(repo, users, nil, nil, application.DecisionCreatorConfig{SuperLikeDailyLimit: 5}, discardLogger{})
+
+	for _, id := range []domain.UserID{"active", "paused", "banned", "deleted", "recipient"} {
+		require.NoError(t, users.UpsertUser(ctx, domain.User{ID: id, Status: domain.UserStatusActive}))
+	}
+	for _, actor := range []domain.UserID{"active", "paused", "banned"} {
+		_, err := creator.SaveDecision(ctx, actor, "recipient", domain.DecisionLike)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, users.SetUserStatus(ctx, "paused", domain.UserStatusPaused))
+	require.NoError(t, users.SetUserStatus(ctx, "banned", domain.UserStatusBanned))
+
+	likers, _, err := repo.GetLikers(ctx, domain.LikersQuery{RecipientID: "recipient", Filter: domain.LikersFilterAll})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"active"}, actorIDs(likers))
+
+	count, err := repo.GetLikersCount(ctx, domain.LikersCountQuery{RecipientID: "recipient"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	index, err := repo.GetLikerIndex(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"active"}, actorIDs(index.Likers))
+
+	_, err = creator.SaveDecision(ctx, "banned", "recipient", domain.DecisionLike)
+	assert.ErrorIs(t, err, domain.ErrUserNotActive)
+
+	_, err = creator.SaveDecision(ctx, "recipient", "paused", domain.DecisionLike)
+	assert.ErrorIs(t, err, domain.ErrUserNotActive)
+
+	require.NoError(t, users.SetUserStatus(ctx, "deleted", domain.UserStatusDeleted))
+	_, err = creator.SaveDecision(ctx, "recipient", "deleted", domain.DecisionLike)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = creator.SaveDecision(ctx, "recipient", "unknown", domain.DecisionLike)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	lenient := application.NewDecisionCreator(repo, users, nil, nil, application.DecisionCreatorConfig{SuperLikeDailyLimit: 5, AllowUnknownUsers: true}, discardLogger{})
+	_, err = lenient.SaveDecision(ctx, "recipient", "unknown", domain.DecisionLike)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,4 @@
+CREATE TABLE liker_seen_watermarks (
+                                       recipient_user_id VARCHAR(36) PRIMARY KEY,
+                                       seen_up_to BIGINT NOT NULL
+);
@@ -0,0 +1,30 @@
+-- decision replaces liked_recipient, which stays for the partial indexes and
+-- queries filtering on it. The column is added without a default so no row is
+-- rewritten; the next migration fills it in batches.
+ALTER TABLE user_decisions ADD COLUMN decision SMALLINT;
+
+-- Enforced on every write from now on, and validated once the backfill is
+-- done.
+ALTER TABLE user_decisions
+    ADD CONSTRAINT chk_user_decisions_decision
+        CHECK (decision IS NOT NULL AND decision IN (0, 1, 2)) NOT VALID;
+
+-- Keeps the two columns in step. Writers that only set liked_recipient, such
+-- as replicas still running the previous release, get the matching decision;
+-- otherwise liked_recipient follows decision.
+CREATE FUNCTION sync_liked_recipient() RETURNS trigger AS $$
+BEGIN
+    IF NEW.decision IS NULL
+        OR (TG_OP = 'UPDATE' AND NEW.decision = OLD.decision
+            AND NEW.liked_recipient IS DISTINCT FROM OLD.liked_recipient) THEN
+        NEW.decision := CASE WHEN NEW.liked_recipient THEN 1 ELSE 0 END;
+    END IF;
+
+    NEW.liked_recipient := NEW.decision > 0;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_sync_liked_recipient
+    BEFORE INSERT OR UPDATE ON user_decisions
+    FOR EACH ROW EXECUTE FUNCTION sync_liked_recipient();
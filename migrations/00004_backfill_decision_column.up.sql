@@ -0,0 +1,39 @@
+-- Fills decision in primary key order, committing after every batch so each
+-- row is locked only briefly and decisions keep being written meanwhile.
+-- COMMIT is only allowed outside a transaction block, so this migration must
+-- stay a single statement.
+DO $$
+DECLARE
+    from_actor     VARCHAR(36) := '';
+    from_recipient VARCHAR(36) := '';
+    to_actor       VARCHAR(36);
+    to_recipient   VARCHAR(36);
+BEGIN
+    LOOP
+        SELECT actor_user_id, recipient_user_id
+        INTO to_actor, to_recipient
+        FROM (
+                 SELECT actor_user_id, recipient_user_id
+                 FROM user_decisions
+                 WHERE (actor_user_id, recipient_user_id) > (from_actor, from_recipient)
+                 ORDER BY actor_user_id, recipient_user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY actor_user_id DESC, recipient_user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        UPDATE user_decisions
+        SET decision = CASE WHEN liked_recipient THEN 1 ELSE 0 END
+        WHERE (actor_user_id, recipient_user_id) > (from_actor, from_recipient)
+          AND (actor_user_id, recipient_user_id) <= (to_actor, to_recipient)
+          AND decision IS NULL;
+
+        COMMIT;
+
+        from_actor := to_actor;
+        from_recipient := to_recipient;
+    END LOOP;
+END;
+$$;
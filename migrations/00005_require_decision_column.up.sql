@@ -0,0 +1,5 @@
+-- Validating scans the table without blocking writes, and SET NOT NULL then
+-- relies on the validated constraint instead of scanning it again.
+ALTER TABLE user_decisions VALIDATE CONSTRAINT chk_user_decisions_decision;
+
+ALTER TABLE user_decisions ALTER COLUMN decision SET NOT NULL;
@@ -0,0 +1,12 @@
+-- Likers are paged by (decision_timestamp, actor_user_id), with the actor
+-- compared bytewise, so likes given in the same second are never skipped at a
+-- page boundary. The actor closes the listing indexes to keep those pages
+-- index-ordered. The liker filters probe (actor_user_id, recipient_user_id),
+-- which the primary key already indexes.
+--
+-- Indexes on user_decisions are built concurrently so decisions keep being
+-- written, which can't happen in a transaction block: each of these
+-- migrations is a single statement.
+CREATE INDEX CONCURRENTLY idx_liker_pages
+    ON user_decisions (recipient_user_id, decision_timestamp, actor_user_id COLLATE "C")
+    WHERE liked_recipient = true;
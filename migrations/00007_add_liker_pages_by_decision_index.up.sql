@@ -0,0 +1,4 @@
+-- Super-likes first listings.
+CREATE INDEX CONCURRENTLY idx_liker_pages_by_decision
+    ON user_decisions (recipient_user_id, decision, decision_timestamp, actor_user_id COLLATE "C")
+    WHERE liked_recipient = true;
@@ -0,0 +1,2 @@
+-- Superseded by idx_liker_pages.
+DROP INDEX CONCURRENTLY idx_liked_recipients;
@@ -0,0 +1,4 @@
+-- Counts an actor's super-likes of the last day for the quota.
+CREATE INDEX CONCURRENTLY idx_actor_super_likes
+    ON user_decisions (actor_user_id, decision_timestamp)
+    WHERE decision = 2;
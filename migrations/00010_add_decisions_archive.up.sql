@@ -0,0 +1,10 @@
+CREATE TABLE user_decisions_archive (
+                                        actor_user_id VARCHAR(36) NOT NULL,
+                                        recipient_user_id VARCHAR(36) NOT NULL,
+                                        decision SMALLINT NOT NULL,
+                                        decision_timestamp BIGINT NOT NULL,
+                                        archived_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_decisions_archive_actor ON user_decisions_archive (actor_user_id);
+CREATE INDEX idx_decisions_archive_recipient ON user_decisions_archive (recipient_user_id);
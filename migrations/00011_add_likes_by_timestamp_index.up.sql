@@ -0,0 +1,4 @@
+-- Finds expired likes for the sweeper.
+CREATE INDEX CONCURRENTLY idx_likes_by_timestamp
+    ON user_decisions (decision_timestamp)
+    WHERE liked_recipient = true;
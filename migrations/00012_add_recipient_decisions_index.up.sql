@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY idx_decisions_recipient
+    ON user_decisions (recipient_user_id);
@@ -0,0 +1,23 @@
+CREATE TABLE users (
+                       user_id VARCHAR(36) PRIMARY KEY,
+                       status VARCHAR(16) NOT NULL DEFAULT 'active'
+                           CHECK (status IN ('active', 'paused', 'banned', 'deleted')),
+                       display_name VARCHAR(255) NOT NULL DEFAULT '',
+                       created_at BIGINT NOT NULL,
+                       updated_at BIGINT NOT NULL
+);
+
+-- Listings only need to know who is hidden, which is a small minority.
+CREATE INDEX idx_users_not_active
+    ON users (user_id)
+    WHERE status <> 'active';
+
+-- Everyone who already made or received a decision is an active user.
+INSERT INTO users (user_id, created_at, updated_at)
+SELECT user_id, MIN(decision_timestamp), MIN(decision_timestamp)
+FROM (
+         SELECT actor_user_id AS user_id, decision_timestamp FROM user_decisions
+         UNION ALL
+         SELECT recipient_user_id, decision_timestamp FROM user_decisions
+     ) AS known_users
+GROUP BY user_id;
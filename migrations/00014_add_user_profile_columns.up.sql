@@ -0,0 +1,5 @@
+-- Profile summaries shown next to likes. The name is the existing
+-- display_name.
+ALTER TABLE users
+    ADD COLUMN photo_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN birth_date DATE;
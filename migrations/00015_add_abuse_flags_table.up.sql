@@ -0,0 +1,11 @@
+-- Shadow-banned actors. Their decisions stay in user_decisions but are left
+-- out of likers listings and counts while a row exists here.
+CREATE TABLE abuse_flags (
+                             user_id VARCHAR(36) PRIMARY KEY,
+                             reason VARCHAR(32) NOT NULL,
+                             details TEXT NOT NULL DEFAULT '',
+                             flagged_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_abuse_flags_flagged_at
+    ON abuse_flags (flagged_at DESC);
@@ -0,0 +1,236 @@
+-- User IDs are parsed into their canonical lowercase form before they reach
+-- the repositories, so rows stored with uppercase IDs could no longer be
+-- found. Where an uppercase and a lowercase row collide, the newer one wins.
+--
+-- Every table is walked in primary key order (the archive by actor), committing
+-- after every batch like the decision backfill, so each row is locked only
+-- briefly and decisions keep being written meanwhile. Only rows with an
+-- uppercase ID are rewritten, each checked against its lowercase twin through
+-- the primary key. COMMIT is only allowed outside a transaction block, so this
+-- migration must stay a single statement.
+DO $$
+DECLARE
+    from_actor     VARCHAR(36) := '';
+    from_recipient VARCHAR(36) := '';
+    to_actor       VARCHAR(36);
+    to_recipient   VARCHAR(36);
+    from_id        VARCHAR(36);
+    to_id          VARCHAR(36);
+    mixed          RECORD;
+    twin_newer     BOOLEAN;
+BEGIN
+    LOOP
+        SELECT actor_user_id, recipient_user_id
+        INTO to_actor, to_recipient
+        FROM (
+                 SELECT actor_user_id, recipient_user_id
+                 FROM user_decisions
+                 WHERE (actor_user_id, recipient_user_id) > (from_actor, from_recipient)
+                 ORDER BY actor_user_id, recipient_user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY actor_user_id DESC, recipient_user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        FOR mixed IN
+            SELECT actor_user_id, recipient_user_id, decision_timestamp
+            FROM user_decisions
+            WHERE (actor_user_id, recipient_user_id) > (from_actor, from_recipient)
+              AND (actor_user_id, recipient_user_id) <= (to_actor, to_recipient)
+              AND (actor_user_id <> lower(actor_user_id) OR recipient_user_id <> lower(recipient_user_id))
+            ORDER BY actor_user_id, recipient_user_id
+        LOOP
+            SELECT decision_timestamp >= mixed.decision_timestamp
+            INTO twin_newer
+            FROM user_decisions
+            WHERE actor_user_id = lower(mixed.actor_user_id)
+              AND recipient_user_id = lower(mixed.recipient_user_id);
+
+            IF twin_newer THEN
+                DELETE FROM user_decisions
+                WHERE actor_user_id = mixed.actor_user_id
+                  AND recipient_user_id = mixed.recipient_user_id;
+                CONTINUE;
+            END IF;
+
+            DELETE FROM user_decisions
+            WHERE actor_user_id = lower(mixed.actor_user_id)
+              AND recipient_user_id = lower(mixed.recipient_user_id);
+
+            UPDATE user_decisions
+            SET actor_user_id = lower(actor_user_id),
+                recipient_user_id = lower(recipient_user_id)
+            WHERE actor_user_id = mixed.actor_user_id
+              AND recipient_user_id = mixed.recipient_user_id;
+        END LOOP;
+
+        COMMIT;
+
+        from_actor := to_actor;
+        from_recipient := to_recipient;
+    END LOOP;
+
+    -- Archived decisions may repeat, so they are only lowercased.
+    from_id := '';
+    LOOP
+        SELECT actor_user_id
+        INTO to_id
+        FROM (
+                 SELECT actor_user_id
+                 FROM user_decisions_archive
+                 WHERE actor_user_id > from_id
+                 ORDER BY actor_user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY actor_user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        UPDATE user_decisions_archive
+        SET actor_user_id = lower(actor_user_id),
+            recipient_user_id = lower(recipient_user_id)
+        WHERE actor_user_id > from_id
+          AND actor_user_id <= to_id
+          AND (actor_user_id <> lower(actor_user_id) OR recipient_user_id <> lower(recipient_user_id));
+
+        COMMIT;
+
+        from_id := to_id;
+    END LOOP;
+
+    -- The higher watermark wins.
+    from_id := '';
+    LOOP
+        SELECT recipient_user_id
+        INTO to_id
+        FROM (
+                 SELECT recipient_user_id
+                 FROM liker_seen_watermarks
+                 WHERE recipient_user_id > from_id
+                 ORDER BY recipient_user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY recipient_user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        FOR mixed IN
+            SELECT recipient_user_id, seen_up_to
+            FROM liker_seen_watermarks
+            WHERE recipient_user_id > from_id
+              AND recipient_user_id <= to_id
+              AND recipient_user_id <> lower(recipient_user_id)
+            ORDER BY recipient_user_id
+        LOOP
+            SELECT seen_up_to >= mixed.seen_up_to
+            INTO twin_newer
+            FROM liker_seen_watermarks
+            WHERE recipient_user_id = lower(mixed.recipient_user_id);
+
+            IF twin_newer THEN
+                DELETE FROM liker_seen_watermarks WHERE recipient_user_id = mixed.recipient_user_id;
+                CONTINUE;
+            END IF;
+
+            DELETE FROM liker_seen_watermarks WHERE recipient_user_id = lower(mixed.recipient_user_id);
+            UPDATE liker_seen_watermarks
+            SET recipient_user_id = lower(recipient_user_id)
+            WHERE recipient_user_id = mixed.recipient_user_id;
+        END LOOP;
+
+        COMMIT;
+
+        from_id := to_id;
+    END LOOP;
+
+    from_id := '';
+    LOOP
+        SELECT user_id
+        INTO to_id
+        FROM (
+                 SELECT user_id
+                 FROM users
+                 WHERE user_id > from_id
+                 ORDER BY user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        FOR mixed IN
+            SELECT user_id, updated_at
+            FROM users
+            WHERE user_id > from_id
+              AND user_id <= to_id
+              AND user_id <> lower(user_id)
+            ORDER BY user_id
+        LOOP
+            SELECT updated_at >= mixed.updated_at
+            INTO twin_newer
+            FROM users
+            WHERE user_id = lower(mixed.user_id);
+
+            IF twin_newer THEN
+                DELETE FROM users WHERE user_id = mixed.user_id;
+                CONTINUE;
+            END IF;
+
+            DELETE FROM users WHERE user_id = lower(mixed.user_id);
+            UPDATE users SET user_id = lower(user_id) WHERE user_id = mixed.user_id;
+        END LOOP;
+
+        COMMIT;
+
+        from_id := to_id;
+    END LOOP;
+
+    from_id := '';
+    LOOP
+        SELECT user_id
+        INTO to_id
+        FROM (
+                 SELECT user_id
+                 FROM abuse_flags
+                 WHERE user_id > from_id
+                 ORDER BY user_id
+                 LIMIT 5000
+             ) AS batch
+        ORDER BY user_id DESC
+        LIMIT 1;
+
+        EXIT WHEN NOT FOUND;
+
+        FOR mixed IN
+            SELECT user_id, flagged_at
+            FROM abuse_flags
+            WHERE user_id > from_id
+              AND user_id <= to_id
+              AND user_id <> lower(user_id)
+            ORDER BY user_id
+        LOOP
+            SELECT flagged_at >= mixed.flagged_at
+            INTO twin_newer
+            FROM abuse_flags
+            WHERE user_id = lower(mixed.user_id);
+
+            IF twin_newer THEN
+                DELETE FROM abuse_flags WHERE user_id = mixed.user_id;
+                CONTINUE;
+            END IF;
+
+            DELETE FROM abuse_flags WHERE user_id = lower(mixed.user_id);
+            UPDATE abuse_flags SET user_id = lower(user_id) WHERE user_id = mixed.user_id;
+        END LOOP;
+
+        COMMIT;
+
+        from_id := to_id;
+    END LOOP;
+END;
+$$;
@@ -0,0 +1,9 @@
+-- A watermark of a timestamp alone hid likes given later in the same second
+-- as the newest seen one. Watermarks now also hold the actor of that like,
+-- the tie-breaker likers are listed by. An empty actor sorts before every ID,
+-- so existing watermarks move one second on to cover the seconds they did.
+ALTER TABLE liker_seen_watermarks
+    ADD COLUMN seen_actor_user_id VARCHAR(36) NOT NULL DEFAULT '';
+
+UPDATE liker_seen_watermarks
+SET seen_up_to = seen_up_to + 1;
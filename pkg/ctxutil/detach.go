@@ -0,0 +1,15 @@
+package ctxutil
+
+import "context"
+
+// Detach returns a context that keeps ctx's values and deadline but not its
+// cancellation, for work shared by several callers: one caller going away
+// must not fail the others waiting on the result.
+func Detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+
+	return context.WithCancel(detached)
+}
@@ -0,0 +1,37 @@
+package ctxutil
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func TestDetach(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	parent, cancelParent := context.WithDeadline(context.WithValue(context.Background(), ctxKey{}, "value"), deadline)
+
+	ctx, cancel := Detach(parent)
+	defer cancel()
+
+	cancelParent()
+	assert.NoError(t, ctx.Err())
+	assert.Equal(t, "value", ctx.Value(ctxKey{}))
+
+	got, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, deadline, got)
+
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestDetach_NoDeadline(t *testing.T) {
+	ctx, cancel := Detach(context.Background())
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
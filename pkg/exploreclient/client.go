@@ -0,0 +1,209 @@
+// Package exploreclient is a Go client for the explore service. It wraps the
+// generated ExploreServiceClient with retries on Unavailable, hedged reads
+// and iterators that follow pagination tokens.
+package exploreclient
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"iter"
+	pb "muzz-homework/pkg/proto"
+)
+
+type Client struct {
+	rpc     pb.ExploreServiceClient
+	options options
+	// conn is only set when the client dialed it and so must close it.
+	conn *grpc.ClientConn
+}
+
+// New wraps an existing connection, which the caller keeps owning.
+func New(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	return &Client{
+		rpc:     pb.NewExploreServiceClient(conn),
+		options: newOptions(opts),
+	}
+}
+
+// Dial connects to the service at target. Close releases the connection.
+func Dial(target string, opts ...Option) (*Client, error) {
+	o := newOptions(opts)
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, o.dialOptions...)
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("creating grpc client: %w", err)
+	}
+
+	return &Client{
+		rpc:     pb.NewExploreServiceClient(conn),
+		options: o,
+		conn:    conn,
+	}, nil
+}
+
+func newOptions(opts []Option) options {
+	o := options{retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Close closes the connection opened by Dial; it does nothing for clients
+// made with New.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// RPC returns the generated client, for calls this package doesn't wrap such
+// as the admin RPCs. Calls made through it are neither retried nor hedged.
+func (c *Client) RPC() pb.ExploreServiceClient {
+	return c.rpc
+}
+
+// ListLikedYou returns one page of likers and the token of the next page,
+// empty on the last page.
+func (c *Client) ListLikedYou(ctx context.Context, recipientID string, paginationToken string, opts ListOptions) ([]*pb.ListLikedYouResponse_Liker, string, error) {
+	return c.listPage(ctx, c.rpc.ListLikedYou, newListRequest(recipientID, paginationToken, opts))
+}
+
+// ListNewLikedYou is ListLikedYou for likers the recipient hasn't decided on.
+func (c *Client) ListNewLikedYou(ctx context.Context, recipientID string, paginationToken string, opts ListOptions) ([]*pb.ListLikedYouResponse_Liker, string, error) {
+	return c.listPage(ctx, c.rpc.ListNewLikedYou, newListRequest(recipientID, paginationToken, opts))
+}
+
+// AllLikers iterates over every liker of the recipient, fetching pages as it
+// goes. Iteration stops after the first error, which is yielded with a nil
+// liker.
+func (c *Client) AllLikers(ctx context.Context, recipientID string, opts ListOptions) iter.Seq2[*pb.ListLikedYouResponse_Liker, error] {
+	return c.allPages(ctx, c.rpc.ListLikedYou, recipientID, opts)
+}
+
+// AllNewLikers is AllLikers for likers the recipient hasn't decided on.
+func (c *Client) AllNewLikers(ctx context.Context, recipientID string, opts ListOptions) iter.Seq2[*pb.ListLikedYouResponse_Liker, error] {
+	return c.allPages(ctx, c.rpc.ListNewLikedYou, recipientID, opts)
+}
+
+type listFunc func(ctx context.Context, req *pb.ListLikedYouRequest, opts ...grpc.CallOption) (*pb.ListLikedYouResponse, error)
+
+func (c *Client) allPages(ctx context.Context, list listFunc, recipientID string, opts ListOptions) iter.Seq2[*pb.ListLikedYouResponse_Liker, error] {
+	return func(yield func(*pb.ListLikedYouResponse_Liker, error) bool) {
+		var token string
+		for {
+			likers, next, err := c.listPage(ctx, list, newListRequest(recipientID, token, opts))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, liker := range likers {
+				if !yield(liker, nil) {
+					return
+				}
+			}
+
+			if next == "" {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+func (c *Client) listPage(ctx context.Context, list listFunc, req *pb.ListLikedYouRequest) ([]*pb.ListLikedYouResponse_Liker, string, error) {
+	page, err := read(ctx, c.options, func(ctx context.Context) (*pb.ListLikedYouResponse, error) {
+		return list(ctx, req)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return page.Likers, page.GetNextPaginationToken(), nil
+}
+
+func newListRequest(recipientID string, paginationToken string, opts ListOptions) *pb.ListLikedYouRequest {
+	req := &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		UnseenOnly:      opts.UnseenOnly,
+		Filter:          opts.Filter,
+		SuperLikesFirst: opts.SuperLikesFirst,
+		IncludeExpired:  opts.IncludeExpired,
+	}
+	if paginationToken != "" {
+		req.PaginationToken = &paginationToken
+	}
+	if len(opts.ProfileFields) > 0 {
+		req.IncludeProfile = &fieldmaskpb.FieldMask{Paths: opts.ProfileFields}
+	}
+	return req
+}
+
+func (c *Client) CountLikedYou(ctx context.Context, recipientID string, opts CountOptions) (*pb.CountLikedYouResponse, error) {
+	return read(ctx, c.options, func(ctx context.Context) (*pb.CountLikedYouResponse, error) {
+		return c.rpc.CountLikedYou(ctx, &pb.CountLikedYouRequest{
+			RecipientUserId: recipientID,
+			IncludeExpired:  opts.IncludeExpired,
+		})
+	})
+}
+
+func (c *Client) GetCandidates(ctx context.Context, actorID string, pageSize uint64) ([]string, error) {
+	resp, err := read(ctx, c.options, func(ctx context.Context) (*pb.GetCandidatesResponse, error) {
+		return c.rpc.GetCandidates(ctx, &pb.GetCandidatesRequest{
+			ActorUserId: actorID,
+			PageSize:    pageSize,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.UserIds, nil
+}
+
+// PutDecision records the actor's decision and reports whether it completes
+// a mutual like. It is retried like a read: the server upserts the decision,
+// a repeated super-like doesn't count against the quota again, and the
+// mutual flag is worked out from both users' latest decisions, so a retry
+// after a lost response answers the same as the lost one.
+func (c *Client) PutDecision(ctx context.Context, actorID string, recipientID string, decision pb.Decision) (bool, error) {
+	resp, err := retry(ctx, c.options.retry, func(ctx context.Context) (*pb.PutDecisionResponse, error) {
+		return c.rpc.PutDecision(ctx, &pb.PutDecisionRequest{
+			ActorUserId:     actorID,
+			RecipientUserId: recipientID,
+			Decision:        decision,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.MutualLikes, nil
+}
+
+// MarkLikesSeen moves the recipient's seen watermark forward to the newest
+// liker they have seen and returns the stored watermark. The watermark never
+// moves back, so retries are safe.
+func (c *Client) MarkLikesSeen(ctx context.Context, recipientID string, upTo *pb.ListLikedYouResponse_Liker) (*pb.MarkLikesSeenResponse, error) {
+	return retry(ctx, c.options.retry, func(ctx context.Context) (*pb.MarkLikesSeenResponse, error) {
+		return c.rpc.MarkLikesSeen(ctx, &pb.MarkLikesSeenRequest{
+			RecipientUserId: recipientID,
+			UpToCursor:      upTo.GetUnixTimestamp(),
+			UpToActorId:     upTo.GetActorId(),
+		})
+	})
+}
+
+// read retries and hedges a read. Each attempt is hedged on its own.
+func read[T any](ctx context.Context, o options, call func(ctx context.Context) (T, error)) (T, error) {
+	return retry(ctx, o.retry, func(ctx context.Context) (T, error) {
+		return hedge(ctx, o.hedgeDelay, call)
+	})
+}
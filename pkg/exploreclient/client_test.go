@@ -0,0 +1,217 @@
+package exploreclient_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"muzz-homework/pkg/exploreclient"
+	"muzz-homework/pkg/exploreclient/exploretest"
+	pb "muzz-homework/pkg/proto"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const recipient = "6f1c2a8e-3b4d-4c5e-8f90-000000000000"
+
+func userID(i int) string {
+	return fmt.Sprintf("6f1c2a8e-3b4d-4c5e-8f90-%012d", i+1)
+}
+
+// noBackoff retries immediately so tests don't sleep.
+var noBackoff = exploreclient.WithRetryPolicy(exploreclient.RetryPolicy{MaxAttempts: 3})
+
+func TestClient_AllLikers(t *testing.T) {
+	ctx := context.Background()
+	server := exploretest.NewServer(t)
+	client := server.Client()
+
+	server.AddUser(t, recipient)
+	// Every like shares one second, so pages break between them.
+	now := uint64(time.Now().Unix())
+	const likers = 45
+	for i := range likers {
+		server.AddUser(t, userID(i))
+		server.AddDecision(t, userID(i), recipient, pb.Decision_DECISION_LIKE, now)
+	}
+
+	seen := make(map[string]bool)
+	for liker, err := range client.AllLikers(ctx, recipient, exploreclient.ListOptions{}) {
+		require.NoError(t, err)
+		assert.False(t, seen[liker.ActorId], "%s listed twice", liker.ActorId)
+		seen[liker.ActorId] = true
+	}
+	assert.Len(t, seen, likers)
+
+	// Breaking out stops the iteration.
+	taken := 0
+	for _, err := range client.AllLikers(ctx, recipient, exploreclient.ListOptions{}) {
+		require.NoError(t, err)
+		taken++
+		if taken == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, taken)
+
+	// Filters are applied to every page.
+	_, err := client.PutDecision(ctx, recipient, userID(0), pb.Decision_DECISION_PASS)
+	require.NoError(t, err)
+
+	pending := 0
+	for _, err := range client.AllNewLikers(ctx, recipient, exploreclient.ListOptions{}) {
+		require.NoError(t, err)
+		pending++
+	}
+	assert.Equal(t, likers-1, pending)
+}
+
+func TestClient_AllLikers_Error(t *testing.T) {
+	client := exploretest.NewServer(t).Client(noBackoff)
+
+	var errs []error
+	for liker, err := range client.AllLikers(context.Background(), "not-a-uuid", exploreclient.ListOptions{}) {
+		assert.Nil(t, liker)
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[0]))
+}
+
+func TestClient_PutDecision(t *testing.T) {
+	ctx := context.Background()
+	server := exploretest.NewServer(t)
+	client := server.Client()
+
+	alice, bob := userID(0), userID(1)
+	server.AddUser(t, alice)
+	server.AddUser(t, bob)
+
+	mutual, err := client.PutDecision(ctx, alice, bob, pb.Decision_DECISION_LIKE)
+	require.NoError(t, err)
+	assert.False(t, mutual)
+
+	mutual, err = client.PutDecision(ctx, bob, alice, pb.Decision_DECISION_SUPER_LIKE)
+	require.NoError(t, err)
+	assert.True(t, mutual)
+
+	// Repeating a decision, as a retry after a lost response does, answers
+	// the same.
+	mutual, err = client.PutDecision(ctx, bob, alice, pb.Decision_DECISION_SUPER_LIKE)
+	require.NoError(t, err)
+	assert.True(t, mutual)
+
+	server.SetUserStatus(t, userID(2), "deleted")
+	_, err = client.PutDecision(ctx, alice, userID(2), pb.Decision_DECISION_LIKE)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// A user who was never provisioned doesn't exist either.
+	_, err = client.PutDecision(ctx, alice, userID(3), pb.Decision_DECISION_LIKE)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+type flakyService struct {
+	pb.UnimplementedExploreServiceServer
+	calls    atomic.Int32
+	failures int32
+	code     codes.Code
+}
+
+func (s *flakyService) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "flaky")
+	}
+	return &pb.PutDecisionResponse{MutualLikes: true}, nil
+}
+
+func (s *flakyService) CountLikedYou(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "flaky")
+	}
+	return &pb.CountLikedYouResponse{Count: 7}, nil
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int32
+		code      codes.Code
+		wantCalls int32
+		wantCode  codes.Code
+	}{
+		{name: "succeeds first time", failures: 0, code: codes.Unavailable, wantCalls: 1, wantCode: codes.OK},
+		{name: "recovers from unavailable", failures: 2, code: codes.Unavailable, wantCalls: 3, wantCode: codes.OK},
+		{name: "gives up after max attempts", failures: 5, code: codes.Unavailable, wantCalls: 3, wantCode: codes.Unavailable},
+		{name: "other codes are not retried", failures: 1, code: codes.Internal, wantCalls: 1, wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" - put decision", func(t *testing.T) {
+			service := &flakyService{failures: tt.failures, code: tt.code}
+			client := exploreclient.New(exploretest.NewConn(t, service), noBackoff)
+
+			mutual, err := client.PutDecision(context.Background(), userID(0), userID(1), pb.Decision_DECISION_LIKE)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, err == nil, mutual)
+			assert.Equal(t, tt.wantCalls, service.calls.Load())
+		})
+
+		t.Run(tt.name+" - count", func(t *testing.T) {
+			service := &flakyService{failures: tt.failures, code: tt.code}
+			client := exploreclient.New(exploretest.NewConn(t, service), noBackoff)
+
+			_, err := client.CountLikedYou(context.Background(), recipient, exploreclient.CountOptions{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCalls, service.calls.Load())
+		})
+	}
+}
+
+// slowFirstService stalls the first call until it is cancelled and answers
+// later ones straight away.
+type slowFirstService struct {
+	pb.UnimplementedExploreServiceServer
+	calls     atomic.Int32
+	cancelled atomic.Bool
+}
+
+func (s *slowFirstService) ListLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+	if s.calls.Add(1) == 1 {
+		<-ctx.Done()
+		s.cancelled.Store(true)
+		return nil, ctx.Err()
+	}
+	return &pb.ListLikedYouResponse{Likers: []*pb.ListLikedYouResponse_Liker{{ActorId: userID(0)}}}, nil
+}
+
+func TestClient_Hedging(t *testing.T) {
+	t.Run("hedged read wins", func(t *testing.T) {
+		service := &slowFirstService{}
+		client := exploreclient.New(exploretest.NewConn(t, service), exploreclient.WithHedging(10*time.Millisecond))
+
+		likers, next, err := client.ListLikedYou(context.Background(), recipient, "", exploreclient.ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, likers, 1)
+		assert.Empty(t, next)
+		assert.Equal(t, int32(2), service.calls.Load())
+
+		// The stalled call is cancelled once the hedge answered.
+		assert.Eventually(t, service.cancelled.Load, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("without hedging", func(t *testing.T) {
+		service := &slowFirstService{}
+		client := exploreclient.New(exploretest.NewConn(t, service))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, _, err := client.ListLikedYou(ctx, recipient, "", exploreclient.ListOptions{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, int32(1), service.calls.Load())
+	})
+}
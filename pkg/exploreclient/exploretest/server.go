@@ -0,0 +1,166 @@
+// Package exploretest runs the explore service in process for tests of code
+// built on exploreclient, without Postgres, Redis or a network port.
+package exploretest
+
+import (
+	"context"
+	"crypto/rand"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	grpcAdapter "muzz-homework/internal/explore/adapters/grpc"
+	"muzz-homework/internal/explore/application"
+	"muzz-homework/internal/explore/domain"
+	infraMemory "muzz-homework/internal/explore/infrastructure/memory"
+	"muzz-homework/pkg/exploreclient"
+	pb "muzz-homework/pkg/proto"
+	"net"
+	"testing"
+	"time"
+)
+
+const (
+	superLikeDailyLimit = 5
+	eraseBatchSize      = 1000
+)
+
+// Server is the explore service on in-memory storage, reachable over an
+// in-process connection. Decisions are only accepted between users added
+// with AddUser.
+type Server struct {
+	// Conn is closed when the test finishes.
+	Conn  *grpc.ClientConn
+	db    *infraMemory.Database
+	users *infraMemory.UserRepository
+}
+
+// NewServer starts the service and stops it when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	secret := make([]byte, 32)
+	rand.Read(secret)
+
+	tokens, err := domain.NewTokenCodec([]domain.SigningKey{{ID: "exploretest", Secret: secret}}, time.Hour)
+	if err != nil {
+		t.Fatalf("creating token codec: %v", err)
+	}
+
+	db := infraMemory.NewDatabase()
+	users := infraMemory.NewUserRepository(db)
+	decisions := infraMemory.NewDecisionRepository(db, infraMemory.DecisionRepositoryConfig{})
+	cache := infraMemory.NewCache(infraMemory.CacheConfig{TTL: time.Minute})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Abuse detection is off, tests seed decisions far faster than people
+	// make them, but flags can still be reviewed and cleared.
+	counters := infraMemory.NewAbuseCounters(infraMemory.AbuseCountersConfig{Window: time.Hour})
+	abuse := application.NewAbuseDetector(counters, infraMemory.NewAbuseFlagRepository(db), decisions, cache, application.AbuseDetectorConfig{}, logger)
+
+	server, err := grpcAdapter.NewGRPCServer("0", grpcAdapter.ServerConfig{},
+		application.NewDecisionProvider(decisions, cache, users, tokens),
+		application.NewDecisionCreator(decisions, users, nil, abuse, application.DecisionCreatorConfig{SuperLikeDailyLimit: superLikeDailyLimit}, logger),
+		application.NewCandidateProvider(infraMemory.NewCandidateSource(db)),
+		application.NewUserDataManager(decisions, cache, counters, logger, eraseBatchSize),
+		abuse,
+		logger)
+	if err != nil {
+		t.Fatalf("creating server: %v", err)
+	}
+
+	return &Server{
+		Conn:  serve(t, server.Serve, server.Stop),
+		db:    db,
+		users: users,
+	}
+}
+
+// AddUser provisions an active user. IDs must be UUIDs.
+func (s *Server) AddUser(t testing.TB, userID string) {
+	t.Helper()
+
+	id, err := domain.ParseUserID(userID)
+	if err != nil {
+		t.Fatalf("adding user: %v", err)
+	}
+
+	if err := s.users.UpsertUser(context.Background(), domain.User{ID: id, Status: domain.UserStatusActive}); err != nil {
+		t.Fatalf("adding user: %v", err)
+	}
+}
+
+// SetUserStatus pauses, bans, deletes or reinstates a user. IDs must be UUIDs
+// and status one of "active", "paused", "banned" or "deleted".
+func (s *Server) SetUserStatus(t testing.TB, userID string, status string) {
+	t.Helper()
+
+	id, err := domain.ParseUserID(userID)
+	if err != nil {
+		t.Fatalf("setting user status: %v", err)
+	}
+
+	if err := s.users.SetUserStatus(context.Background(), id, domain.UserStatus(status)); err != nil {
+		t.Fatalf("setting user status: %v", err)
+	}
+}
+
+// AddDecision stores a decision with the given Unix timestamp, bypassing the
+// user checks of PutDecision. Likes may share a timestamp; pages list them by
+// actor ID.
+func (s *Server) AddDecision(t testing.TB, actorID string, recipientID string, decision pb.Decision, timestamp uint64) {
+	t.Helper()
+
+	var d domain.Decision
+	switch decision {
+	case pb.Decision_DECISION_PASS:
+		d = domain.DecisionPass
+	case pb.Decision_DECISION_LIKE:
+		d = domain.DecisionLike
+	case pb.Decision_DECISION_SUPER_LIKE:
+		d = domain.DecisionSuperLike
+	default:
+		t.Fatalf("adding decision: unknown decision %v", decision)
+	}
+
+	s.db.RecordDecision(domain.UserID(actorID), domain.UserID(recipientID), d, timestamp)
+}
+
+// Client returns a client of the server.
+func (s *Server) Client(opts ...exploreclient.Option) *exploreclient.Client {
+	return exploreclient.New(s.Conn, opts...)
+}
+
+// NewConn serves service, typically a fake embedding
+// pb.UnimplementedExploreServiceServer, and returns a connection to it. Both
+// are closed when the test finishes.
+func NewConn(t testing.TB, service pb.ExploreServiceServer) *grpc.ClientConn {
+	t.Helper()
+
+	server := grpc.NewServer()
+	pb.RegisterExploreServiceServer(server, service)
+
+	return serve(t, server.Serve, server.Stop)
+}
+
+func serve(t testing.TB, serve func(net.Listener) error, stop func()) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go serve(lis)
+	t.Cleanup(stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dialing server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
@@ -0,0 +1,82 @@
+package exploreclient
+
+import (
+	"google.golang.org/grpc"
+	pb "muzz-homework/pkg/proto"
+	"time"
+)
+
+// RetryPolicy controls how calls failing with Unavailable are retried. The
+// wait before retry n is InitialBackoff*Multiplier^(n-1), capped at
+// MaxBackoff, with up to half of it randomized so clients don't retry in
+// lockstep.
+type RetryPolicy struct {
+	// MaxAttempts counts the first call too; 1 disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy rides out a server restart or a lost connection without
+// holding a caller for more than about a second.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+}
+
+type options struct {
+	retry       RetryPolicy
+	hedgeDelay  time.Duration
+	dialOptions []grpc.DialOption
+}
+
+type Option func(*options)
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithHedging sends a second copy of a read that hasn't answered after delay
+// and keeps whichever answers first. It trades extra load for a shorter tail
+// latency, so delay is best set around the read's p95. Writes are never
+// hedged. 0, the default, disables hedging.
+func WithHedging(delay time.Duration) Option {
+	return func(o *options) {
+		o.hedgeDelay = delay
+	}
+}
+
+// WithDialOptions is passed on to grpc.NewClient by Dial. Without transport
+// credentials among them the connection is insecure.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// ListOptions narrows a likers listing; the zero value lists every liker,
+// newest first.
+type ListOptions struct {
+	// Filter defaults to LIKER_FILTER_ALL for likers and LIKER_FILTER_PENDING
+	// for new likers.
+	Filter          pb.LikerFilter
+	UnseenOnly      bool
+	SuperLikesFirst bool
+	// IncludeExpired needs an admin bearer token in the call's
+	// "authorization" metadata.
+	IncludeExpired bool
+	// ProfileFields attaches the likers' profiles with these fields: name,
+	// photo_url, age.
+	ProfileFields []string
+}
+
+type CountOptions struct {
+	// IncludeExpired needs an admin bearer token, as for ListOptions.
+	IncludeExpired bool
+}
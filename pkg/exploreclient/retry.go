@@ -0,0 +1,88 @@
+package exploreclient
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math/rand/v2"
+	"time"
+)
+
+// retry calls call until it succeeds, fails with anything but Unavailable,
+// the policy runs out of attempts or ctx is done. The last error is returned.
+func retry[T any](ctx context.Context, policy RetryPolicy, call func(ctx context.Context) (T, error)) (T, error) {
+	backoff := policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, err := call(ctx)
+		if err == nil || status.Code(err) != codes.Unavailable || attempt >= policy.MaxAttempts {
+			return result, err
+		}
+
+		timer := time.NewTimer(jitter(backoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		backoff = min(time.Duration(float64(backoff)*policy.Multiplier), policy.MaxBackoff)
+	}
+}
+
+// jitter keeps at least half of the backoff so retries still spread out
+// when many clients fail at once.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// hedge calls call, and calls it a second time if the first hasn't returned
+// after delay. The first success wins and the other call is cancelled. An
+// error only wins once both calls have failed, or when the first call fails
+// before the hedge is sent.
+func hedge[T any](ctx context.Context, delay time.Duration, call func(ctx context.Context) (T, error)) (T, error) {
+	if delay <= 0 {
+		return call(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+
+	results := make(chan result, 2)
+	send := func() {
+		value, err := call(ctx)
+		results <- result{value: value, err: err}
+	}
+
+	go send()
+	pending := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	hedged := timer.C
+
+	for {
+		select {
+		case <-hedged:
+			hedged = nil
+			pending++
+			go send()
+		case r := <-results:
+			pending--
+			if r.err == nil || pending == 0 {
+				return r.value, r.err
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package exploreclient
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	for _, backoff := range []time.Duration{0, 1, 10 * time.Millisecond, time.Second} {
+		for range 100 {
+			wait := jitter(backoff)
+			assert.GreaterOrEqual(t, wait, backoff/2)
+			assert.LessOrEqual(t, wait, backoff)
+		}
+	}
+}
+
+func TestRetry_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 30 * time.Millisecond, Multiplier: 2}
+
+	var calls []time.Time
+	start := time.Now()
+	_, err := retry(context.Background(), policy, func(ctx context.Context) (int, error) {
+		calls = append(calls, time.Now())
+		return 0, status.Error(codes.Unavailable, "down")
+	})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Len(t, calls, 4)
+	// At least 10ms, 15ms and 15ms: half of 20ms, then of the 30ms cap.
+	assert.GreaterOrEqual(t, calls[3].Sub(start), 40*time.Millisecond)
+}
+
+func TestRetry_StopsWhenContextDone(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour, MaxBackoff: time.Hour, Multiplier: 1}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	_, err := retry(ctx, policy, func(ctx context.Context) (int, error) {
+		calls++
+		return 0, status.Error(codes.Unavailable, "down")
+	})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestHedge(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		first   func(ctx context.Context) (string, error)
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "fast first call is not hedged",
+			first: func(ctx context.Context) (string, error) { return "first", nil },
+			want:  "first",
+		},
+		{
+			name:    "fast failure is returned without hedging",
+			first:   func(ctx context.Context) (string, error) { return "", errFailed },
+			wantErr: errFailed,
+		},
+		{
+			name: "slow first call loses to the hedge",
+			first: func(ctx context.Context) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			},
+			want: "hedge",
+		},
+		{
+			name: "slow failure waits for the hedge",
+			first: func(ctx context.Context) (string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return "", errFailed
+			},
+			want: "hedge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			got, err := hedge(context.Background(), 10*time.Millisecond, func(ctx context.Context) (string, error) {
+				if calls.Add(1) == 1 {
+					return tt.first(ctx)
+				}
+				time.Sleep(30 * time.Millisecond)
+				return "hedge", nil
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
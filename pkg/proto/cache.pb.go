@@ -0,0 +1,446 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: internal/explore/infrastructure/redis/cache.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CachedLikers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Likers      []*ListLikedYouResponse_Liker `protobuf:"bytes,1,rep,name=likers,proto3" json:"likers,omitempty"`
+	Cursor      *CachedCursor                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // Unset on the last page
+	ComputeUs   int64                         `protobuf:"varint,3,opt,name=compute_us,json=computeUs,proto3" json:"compute_us,omitempty"`
+	ExpiresAtMs int64                         `protobuf:"varint,4,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`
+}
+
+func (x *CachedLikers) Reset() {
+	*x = CachedLikers{}
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedLikers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedLikers) ProtoMessage() {}
+
+func (x *CachedLikers) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedLikers.ProtoReflect.Descriptor instead.
+func (*CachedLikers) Descriptor() ([]byte, []int) {
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CachedLikers) GetLikers() []*ListLikedYouResponse_Liker {
+	if x != nil {
+		return x.Likers
+	}
+	return nil
+}
+
+func (x *CachedLikers) GetCursor() *CachedCursor {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *CachedLikers) GetComputeUs() int64 {
+	if x != nil {
+		return x.ComputeUs
+	}
+	return 0
+}
+
+func (x *CachedLikers) GetExpiresAtMs() int64 {
+	if x != nil {
+		return x.ExpiresAtMs
+	}
+	return 0
+}
+
+type CachedCursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixTimestamp uint64   `protobuf:"varint,1,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	Decision      Decision `protobuf:"varint,2,opt,name=decision,proto3,enum=explore.Decision" json:"decision,omitempty"`
+	ActorId       string   `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+}
+
+func (x *CachedCursor) Reset() {
+	*x = CachedCursor{}
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedCursor) ProtoMessage() {}
+
+func (x *CachedCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedCursor.ProtoReflect.Descriptor instead.
+func (*CachedCursor) Descriptor() ([]byte, []int) {
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CachedCursor) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *CachedCursor) GetDecision() Decision {
+	if x != nil {
+		return x.Decision
+	}
+	return Decision_DECISION_UNSPECIFIED
+}
+
+func (x *CachedCursor) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+type CachedCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count       uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	ComputeUs   int64  `protobuf:"varint,2,opt,name=compute_us,json=computeUs,proto3" json:"compute_us,omitempty"`
+	ExpiresAtMs int64  `protobuf:"varint,3,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`
+}
+
+func (x *CachedCount) Reset() {
+	*x = CachedCount{}
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedCount) ProtoMessage() {}
+
+func (x *CachedCount) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedCount.ProtoReflect.Descriptor instead.
+func (*CachedCount) Descriptor() ([]byte, []int) {
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CachedCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CachedCount) GetComputeUs() int64 {
+	if x != nil {
+		return x.ComputeUs
+	}
+	return 0
+}
+
+func (x *CachedCount) GetExpiresAtMs() int64 {
+	if x != nil {
+		return x.ExpiresAtMs
+	}
+	return 0
+}
+
+type CachedProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Found     bool   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"` // False caches that the user has no profile
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhotoUrl  string `protobuf:"bytes,3,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	BirthDate string `protobuf:"bytes,4,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"` // YYYY-MM-DD, empty when unknown
+}
+
+func (x *CachedProfile) Reset() {
+	*x = CachedProfile{}
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedProfile) ProtoMessage() {}
+
+func (x *CachedProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedProfile.ProtoReflect.Descriptor instead.
+func (*CachedProfile) Descriptor() ([]byte, []int) {
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CachedProfile) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *CachedProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CachedProfile) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *CachedProfile) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+type CachedWatermark struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixTimestamp uint64 `protobuf:"varint,1,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	ActorId       string `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+}
+
+func (x *CachedWatermark) Reset() {
+	*x = CachedWatermark{}
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedWatermark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedWatermark) ProtoMessage() {}
+
+func (x *CachedWatermark) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_infrastructure_redis_cache_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedWatermark.ProtoReflect.Descriptor instead.
+func (*CachedWatermark) Descriptor() ([]byte, []int) {
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CachedWatermark) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *CachedWatermark) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+var File_internal_explore_infrastructure_redis_cache_proto protoreflect.FileDescriptor
+
+var file_internal_explore_infrastructure_redis_cache_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2f, 0x69, 0x6e, 0x66, 0x72, 0x61, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x2f, 0x72, 0x65, 0x64, 0x69, 0x73, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x1a, 0x2c, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2f, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbd, 0x01, 0x0a, 0x0c, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x06, 0x6c,
+	0x69, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
+	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x72,
+	0x52, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x5f, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x65, 0x55, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4d, 0x73, 0x22, 0x7f, 0x0a, 0x0c, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e,
+	0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x2d, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x66, 0x0a, 0x0b, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x5f, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x55, 0x73, 0x12,
+	0x22, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x4d, 0x73, 0x22, 0x75, 0x0a, 0x0d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x69, 0x72, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x44, 0x61, 0x74, 0x65, 0x22, 0x53, 0x0a, 0x0f, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x64, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x25, 0x0a,
+	0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x42,
+	0x08, 0x5a, 0x06, 0x2e, 0x3b, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_internal_explore_infrastructure_redis_cache_proto_rawDescOnce sync.Once
+	file_internal_explore_infrastructure_redis_cache_proto_rawDescData = file_internal_explore_infrastructure_redis_cache_proto_rawDesc
+)
+
+func file_internal_explore_infrastructure_redis_cache_proto_rawDescGZIP() []byte {
+	file_internal_explore_infrastructure_redis_cache_proto_rawDescOnce.Do(func() {
+		file_internal_explore_infrastructure_redis_cache_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_explore_infrastructure_redis_cache_proto_rawDescData)
+	})
+	return file_internal_explore_infrastructure_redis_cache_proto_rawDescData
+}
+
+var file_internal_explore_infrastructure_redis_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_explore_infrastructure_redis_cache_proto_goTypes = []any{
+	(*CachedLikers)(nil),               // 0: explore.CachedLikers
+	(*CachedCursor)(nil),               // 1: explore.CachedCursor
+	(*CachedCount)(nil),                // 2: explore.CachedCount
+	(*CachedProfile)(nil),              // 3: explore.CachedProfile
+	(*CachedWatermark)(nil),            // 4: explore.CachedWatermark
+	(*ListLikedYouResponse_Liker)(nil), // 5: explore.ListLikedYouResponse.Liker
+	(Decision)(0),                      // 6: explore.Decision
+}
+var file_internal_explore_infrastructure_redis_cache_proto_depIdxs = []int32{
+	5, // 0: explore.CachedLikers.likers:type_name -> explore.ListLikedYouResponse.Liker
+	1, // 1: explore.CachedLikers.cursor:type_name -> explore.CachedCursor
+	6, // 2: explore.CachedCursor.decision:type_name -> explore.Decision
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_internal_explore_infrastructure_redis_cache_proto_init() }
+func file_internal_explore_infrastructure_redis_cache_proto_init() {
+	if File_internal_explore_infrastructure_redis_cache_proto != nil {
+		return
+	}
+	file_internal_explore_adapters_grpc_explore_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_explore_infrastructure_redis_cache_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_explore_infrastructure_redis_cache_proto_goTypes,
+		DependencyIndexes: file_internal_explore_infrastructure_redis_cache_proto_depIdxs,
+		MessageInfos:      file_internal_explore_infrastructure_redis_cache_proto_msgTypes,
+	}.Build()
+	File_internal_explore_infrastructure_redis_cache_proto = out.File
+	file_internal_explore_infrastructure_redis_cache_proto_rawDesc = nil
+	file_internal_explore_infrastructure_redis_cache_proto_goTypes = nil
+	file_internal_explore_infrastructure_redis_cache_proto_depIdxs = nil
+}
@@ -9,6 +9,7 @@ package grpc
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,13 +21,177 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Decision int32
+
+const (
+	Decision_DECISION_UNSPECIFIED Decision = 0
+	Decision_DECISION_PASS        Decision = 1
+	Decision_DECISION_LIKE        Decision = 2
+	Decision_DECISION_SUPER_LIKE  Decision = 3
+)
+
+// Enum value maps for Decision.
+var (
+	Decision_name = map[int32]string{
+		0: "DECISION_UNSPECIFIED",
+		1: "DECISION_PASS",
+		2: "DECISION_LIKE",
+		3: "DECISION_SUPER_LIKE",
+	}
+	Decision_value = map[string]int32{
+		"DECISION_UNSPECIFIED": 0,
+		"DECISION_PASS":        1,
+		"DECISION_LIKE":        2,
+		"DECISION_SUPER_LIKE":  3,
+	}
+)
+
+func (x Decision) Enum() *Decision {
+	p := new(Decision)
+	*p = x
+	return p
+}
+
+func (x Decision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Decision) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_explore_adapters_grpc_explore_proto_enumTypes[0].Descriptor()
+}
+
+func (Decision) Type() protoreflect.EnumType {
+	return &file_internal_explore_adapters_grpc_explore_proto_enumTypes[0]
+}
+
+func (x Decision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Decision.Descriptor instead.
+func (Decision) EnumDescriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{0}
+}
+
+type LikerFilter int32
+
+const (
+	LikerFilter_LIKER_FILTER_UNSPECIFIED LikerFilter = 0
+	LikerFilter_LIKER_FILTER_ALL         LikerFilter = 1 // Every liker the recipient has not passed on
+	LikerFilter_LIKER_FILTER_PENDING     LikerFilter = 2 // Likers the recipient has not decided on yet
+	LikerFilter_LIKER_FILTER_MATCHED     LikerFilter = 3 // Likers the recipient liked back
+	LikerFilter_LIKER_FILTER_REJECTED    LikerFilter = 4 // Likers the recipient passed on
+)
+
+// Enum value maps for LikerFilter.
+var (
+	LikerFilter_name = map[int32]string{
+		0: "LIKER_FILTER_UNSPECIFIED",
+		1: "LIKER_FILTER_ALL",
+		2: "LIKER_FILTER_PENDING",
+		3: "LIKER_FILTER_MATCHED",
+		4: "LIKER_FILTER_REJECTED",
+	}
+	LikerFilter_value = map[string]int32{
+		"LIKER_FILTER_UNSPECIFIED": 0,
+		"LIKER_FILTER_ALL":         1,
+		"LIKER_FILTER_PENDING":     2,
+		"LIKER_FILTER_MATCHED":     3,
+		"LIKER_FILTER_REJECTED":    4,
+	}
+)
+
+func (x LikerFilter) Enum() *LikerFilter {
+	p := new(LikerFilter)
+	*p = x
+	return p
+}
+
+func (x LikerFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LikerFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_explore_adapters_grpc_explore_proto_enumTypes[1].Descriptor()
+}
+
+func (LikerFilter) Type() protoreflect.EnumType {
+	return &file_internal_explore_adapters_grpc_explore_proto_enumTypes[1]
+}
+
+func (x LikerFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LikerFilter.Descriptor instead.
+func (LikerFilter) EnumDescriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{1}
+}
+
+type AbuseReason int32
+
+const (
+	AbuseReason_ABUSE_REASON_UNSPECIFIED    AbuseReason = 0
+	AbuseReason_ABUSE_REASON_VELOCITY       AbuseReason = 1 // Liked too many users within one window
+	AbuseReason_ABUSE_REASON_LIKE_RATIO     AbuseReason = 2 // Liked nearly everyone they decided on
+	AbuseReason_ABUSE_REASON_SEQUENTIAL_IDS AbuseReason = 3 // Decided on users in ascending ID order, as when walking the feed
+)
+
+// Enum value maps for AbuseReason.
+var (
+	AbuseReason_name = map[int32]string{
+		0: "ABUSE_REASON_UNSPECIFIED",
+		1: "ABUSE_REASON_VELOCITY",
+		2: "ABUSE_REASON_LIKE_RATIO",
+		3: "ABUSE_REASON_SEQUENTIAL_IDS",
+	}
+	AbuseReason_value = map[string]int32{
+		"ABUSE_REASON_UNSPECIFIED":    0,
+		"ABUSE_REASON_VELOCITY":       1,
+		"ABUSE_REASON_LIKE_RATIO":     2,
+		"ABUSE_REASON_SEQUENTIAL_IDS": 3,
+	}
+)
+
+func (x AbuseReason) Enum() *AbuseReason {
+	p := new(AbuseReason)
+	*p = x
+	return p
+}
+
+func (x AbuseReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AbuseReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_explore_adapters_grpc_explore_proto_enumTypes[2].Descriptor()
+}
+
+func (AbuseReason) Type() protoreflect.EnumType {
+	return &file_internal_explore_adapters_grpc_explore_proto_enumTypes[2]
+}
+
+func (x AbuseReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AbuseReason.Descriptor instead.
+func (AbuseReason) EnumDescriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{2}
+}
+
 type ListLikedYouRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RecipientUserId string  `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	PaginationToken *string `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	UnseenOnly      bool                   `protobuf:"varint,3,opt,name=unseen_only,json=unseenOnly,proto3" json:"unseen_only,omitempty"`                  // Only return likes newer than the recipient's seen watermark
+	Filter          LikerFilter            `protobuf:"varint,4,opt,name=filter,proto3,enum=explore.LikerFilter" json:"filter,omitempty"`                   // Defaults to ALL for ListLikedYou and PENDING for ListNewLikedYou
+	SuperLikesFirst bool                   `protobuf:"varint,5,opt,name=super_likes_first,json=superLikesFirst,proto3" json:"super_likes_first,omitempty"` // List super-likes before likes, each newest first
+	IncludeExpired  bool                   `protobuf:"varint,6,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`      // Include likes older than the configured like lifetime; requires an admin token
+	IncludeProfile  *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=include_profile,json=includeProfile,proto3" json:"include_profile,omitempty"`       // Attach each liker's profile with these fields: name, photo_url, age
 }
 
 func (x *ListLikedYouRequest) Reset() {
@@ -73,6 +238,102 @@ func (x *ListLikedYouRequest) GetPaginationToken() string {
 	return ""
 }
 
+func (x *ListLikedYouRequest) GetUnseenOnly() bool {
+	if x != nil {
+		return x.UnseenOnly
+	}
+	return false
+}
+
+func (x *ListLikedYouRequest) GetFilter() LikerFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return LikerFilter_LIKER_FILTER_UNSPECIFIED
+}
+
+func (x *ListLikedYouRequest) GetSuperLikesFirst() bool {
+	if x != nil {
+		return x.SuperLikesFirst
+	}
+	return false
+}
+
+func (x *ListLikedYouRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+func (x *ListLikedYouRequest) GetIncludeProfile() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.IncludeProfile
+	}
+	return nil
+}
+
+type Profile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	PhotoUrl *string `protobuf:"bytes,2,opt,name=photo_url,json=photoUrl,proto3,oneof" json:"photo_url,omitempty"`
+	Age      *uint32 `protobuf:"varint,3,opt,name=age,proto3,oneof" json:"age,omitempty"`
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Profile) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetPhotoUrl() string {
+	if x != nil && x.PhotoUrl != nil {
+		return *x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *Profile) GetAge() uint32 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
 type ListLikedYouResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -84,7 +345,7 @@ type ListLikedYouResponse struct {
 
 func (x *ListLikedYouResponse) Reset() {
 	*x = ListLikedYouResponse{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[1]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -96,7 +357,7 @@ func (x *ListLikedYouResponse) String() string {
 func (*ListLikedYouResponse) ProtoMessage() {}
 
 func (x *ListLikedYouResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[1]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -109,7 +370,7 @@ func (x *ListLikedYouResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLikedYouResponse.ProtoReflect.Descriptor instead.
 func (*ListLikedYouResponse) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{1}
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ListLikedYouResponse) GetLikers() []*ListLikedYouResponse_Liker {
@@ -132,11 +393,12 @@ type CountLikedYouRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	RecipientUserId string `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	IncludeExpired  bool   `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"` // Include likes older than the configured like lifetime; requires an admin token
 }
 
 func (x *CountLikedYouRequest) Reset() {
 	*x = CountLikedYouRequest{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[2]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -148,7 +410,7 @@ func (x *CountLikedYouRequest) String() string {
 func (*CountLikedYouRequest) ProtoMessage() {}
 
 func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[2]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +423,7 @@ func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouRequest.ProtoReflect.Descriptor instead.
 func (*CountLikedYouRequest) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{2}
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CountLikedYouRequest) GetRecipientUserId() string {
@@ -171,17 +433,25 @@ func (x *CountLikedYouRequest) GetRecipientUserId() string {
 	return ""
 }
 
+func (x *CountLikedYouRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
 type CountLikedYouResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Count       uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	UnseenCount uint64 `protobuf:"varint,2,opt,name=unseen_count,json=unseenCount,proto3" json:"unseen_count,omitempty"`
 }
 
 func (x *CountLikedYouResponse) Reset() {
 	*x = CountLikedYouResponse{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[3]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -193,7 +463,7 @@ func (x *CountLikedYouResponse) String() string {
 func (*CountLikedYouResponse) ProtoMessage() {}
 
 func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[3]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +476,7 @@ func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouResponse.ProtoReflect.Descriptor instead.
 func (*CountLikedYouResponse) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{3}
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CountLikedYouResponse) GetCount() uint64 {
@@ -216,19 +486,27 @@ func (x *CountLikedYouResponse) GetCount() uint64 {
 	return 0
 }
 
+func (x *CountLikedYouResponse) GetUnseenCount() uint64 {
+	if x != nil {
+		return x.UnseenCount
+	}
+	return 0
+}
+
 type PutDecisionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorUserId     string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
-	RecipientUserId string `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	LikedRecipient  bool   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	ActorUserId     string   `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string   `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool     `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"` // Ignored when decision is set
+	Decision        Decision `protobuf:"varint,4,opt,name=decision,proto3,enum=explore.Decision" json:"decision,omitempty"`
 }
 
 func (x *PutDecisionRequest) Reset() {
 	*x = PutDecisionRequest{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[4]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -240,7 +518,7 @@ func (x *PutDecisionRequest) String() string {
 func (*PutDecisionRequest) ProtoMessage() {}
 
 func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[4]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -253,7 +531,7 @@ func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
 func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{4}
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PutDecisionRequest) GetActorUserId() string {
@@ -277,17 +555,24 @@ func (x *PutDecisionRequest) GetLikedRecipient() bool {
 	return false
 }
 
+func (x *PutDecisionRequest) GetDecision() Decision {
+	if x != nil {
+		return x.Decision
+	}
+	return Decision_DECISION_UNSPECIFIED
+}
+
 type PutDecisionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MutualLikes bool `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other
+	MutualLikes bool `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`
 }
 
 func (x *PutDecisionResponse) Reset() {
 	*x = PutDecisionResponse{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[5]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -299,7 +584,7 @@ func (x *PutDecisionResponse) String() string {
 func (*PutDecisionResponse) ProtoMessage() {}
 
 func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[5]
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +597,7 @@ func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
 func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{5}
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutDecisionResponse) GetMutualLikes() bool {
@@ -322,30 +607,31 @@ func (x *PutDecisionResponse) GetMutualLikes() bool {
 	return false
 }
 
-type ListLikedYouResponse_Liker struct {
+type MarkLikesSeenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorId       string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
-	UnixTimestamp uint64 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	RecipientUserId string `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	UpToCursor      uint64 `protobuf:"varint,2,opt,name=up_to_cursor,json=upToCursor,proto3" json:"up_to_cursor,omitempty"`     // Unix timestamp of the newest liker the recipient has seen
+	UpToActorId     string `protobuf:"bytes,3,opt,name=up_to_actor_id,json=upToActorId,proto3" json:"up_to_actor_id,omitempty"` // Actor ID of that liker, which orders likers given in the same second
 }
 
-func (x *ListLikedYouResponse_Liker) Reset() {
-	*x = ListLikedYouResponse_Liker{}
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[6]
+func (x *MarkLikesSeenRequest) Reset() {
+	*x = MarkLikesSeenRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLikedYouResponse_Liker) String() string {
+func (x *MarkLikesSeenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+func (*MarkLikesSeenRequest) ProtoMessage() {}
 
-func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[6]
+func (x *MarkLikesSeenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,21 +642,722 @@ func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
-func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
-	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use MarkLikesSeenRequest.ProtoReflect.Descriptor instead.
+func (*MarkLikesSeenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListLikedYouResponse_Liker) GetActorId() string {
+func (x *MarkLikesSeenRequest) GetRecipientUserId() string {
 	if x != nil {
-		return x.ActorId
+		return x.RecipientUserId
 	}
 	return ""
 }
 
-func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+func (x *MarkLikesSeenRequest) GetUpToCursor() uint64 {
 	if x != nil {
-		return x.UnixTimestamp
+		return x.UpToCursor
+	}
+	return 0
+}
+
+func (x *MarkLikesSeenRequest) GetUpToActorId() string {
+	if x != nil {
+		return x.UpToActorId
+	}
+	return ""
+}
+
+type MarkLikesSeenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeenUpTo        uint64 `protobuf:"varint,1,opt,name=seen_up_to,json=seenUpTo,proto3" json:"seen_up_to,omitempty"`
+	SeenUpToActorId string `protobuf:"bytes,2,opt,name=seen_up_to_actor_id,json=seenUpToActorId,proto3" json:"seen_up_to_actor_id,omitempty"`
+}
+
+func (x *MarkLikesSeenResponse) Reset() {
+	*x = MarkLikesSeenResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkLikesSeenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkLikesSeenResponse) ProtoMessage() {}
+
+func (x *MarkLikesSeenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkLikesSeenResponse.ProtoReflect.Descriptor instead.
+func (*MarkLikesSeenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MarkLikesSeenResponse) GetSeenUpTo() uint64 {
+	if x != nil {
+		return x.SeenUpTo
+	}
+	return 0
+}
+
+func (x *MarkLikesSeenResponse) GetSeenUpToActorId() string {
+	if x != nil {
+		return x.SeenUpToActorId
+	}
+	return ""
+}
+
+type GetCandidatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	PageSize    uint64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20, capped at 100
+}
+
+func (x *GetCandidatesRequest) Reset() {
+	*x = GetCandidatesRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidatesRequest) ProtoMessage() {}
+
+func (x *GetCandidatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidatesRequest.ProtoReflect.Descriptor instead.
+func (*GetCandidatesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCandidatesRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetCandidatesRequest) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetCandidatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserIds []string `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+}
+
+func (x *GetCandidatesResponse) Reset() {
+	*x = GetCandidatesResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidatesResponse) ProtoMessage() {}
+
+func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidatesResponse.ProtoReflect.Descriptor instead.
+func (*GetCandidatesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCandidatesResponse) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type EraseUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+	RequestedBy string `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // Ignored, the audit log records the name of the admin token
+	Reason      string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserRequest) ProtoMessage() {}
+
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EraseUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+func (x *EraseUserRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *EraseUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EraseUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DecisionsDeleted  uint64 `protobuf:"varint,1,opt,name=decisions_deleted,json=decisionsDeleted,proto3" json:"decisions_deleted,omitempty"`
+	CountersRecounted uint64 `protobuf:"varint,2,opt,name=counters_recounted,json=countersRecounted,proto3" json:"counters_recounted,omitempty"`
+}
+
+func (x *EraseUserResponse) Reset() {
+	*x = EraseUserResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserResponse) ProtoMessage() {}
+
+func (x *EraseUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EraseUserResponse) GetDecisionsDeleted() uint64 {
+	if x != nil {
+		return x.DecisionsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserResponse) GetCountersRecounted() uint64 {
+	if x != nil {
+		return x.CountersRecounted
+	}
+	return 0
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+	RequestedBy string `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // Ignored, the audit log records the name of the admin token
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+func (x *ExportUserDataRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JsonLines []byte `protobuf:"bytes,1,opt,name=json_lines,json=jsonLines,proto3" json:"json_lines,omitempty"` // One or more newline-terminated JSON objects
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExportUserDataResponse) GetJsonLines() []byte {
+	if x != nil {
+		return x.JsonLines
+	}
+	return nil
+}
+
+type ListAbuseFlagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize uint64 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 100, capped at 1000
+}
+
+func (x *ListAbuseFlagsRequest) Reset() {
+	*x = ListAbuseFlagsRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAbuseFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAbuseFlagsRequest) ProtoMessage() {}
+
+func (x *ListAbuseFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAbuseFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListAbuseFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListAbuseFlagsRequest) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListAbuseFlagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flags []*ListAbuseFlagsResponse_AbuseFlag `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *ListAbuseFlagsResponse) Reset() {
+	*x = ListAbuseFlagsResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAbuseFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAbuseFlagsResponse) ProtoMessage() {}
+
+func (x *ListAbuseFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAbuseFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListAbuseFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListAbuseFlagsResponse) GetFlags() []*ListAbuseFlagsResponse_AbuseFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type ClearAbuseFlagRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+	RequestedBy string `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // Ignored, the audit log records the name of the admin token
+	Reason      string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ClearAbuseFlagRequest) Reset() {
+	*x = ClearAbuseFlagRequest{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearAbuseFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearAbuseFlagRequest) ProtoMessage() {}
+
+func (x *ClearAbuseFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearAbuseFlagRequest.ProtoReflect.Descriptor instead.
+func (*ClearAbuseFlagRequest) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClearAbuseFlagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in internal/explore/adapters/grpc/explore.proto.
+func (x *ClearAbuseFlagRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *ClearAbuseFlagRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ClearAbuseFlagResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearAbuseFlagResponse) Reset() {
+	*x = ClearAbuseFlagResponse{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearAbuseFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearAbuseFlagResponse) ProtoMessage() {}
+
+func (x *ClearAbuseFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearAbuseFlagResponse.ProtoReflect.Descriptor instead.
+func (*ClearAbuseFlagResponse) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{18}
+}
+
+type ListLikedYouResponse_Liker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId       string   `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64   `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	Decision      Decision `protobuf:"varint,3,opt,name=decision,proto3,enum=explore.Decision" json:"decision,omitempty"`
+	Profile       *Profile `protobuf:"bytes,4,opt,name=profile,proto3,oneof" json:"profile,omitempty"` // Set when include_profile is given and the liker has a profile
+}
+
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListLikedYouResponse_Liker) GetDecision() Decision {
+	if x != nil {
+		return x.Decision
+	}
+	return Decision_DECISION_UNSPECIFIED
+}
+
+func (x *ListLikedYouResponse_Liker) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type ListAbuseFlagsResponse_AbuseFlag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string      `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason    AbuseReason `protobuf:"varint,2,opt,name=reason,proto3,enum=explore.AbuseReason" json:"reason,omitempty"`
+	Details   string      `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	FlaggedAt uint64      `protobuf:"varint,4,opt,name=flagged_at,json=flaggedAt,proto3" json:"flagged_at,omitempty"` // Unix timestamp
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) Reset() {
+	*x = ListAbuseFlagsResponse_AbuseFlag{}
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAbuseFlagsResponse_AbuseFlag) ProtoMessage() {}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_explore_adapters_grpc_explore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAbuseFlagsResponse_AbuseFlag.ProtoReflect.Descriptor instead.
+func (*ListAbuseFlagsResponse_AbuseFlag) Descriptor() ([]byte, []int) {
+	return file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) GetReason() AbuseReason {
+	if x != nil {
+		return x.Reason
+	}
+	return AbuseReason_ABUSE_REASON_UNSPECIFIED
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *ListAbuseFlagsResponse_AbuseFlag) GetFlaggedAt() uint64 {
+	if x != nil {
+		return x.FlaggedAt
 	}
 	return 0
 }
@@ -381,38 +1368,74 @@ var file_internal_explore_adapters_grpc_explore_proto_rawDesc = []byte{
 	0x0a, 0x2c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x6f,
 	0x72, 0x65, 0x2f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63,
 	0x2f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
-	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
-	0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69,
-	0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x10, 0x70,
-	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f,
-	0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0xf1, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f,
-	0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x69, 0x6b,
-	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x78, 0x70, 0x6c,
-	0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x52, 0x06,
-	0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
-	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a,
-	0x49, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f,
-	0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69,
-	0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e,
-	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b,
-	0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11,
-	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
-	0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x74, 0x44,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef, 0x02, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a,
+	0x10, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a,
+	0x0b, 0x75, 0x6e, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x75, 0x6e, 0x73, 0x65, 0x65, 0x6e, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x2c,
+	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x11,
+	0x73, 0x75, 0x70, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x65, 0x72, 0x4c, 0x69,
+	0x6b, 0x65, 0x73, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x64, 0x12, 0x43, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x7a, 0x0a, 0x07, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x20, 0x0a, 0x09, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x55, 0x72, 0x6c, 0x88, 0x01,
+	0x01, 0x12, 0x15, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02,
+	0x52, 0x03, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x42,
+	0x06, 0x0a, 0x04, 0x5f, 0x61, 0x67, 0x65, 0x22, 0xde, 0x02, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c,
+	0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4c, 0x69, 0x6b, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a,
+	0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x13,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a, 0xb5, 0x01, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x72,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x75,
+	0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x44,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x2f, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x88,
+	0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x18,
+	0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6b, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x22, 0x50, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69,
+	0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x6e, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x75, 0x6e, 0x73, 0x65,
+	0x65, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xbc, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x74, 0x44,
 	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
 	0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72,
@@ -421,32 +1444,163 @@ var file_internal_explore_adapters_grpc_explore_proto_rawDesc = []byte{
 	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27,
 	0x0a, 0x0f, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
 	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65,
-	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x44, 0x65,
-	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21,
-	0x0a, 0x0c, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65,
-	0x73, 0x32, 0xc7, 0x02, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65,
-	0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x65, 0x77, 0x4c, 0x69, 0x6b, 0x65,
-	0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
-	0x6f, 0x75, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x48, 0x0a, 0x0b, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65,
-	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
-	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a, 0x06, 0x2e,
-	0x3b, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73,
+	0x22, 0x89, 0x01, 0x0a, 0x14, 0x4d, 0x61, 0x72, 0x6b, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x53, 0x65,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x70, 0x5f, 0x74, 0x6f, 0x5f, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x75, 0x70, 0x54,
+	0x6f, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0e, 0x75, 0x70, 0x5f, 0x74, 0x6f,
+	0x5f, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x75, 0x70, 0x54, 0x6f, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x63, 0x0a, 0x15,
+	0x4d, 0x61, 0x72, 0x6b, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x53, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x75, 0x70,
+	0x5f, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x65, 0x6e, 0x55,
+	0x70, 0x54, 0x6f, 0x12, 0x2c, 0x0a, 0x13, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x75, 0x70, 0x5f, 0x74,
+	0x6f, 0x5f, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x73, 0x65, 0x65, 0x6e, 0x55, 0x70, 0x54, 0x6f, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49,
+	0x64, 0x22, 0x57, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x32, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x6a,
+	0x0a, 0x10, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x42, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x6f, 0x0a, 0x11, 0x45, 0x72,
+	0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2b, 0x0a, 0x11, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x22, 0x57, 0x0a, 0x15, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x42, 0x79, 0x22, 0x37, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x34, 0x0a,
+	0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73,
+	0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f,
+	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73,
+	0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41,
+	0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x1a,
+	0x8b, 0x01, 0x0a, 0x09, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
+	0x2e, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6f, 0x0a,
+	0x15, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x25, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x18,
+	0x0a, 0x16, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x63, 0x0a, 0x08, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x10,
+	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49,
+	0x4b, 0x45, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x55, 0x50, 0x45, 0x52, 0x5f, 0x4c, 0x49, 0x4b, 0x45, 0x10, 0x03, 0x2a, 0x90, 0x01,
+	0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a,
+	0x18, 0x4c, 0x49, 0x4b, 0x45, 0x52, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4c,
+	0x49, 0x4b, 0x45, 0x52, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x41, 0x4c, 0x4c, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x49, 0x4b, 0x45, 0x52, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45,
+	0x52, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x4c,
+	0x49, 0x4b, 0x45, 0x52, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x49, 0x4b, 0x45, 0x52, 0x5f, 0x46,
+	0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x04,
+	0x2a, 0x84, 0x01, 0x0a, 0x0b, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x18, 0x41, 0x42, 0x55, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x41, 0x42, 0x55, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x56,
+	0x45, 0x4c, 0x4f, 0x43, 0x49, 0x54, 0x59, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x42, 0x55,
+	0x53, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x4b, 0x45, 0x5f, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x42, 0x55, 0x53, 0x45, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x54, 0x49, 0x41,
+	0x4c, 0x5f, 0x49, 0x44, 0x53, 0x10, 0x03, 0x32, 0xa6, 0x06, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f,
+	0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4e,
+	0x65, 0x77, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f,
+	0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x50, 0x75, 0x74, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
+	0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x4d, 0x61, 0x72, 0x6b, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x53, 0x65,
+	0x65, 0x6e, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x61, 0x72,
+	0x6b, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x53, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
+	0x4c, 0x69, 0x6b, 0x65, 0x73, 0x53, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x42, 0x0a, 0x09, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x19,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x0e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x12,
+	0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41,
+	0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41,
+	0x62, 0x75, 0x73, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x08, 0x5a, 0x06, 0x2e, 0x3b, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -461,31 +1615,69 @@ func file_internal_explore_adapters_grpc_explore_proto_rawDescGZIP() []byte {
 	return file_internal_explore_adapters_grpc_explore_proto_rawDescData
 }
 
-var file_internal_explore_adapters_grpc_explore_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_internal_explore_adapters_grpc_explore_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_internal_explore_adapters_grpc_explore_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_internal_explore_adapters_grpc_explore_proto_goTypes = []any{
-	(*ListLikedYouRequest)(nil),        // 0: explore.ListLikedYouRequest
-	(*ListLikedYouResponse)(nil),       // 1: explore.ListLikedYouResponse
-	(*CountLikedYouRequest)(nil),       // 2: explore.CountLikedYouRequest
-	(*CountLikedYouResponse)(nil),      // 3: explore.CountLikedYouResponse
-	(*PutDecisionRequest)(nil),         // 4: explore.PutDecisionRequest
-	(*PutDecisionResponse)(nil),        // 5: explore.PutDecisionResponse
-	(*ListLikedYouResponse_Liker)(nil), // 6: explore.ListLikedYouResponse.Liker
+	(Decision)(0),                            // 0: explore.Decision
+	(LikerFilter)(0),                         // 1: explore.LikerFilter
+	(AbuseReason)(0),                         // 2: explore.AbuseReason
+	(*ListLikedYouRequest)(nil),              // 3: explore.ListLikedYouRequest
+	(*Profile)(nil),                          // 4: explore.Profile
+	(*ListLikedYouResponse)(nil),             // 5: explore.ListLikedYouResponse
+	(*CountLikedYouRequest)(nil),             // 6: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),            // 7: explore.CountLikedYouResponse
+	(*PutDecisionRequest)(nil),               // 8: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),              // 9: explore.PutDecisionResponse
+	(*MarkLikesSeenRequest)(nil),             // 10: explore.MarkLikesSeenRequest
+	(*MarkLikesSeenResponse)(nil),            // 11: explore.MarkLikesSeenResponse
+	(*GetCandidatesRequest)(nil),             // 12: explore.GetCandidatesRequest
+	(*GetCandidatesResponse)(nil),            // 13: explore.GetCandidatesResponse
+	(*EraseUserRequest)(nil),                 // 14: explore.EraseUserRequest
+	(*EraseUserResponse)(nil),                // 15: explore.EraseUserResponse
+	(*ExportUserDataRequest)(nil),            // 16: explore.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),           // 17: explore.ExportUserDataResponse
+	(*ListAbuseFlagsRequest)(nil),            // 18: explore.ListAbuseFlagsRequest
+	(*ListAbuseFlagsResponse)(nil),           // 19: explore.ListAbuseFlagsResponse
+	(*ClearAbuseFlagRequest)(nil),            // 20: explore.ClearAbuseFlagRequest
+	(*ClearAbuseFlagResponse)(nil),           // 21: explore.ClearAbuseFlagResponse
+	(*ListLikedYouResponse_Liker)(nil),       // 22: explore.ListLikedYouResponse.Liker
+	(*ListAbuseFlagsResponse_AbuseFlag)(nil), // 23: explore.ListAbuseFlagsResponse.AbuseFlag
+	(*fieldmaskpb.FieldMask)(nil),            // 24: google.protobuf.FieldMask
 }
 var file_internal_explore_adapters_grpc_explore_proto_depIdxs = []int32{
-	6, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
-	0, // 1: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
-	0, // 2: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
-	2, // 3: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
-	4, // 4: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
-	1, // 5: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
-	1, // 6: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
-	3, // 7: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
-	5, // 8: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: explore.ListLikedYouRequest.filter:type_name -> explore.LikerFilter
+	24, // 1: explore.ListLikedYouRequest.include_profile:type_name -> google.protobuf.FieldMask
+	22, // 2: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	0,  // 3: explore.PutDecisionRequest.decision:type_name -> explore.Decision
+	23, // 4: explore.ListAbuseFlagsResponse.flags:type_name -> explore.ListAbuseFlagsResponse.AbuseFlag
+	0,  // 5: explore.ListLikedYouResponse.Liker.decision:type_name -> explore.Decision
+	4,  // 6: explore.ListLikedYouResponse.Liker.profile:type_name -> explore.Profile
+	2,  // 7: explore.ListAbuseFlagsResponse.AbuseFlag.reason:type_name -> explore.AbuseReason
+	3,  // 8: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	3,  // 9: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	6,  // 10: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	8,  // 11: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	10, // 12: explore.ExploreService.MarkLikesSeen:input_type -> explore.MarkLikesSeenRequest
+	12, // 13: explore.ExploreService.GetCandidates:input_type -> explore.GetCandidatesRequest
+	14, // 14: explore.ExploreService.EraseUser:input_type -> explore.EraseUserRequest
+	16, // 15: explore.ExploreService.ExportUserData:input_type -> explore.ExportUserDataRequest
+	18, // 16: explore.ExploreService.ListAbuseFlags:input_type -> explore.ListAbuseFlagsRequest
+	20, // 17: explore.ExploreService.ClearAbuseFlag:input_type -> explore.ClearAbuseFlagRequest
+	5,  // 18: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	5,  // 19: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	7,  // 20: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	9,  // 21: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	11, // 22: explore.ExploreService.MarkLikesSeen:output_type -> explore.MarkLikesSeenResponse
+	13, // 23: explore.ExploreService.GetCandidates:output_type -> explore.GetCandidatesResponse
+	15, // 24: explore.ExploreService.EraseUser:output_type -> explore.EraseUserResponse
+	17, // 25: explore.ExploreService.ExportUserData:output_type -> explore.ExportUserDataResponse
+	19, // 26: explore.ExploreService.ListAbuseFlags:output_type -> explore.ListAbuseFlagsResponse
+	21, // 27: explore.ExploreService.ClearAbuseFlag:output_type -> explore.ClearAbuseFlagResponse
+	18, // [18:28] is the sub-list for method output_type
+	8,  // [8:18] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_internal_explore_adapters_grpc_explore_proto_init() }
@@ -495,18 +1687,21 @@ func file_internal_explore_adapters_grpc_explore_proto_init() {
 	}
 	file_internal_explore_adapters_grpc_explore_proto_msgTypes[0].OneofWrappers = []any{}
 	file_internal_explore_adapters_grpc_explore_proto_msgTypes[1].OneofWrappers = []any{}
+	file_internal_explore_adapters_grpc_explore_proto_msgTypes[2].OneofWrappers = []any{}
+	file_internal_explore_adapters_grpc_explore_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_explore_adapters_grpc_explore_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_internal_explore_adapters_grpc_explore_proto_goTypes,
 		DependencyIndexes: file_internal_explore_adapters_grpc_explore_proto_depIdxs,
+		EnumInfos:         file_internal_explore_adapters_grpc_explore_proto_enumTypes,
 		MessageInfos:      file_internal_explore_adapters_grpc_explore_proto_msgTypes,
 	}.Build()
 	File_internal_explore_adapters_grpc_explore_proto = out.File
@@ -23,6 +23,12 @@ const (
 	ExploreService_ListNewLikedYou_FullMethodName = "/explore.ExploreService/ListNewLikedYou"
 	ExploreService_CountLikedYou_FullMethodName   = "/explore.ExploreService/CountLikedYou"
 	ExploreService_PutDecision_FullMethodName     = "/explore.ExploreService/PutDecision"
+	ExploreService_MarkLikesSeen_FullMethodName   = "/explore.ExploreService/MarkLikesSeen"
+	ExploreService_GetCandidates_FullMethodName   = "/explore.ExploreService/GetCandidates"
+	ExploreService_EraseUser_FullMethodName       = "/explore.ExploreService/EraseUser"
+	ExploreService_ExportUserData_FullMethodName  = "/explore.ExploreService/ExportUserData"
+	ExploreService_ListAbuseFlags_FullMethodName  = "/explore.ExploreService/ListAbuseFlags"
+	ExploreService_ClearAbuseFlag_FullMethodName  = "/explore.ExploreService/ClearAbuseFlag"
 )
 
 // ExploreServiceClient is the client API for ExploreService service.
@@ -33,6 +39,12 @@ type ExploreServiceClient interface {
 	ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
 	CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
 	PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error)
+	MarkLikesSeen(ctx context.Context, in *MarkLikesSeenRequest, opts ...grpc.CallOption) (*MarkLikesSeenResponse, error)
+	GetCandidates(ctx context.Context, in *GetCandidatesRequest, opts ...grpc.CallOption) (*GetCandidatesResponse, error)
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error)
+	ListAbuseFlags(ctx context.Context, in *ListAbuseFlagsRequest, opts ...grpc.CallOption) (*ListAbuseFlagsResponse, error)
+	ClearAbuseFlag(ctx context.Context, in *ClearAbuseFlagRequest, opts ...grpc.CallOption) (*ClearAbuseFlagResponse, error)
 }
 
 type exploreServiceClient struct {
@@ -83,6 +95,75 @@ func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionR
 	return out, nil
 }
 
+func (c *exploreServiceClient) MarkLikesSeen(ctx context.Context, in *MarkLikesSeenRequest, opts ...grpc.CallOption) (*MarkLikesSeenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkLikesSeenResponse)
+	err := c.cc.Invoke(ctx, ExploreService_MarkLikesSeen_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetCandidates(ctx context.Context, in *GetCandidatesRequest, opts ...grpc.CallOption) (*GetCandidatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCandidatesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetCandidates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_EraseUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[0], ExploreService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, ExportUserDataResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_ExportUserDataClient = grpc.ServerStreamingClient[ExportUserDataResponse]
+
+func (c *exploreServiceClient) ListAbuseFlags(ctx context.Context, in *ListAbuseFlagsRequest, opts ...grpc.CallOption) (*ListAbuseFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAbuseFlagsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListAbuseFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ClearAbuseFlag(ctx context.Context, in *ClearAbuseFlagRequest, opts ...grpc.CallOption) (*ClearAbuseFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearAbuseFlagResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ClearAbuseFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExploreServiceServer is the server API for ExploreService service.
 // All implementations must embed UnimplementedExploreServiceServer
 // for forward compatibility.
@@ -91,6 +172,12 @@ type ExploreServiceServer interface {
 	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
 	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
 	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+	MarkLikesSeen(context.Context, *MarkLikesSeenRequest) (*MarkLikesSeenResponse, error)
+	GetCandidates(context.Context, *GetCandidatesRequest) (*GetCandidatesResponse, error)
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error
+	ListAbuseFlags(context.Context, *ListAbuseFlagsRequest) (*ListAbuseFlagsResponse, error)
+	ClearAbuseFlag(context.Context, *ClearAbuseFlagRequest) (*ClearAbuseFlagResponse, error)
 	mustEmbedUnimplementedExploreServiceServer()
 }
 
@@ -113,6 +200,24 @@ func (UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLi
 func (UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
 }
+func (UnimplementedExploreServiceServer) MarkLikesSeen(context.Context, *MarkLikesSeenRequest) (*MarkLikesSeenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkLikesSeen not implemented")
+}
+func (UnimplementedExploreServiceServer) GetCandidates(context.Context, *GetCandidatesRequest) (*GetCandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidates not implemented")
+}
+func (UnimplementedExploreServiceServer) EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedExploreServiceServer) ListAbuseFlags(context.Context, *ListAbuseFlagsRequest) (*ListAbuseFlagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAbuseFlags not implemented")
+}
+func (UnimplementedExploreServiceServer) ClearAbuseFlag(context.Context, *ClearAbuseFlagRequest) (*ClearAbuseFlagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearAbuseFlag not implemented")
+}
 func (UnimplementedExploreServiceServer) mustEmbedUnimplementedExploreServiceServer() {}
 func (UnimplementedExploreServiceServer) testEmbeddedByValue()                        {}
 
@@ -206,6 +311,107 @@ func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExploreService_MarkLikesSeen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkLikesSeenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).MarkLikesSeen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_MarkLikesSeen_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).MarkLikesSeen(ctx, req.(*MarkLikesSeenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetCandidates(ctx, req.(*GetCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_EraseUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, ExportUserDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_ExportUserDataServer = grpc.ServerStreamingServer[ExportUserDataResponse]
+
+func _ExploreService_ListAbuseFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAbuseFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListAbuseFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListAbuseFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListAbuseFlags(ctx, req.(*ListAbuseFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ClearAbuseFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearAbuseFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ClearAbuseFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ClearAbuseFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ClearAbuseFlag(ctx, req.(*ClearAbuseFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +435,33 @@ var ExploreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutDecision",
 			Handler:    _ExploreService_PutDecision_Handler,
 		},
+		{
+			MethodName: "MarkLikesSeen",
+			Handler:    _ExploreService_MarkLikesSeen_Handler,
+		},
+		{
+			MethodName: "GetCandidates",
+			Handler:    _ExploreService_GetCandidates_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _ExploreService_EraseUser_Handler,
+		},
+		{
+			MethodName: "ListAbuseFlags",
+			Handler:    _ExploreService_ListAbuseFlags_Handler,
+		},
+		{
+			MethodName: "ClearAbuseFlag",
+			Handler:    _ExploreService_ClearAbuseFlag_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _ExploreService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/explore/adapters/grpc/explore.proto",
 }
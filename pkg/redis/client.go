@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"os"
+)
+
+const (
+	ModeStandalone = "standalone"
+	ModeCluster    = "cluster"
+	ModeSentinel   = "sentinel"
+)
+
+type Config struct {
+	// Mode is ModeStandalone, ModeCluster or ModeSentinel. Empty means
+	// standalone.
+	Mode string
+	// Addrs is the server address in standalone mode, the seed nodes in
+	// cluster mode and the sentinels in sentinel mode.
+	Addrs []string
+	// MasterName is the monitored master set in sentinel mode.
+	MasterName       string
+	Username         string
+	Password         string
+	SentinelUsername string
+	SentinelPassword string
+	// DB is ignored by Redis Cluster, which only has DB 0.
+	DB  int
+	TLS TLSConfig
+}
+
+type TLSConfig struct {
+	Enabled bool
+	// CAFile verifies the servers instead of the system roots.
+	CAFile string
+	// CertFile and KeyFile are presented when the servers require client
+	// certificates.
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+func NewUniversalClient(config Config) (goredis.UniversalClient, error) {
+	if len(config.Addrs) == 0 {
+		return nil, errors.New("no redis addresses configured")
+	}
+
+	options := &goredis.UniversalOptions{
+		Addrs:            config.Addrs,
+		MasterName:       config.MasterName,
+		Username:         config.Username,
+		Password:         config.Password,
+		SentinelUsername: config.SentinelUsername,
+		SentinelPassword: config.SentinelPassword,
+		DB:               config.DB,
+	}
+
+	if config.TLS.Enabled {
+		tlsConfig, err := loadTLS(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
+	}
+
+	switch config.Mode {
+	case "", ModeStandalone:
+		if len(config.Addrs) != 1 {
+			return nil, fmt.Errorf("standalone mode takes one address, got %d", len(config.Addrs))
+		}
+		return goredis.NewClient(options.Simple()), nil
+	case ModeCluster:
+		return goredis.NewClusterClient(options.Cluster()), nil
+	case ModeSentinel:
+		if config.MasterName == "" {
+			return nil, errors.New("sentinel mode needs a master name")
+		}
+		return goredis.NewFailoverClient(options.Failover()), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", config.Mode)
+	}
+}
+
+func loadTLS(config TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: config.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if config.CAFile != "" {
+		data, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("failed to parse CA file: no certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
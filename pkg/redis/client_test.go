@@ -0,0 +1,175 @@
+package redis
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewUniversalClient(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("app", "secret")
+
+	tests := []struct {
+		name   string
+		config Config
+		want   any
+	}{
+		{
+			name:   "standalone by default",
+			config: Config{Addrs: []string{server.Addr()}, Username: "app", Password: "secret"},
+			want:   &goredis.Client{},
+		},
+		{
+			name:   "cluster",
+			config: Config{Mode: ModeCluster, Addrs: []string{server.Addr()}, Username: "app", Password: "secret"},
+			want:   &goredis.ClusterClient{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewUniversalClient(tt.config)
+			require.NoError(t, err)
+			defer client.Close()
+
+			assert.IsType(t, tt.want, client)
+
+			ctx := context.Background()
+			require.NoError(t, client.Set(ctx, "{user1}:key", "value", time.Minute).Err())
+			value, err := client.Get(ctx, "{user1}:key").Result()
+			require.NoError(t, err)
+			assert.Equal(t, "value", value)
+		})
+	}
+}
+
+func TestNewUniversalClient_WrongPassword(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	client, err := NewUniversalClient(Config{Addrs: []string{server.Addr()}, Password: "wrong"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.Error(t, client.Ping(context.Background()).Err())
+}
+
+func TestNewUniversalClient_TLS(t *testing.T) {
+	caFile, serverCert := newTestCA(t)
+
+	server := miniredis.NewMiniRedis()
+	require.NoError(t, server.StartTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}}))
+	t.Cleanup(server.Close)
+
+	client, err := NewUniversalClient(Config{
+		Addrs: []string{server.Addr()},
+		TLS:   TLSConfig{Enabled: true, CAFile: caFile, ServerName: "localhost"},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.Ping(context.Background()).Err())
+
+	// Without the CA the server certificate is rejected.
+	untrusted, err := NewUniversalClient(Config{
+		Addrs: []string{server.Addr()},
+		TLS:   TLSConfig{Enabled: true, ServerName: "localhost"},
+	})
+	require.NoError(t, err)
+	defer untrusted.Close()
+
+	assert.Error(t, untrusted.Ping(context.Background()).Err())
+}
+
+func TestNewUniversalClient_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "no addresses",
+			config: Config{},
+		},
+		{
+			name:   "standalone with several addresses",
+			config: Config{Addrs: []string{"a:6379", "b:6379"}},
+		},
+		{
+			name:   "sentinel without master name",
+			config: Config{Mode: ModeSentinel, Addrs: []string{"a:26379"}},
+		},
+		{
+			name:   "unknown mode",
+			config: Config{Mode: "replicated", Addrs: []string{"a:6379"}},
+		},
+		{
+			name:   "missing CA file",
+			config: Config{Addrs: []string{"a:6379"}, TLS: TLSConfig{Enabled: true, CAFile: "missing.pem"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUniversalClient(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// newTestCA writes a CA certificate to a temporary file and returns it with a
+// server certificate for localhost signed by it.
+func newTestCA(t *testing.T) (string, tls.Certificate) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+
+	return caFile, tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}
+}
@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ConfigFromEnv reads the REDIS_* connection settings shared by the API and
+// the admin tool, so both always reach the same servers.
+func ConfigFromEnv() (Config, error) {
+	db, err := strconv.Atoi(getEnvOrDefault("REDIS_DB", "0"))
+	if err != nil || db < 0 {
+		return Config{}, fmt.Errorf("REDIS_DB must be a non-negative integer, got %q", os.Getenv("REDIS_DB"))
+	}
+
+	return Config{
+		Mode:             getEnvOrDefault("REDIS_MODE", ModeStandalone),
+		Addrs:            strings.Split(getEnvOrDefault("REDIS_ADDR", "redis:6379"), ","),
+		MasterName:       os.Getenv("REDIS_MASTER_NAME"),
+		Username:         os.Getenv("REDIS_USERNAME"),
+		Password:         os.Getenv("REDIS_PASSWORD"),
+		SentinelUsername: os.Getenv("REDIS_SENTINEL_USERNAME"),
+		SentinelPassword: os.Getenv("REDIS_SENTINEL_PASSWORD"),
+		DB:               db,
+		TLS: TLSConfig{
+			Enabled:    os.Getenv("REDIS_TLS_ENABLED") == "true",
+			CAFile:     os.Getenv("REDIS_TLS_CA_FILE"),
+			CertFile:   os.Getenv("REDIS_TLS_CERT_FILE"),
+			KeyFile:    os.Getenv("REDIS_TLS_KEY_FILE"),
+			ServerName: os.Getenv("REDIS_TLS_SERVER_NAME"),
+		},
+	}, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
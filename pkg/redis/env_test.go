@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: Config{Mode: ModeStandalone, Addrs: []string{"redis:6379"}},
+		},
+		{
+			name: "sentinel with TLS",
+			env: map[string]string{
+				"REDIS_MODE":              ModeSentinel,
+				"REDIS_ADDR":              "s1:26379,s2:26379",
+				"REDIS_MASTER_NAME":       "primary",
+				"REDIS_USERNAME":          "app",
+				"REDIS_PASSWORD":          "secret",
+				"REDIS_SENTINEL_PASSWORD": "sentinel-secret",
+				"REDIS_DB":                "2",
+				"REDIS_TLS_ENABLED":       "true",
+				"REDIS_TLS_CA_FILE":       "/certs/ca.pem",
+				"REDIS_TLS_SERVER_NAME":   "redis.internal",
+			},
+			want: Config{
+				Mode:             ModeSentinel,
+				Addrs:            []string{"s1:26379", "s2:26379"},
+				MasterName:       "primary",
+				Username:         "app",
+				Password:         "secret",
+				SentinelPassword: "sentinel-secret",
+				DB:               2,
+				TLS:              TLSConfig{Enabled: true, CAFile: "/certs/ca.pem", ServerName: "redis.internal"},
+			},
+		},
+		{
+			name:    "invalid DB",
+			env:     map[string]string{"REDIS_DB": "one"},
+			wantErr: `REDIS_DB must be a non-negative integer, got "one"`,
+		},
+		{
+			name:    "negative DB",
+			env:     map[string]string{"REDIS_DB": "-1"},
+			wantErr: `REDIS_DB must be a non-negative integer, got "-1"`,
+		},
+	}
+
+	keys := []string{"REDIS_MODE", "REDIS_ADDR", "REDIS_MASTER_NAME", "REDIS_USERNAME", "REDIS_PASSWORD",
+		"REDIS_SENTINEL_USERNAME", "REDIS_SENTINEL_PASSWORD", "REDIS_DB", "REDIS_TLS_ENABLED",
+		"REDIS_TLS_CA_FILE", "REDIS_TLS_CERT_FILE", "REDIS_TLS_KEY_FILE", "REDIS_TLS_SERVER_NAME"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			got, err := ConfigFromEnv()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}